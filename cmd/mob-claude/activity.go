@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/activity"
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/spf13/cobra"
+)
+
+var activityInterval time.Duration
+
+func newActivityCmd() *cobra.Command {
+	activityCmd := &cobra.Command{
+		Use:   "activity",
+		Short: "Show or record the current rotation's activity",
+		Long: `Tracks what happened during a rotation, beyond the final diff: commits made,
+files saved (with 'activity watch' running), and test runs (through
+'activity test' or a hook calling 'activity record'). The log is passed to
+Claude with the rotation summary prompt and cleared at handoff.
+
+Example: mob-claude activity test -- go test ./...
+Example: mob-claude activity record test "npm test: 2 failing"`,
+		Args: cobra.NoArgs,
+		RunE: runActivityShow,
+	}
+
+	recordCmd := &cobra.Command{
+		Use:   "record <kind> [detail...]",
+		Short: "Record an activity event, e.g. from a test or editor hook",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runActivityRecord,
+	}
+
+	testCmd := &cobra.Command{
+		Use:   "test -- <command...>",
+		Short: "Run a test command and record whether it passed",
+		Args:  cobra.MinimumNArgs(1),
+		// A failing test run is reported by the command itself
+		SilenceUsage: true,
+		RunE:         runActivityTest,
+	}
+
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Record file saves until interrupted",
+		Args:  cobra.NoArgs,
+		RunE:  runActivityWatch,
+	}
+	watchCmd.Flags().DurationVar(&activityInterval, "interval", 2*time.Second, "How often to check for saved files")
+
+	activityCmd.AddCommand(recordCmd, testCmd, watchCmd)
+	return activityCmd
+}
+
+func runActivityShow(cmd *cobra.Command, args []string) error {
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return fmt.Errorf("no active mob session. Run 'mob-claude start' first")
+	}
+
+	events := rotationEvents(session, mob.NewWrapper())
+	setResult(events)
+	if len(events) == 0 {
+		i18n.Println("No activity recorded for this rotation yet")
+		return nil
+	}
+	fmt.Print(activity.Format(events))
+	return nil
+}
+
+func runActivityRecord(cmd *cobra.Command, args []string) error {
+	return recordActivity(args[0], strings.Join(args[1:], " "))
+}
+
+func runActivityTest(cmd *cobra.Command, args []string) error {
+	started := clk.Now()
+	test := exec.Command(args[0], args[1:]...)
+	test.Stdin, test.Stdout, test.Stderr = os.Stdin, os.Stdout, os.Stderr
+	runErr := test.Run()
+
+	result := "passed"
+	if runErr != nil {
+		result = "failed"
+	}
+	detail := fmt.Sprintf("%s: %s in %s", strings.Join(args, " "), result, clk.Now().Sub(started).Round(time.Second))
+	if err := recordActivity(activity.KindTest, detail); err != nil {
+		i18n.Printf("Warning: could not record activity: %v\n", err)
+	}
+	if runErr != nil {
+		return fmt.Errorf("%s %s: %w", args[0], result, runErr)
+	}
+	return nil
+}
+
+func runActivityWatch(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	i18n.Println("Recording file saves for the rotation summary (Ctrl-C to stop)...")
+	return activity.Watch(ctx, ".", activityInterval, func(path string) {
+		if err := recordActivity(activity.KindSave, path); err != nil {
+			i18n.Printf("Warning: could not record activity: %v\n", err)
+		}
+	})
+}
+
+// newActivityLog returns the project's activity log
+func newActivityLog() (*activity.Log, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return activity.New(dir), nil
+}
+
+// recordActivity appends an event to the activity log
+func recordActivity(kind, detail string) error {
+	log, err := newActivityLog()
+	if err != nil {
+		return err
+	}
+	return log.Record(activity.Event{Time: clk.Now(), Kind: kind, Detail: detail})
+}
+
+// rotationActivity renders the activity since the session's rotation
+// started: the logged events plus the commits made in the meantime
+func rotationActivity(session *config.CurrentSession, mobWrapper *mob.Wrapper) string {
+	return activity.Format(rotationEvents(session, mobWrapper))
+}
+
+// rotationEvents is the activity since the session's rotation started, oldest
+// first
+func rotationEvents(session *config.CurrentSession, mobWrapper *mob.Wrapper) []activity.Event {
+	started, err := time.Parse(time.RFC3339, session.StartedAt)
+	if err != nil {
+		return nil
+	}
+
+	var events []activity.Event
+	if log, err := newActivityLog(); err == nil {
+		events, _ = log.Load(started)
+	}
+	if commits, err := mobWrapper.CommitsSince(started); err == nil {
+		for _, c := range commits {
+			events = append(events, activity.Event{Time: c.Time, Kind: activity.KindCommit, Detail: c.Hash + " " + c.Subject})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
+
+// clearActivity empties the activity log once the rotation is handed off
+func clearActivity() {
+	log, err := newActivityLog()
+	if err != nil {
+		return
+	}
+	if err := log.Clear(); err != nil {
+		i18n.Printf("Warning: could not clear activity log: %v\n", err)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	amendDriver string
+	amendNote   string
+	amendTLDR   string
+	amendTags   []string
+	amendBranch string
+)
+
+func newAmendLastCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "amend-last",
+		Short: "Correct the last rotation's driver, note, TL;DR, or tags",
+		Long: `Fixes the metadata of the last recorded rotation after the fact: a wrong
+driver name, a forgotten note, or a TL;DR that missed the point. The saved
+summary is updated, and so is the dashboard's rotation, or its upload if
+it's still waiting in the outbox.
+
+Only the fields given are changed. The rotation is the latest one saved,
+or the latest on --branch.
+
+Example: mob-claude amend-last --driver Bob -m "left the flaky test skipped"
+Example: mob-claude amend-last --tldr "Login form validates emails" --tags frontend`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runAmendLast,
+	}
+	cmd.Flags().StringVar(&amendDriver, "driver", "", "Driver who drove the rotation")
+	cmd.Flags().StringVarP(&amendNote, "message", "m", "", "Driver note for the rotation")
+	cmd.Flags().StringVar(&amendTLDR, "tldr", "", "Summary TL;DR")
+	cmd.Flags().StringSliceVar(&amendTags, "tags", nil, "Comma-separated tags, replacing the rotation's")
+	cmd.Flags().StringVar(&amendBranch, "branch", "", "Amend the last rotation on this branch")
+	return cmd
+}
+
+func runAmendLast(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	if !flags.Changed("driver") && !flags.Changed("message") && !flags.Changed("tldr") && !flags.Changed("tags") {
+		return fmt.Errorf("nothing to amend: use --driver, -m, --tldr, or --tags")
+	}
+	if flags.Changed("driver") && amendDriver == "" {
+		return fmt.Errorf("--driver can't be empty")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	var files []string
+	if amendBranch != "" {
+		files, err = planMgr.ListBranchSummaries(amendBranch)
+	} else {
+		files, err = planMgr.ListSummaries()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list summaries: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no saved rotation to amend")
+	}
+	file := files[len(files)-1]
+	summaryObj, err := planMgr.LoadSummary(file)
+	if err != nil {
+		return err
+	}
+
+	previousDriver := summaryObj.DriverName
+	if flags.Changed("driver") {
+		summaryObj.DriverName = amendDriver
+	}
+	if flags.Changed("message") {
+		summaryObj.DriverNote = amendNote
+	}
+	if flags.Changed("tldr") {
+		summaryObj.TLDR = amendTLDR
+	}
+	if flags.Changed("tags") {
+		summaryObj.Tags = plans.NormalizeTags(amendTags)
+	}
+
+	if err := planMgr.ReplaceSummary(file, summaryObj); err != nil {
+		return fmt.Errorf("failed to save summary: %w", err)
+	}
+	i18n.Printf("Amended %s's rotation on %s\n", summaryObj.DriverName, summaryObj.Branch)
+
+	result := rotationResult{Branch: summaryObj.Branch, Driver: summaryObj.DriverName, Summary: summaryObj}
+	if cfg.TeamName != "" && cfg.APIURL != "" {
+		if result.Upload, err = amendDashboardRotation(cmd.Context(), cfg, summaryObj, previousDriver); err != nil {
+			return err
+		}
+	}
+	setResult(result)
+	return nil
+}
+
+// amendDashboardRotation brings the dashboard's copy of the rotation in
+// line with the amended summary, and returns uploadQueued or
+// uploadRecorded for where it was changed, or "" if the dashboard's latest
+// rotation is a different one
+func amendDashboardRotation(ctx context.Context, cfg *config.Config, summaryObj *plans.Summary, previousDriver string) (string, error) {
+	// A rotation still waiting in the outbox is amended before it's sent
+	if box, err := newOutbox(); err == nil {
+		queued, err := box.AmendRotation(summaryObj.Branch, summaryObj.StartedAt, func(r *api.CreateRotationRequest) {
+			r.DriverName = summaryObj.DriverName
+			r.DriverNote = summaryObj.DriverNote
+			r.SummaryTLDR = summaryObj.TLDR
+			r.SummaryJSON = rotationSummaryJSON(summaryObj)
+			r.Tags = summaryObj.Tags
+		})
+		if err != nil {
+			i18n.Printf("Warning: could not update the queued upload: %v\n", err)
+		} else if queued {
+			i18n.Println("Queued upload updated")
+			return uploadQueued, nil
+		}
+	}
+
+	client := newAPIClient(cfg)
+	rotation, err := client.GetLatestRotation(ctx, summaryObj.Branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the dashboard's rotation: %w", err)
+	}
+	if sameRotation(rotation, summaryObj, previousDriver) {
+		rotation, err = client.UpdateRotation(ctx, summaryObj.Branch, rotation.ID, &api.UpdateRotationRequest{
+			DriverName:  summaryObj.DriverName,
+			DriverNote:  summaryObj.DriverNote,
+			SummaryTLDR: summaryObj.TLDR,
+			SummaryJSON: rotationSummaryJSON(summaryObj),
+			Tags:        summaryObj.Tags,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to update the dashboard's rotation: %w", err)
+		}
+		if rotation != nil {
+			i18n.Println("Rotation updated in dashboard")
+			return uploadRecorded, nil
+		}
+	}
+	i18n.Printf("Warning: the dashboard's latest rotation on %s isn't this one; it was left as is\n", summaryObj.Branch)
+	return "", nil
+}
+
+// sameRotation reports whether the dashboard's rotation is the one the
+// summary records: it started at the same second, or, for summaries that
+// don't know when they started, it was driven by the same driver
+func sameRotation(rotation *api.Rotation, summaryObj *plans.Summary, driver string) bool {
+	if rotation == nil {
+		return false
+	}
+	if summaryObj.StartedAt.IsZero() {
+		return rotation.DriverName == driver
+	}
+	return rotation.StartedAt.Unix() == summaryObj.StartedAt.Unix()
+}
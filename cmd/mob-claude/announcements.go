@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func newAnnouncementsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "announcements",
+		Short: "Show the team's pinned announcements",
+		Long: `Lists every pinned team announcement, including ones already shown at start.
+Facilitators pin announcements on the dashboard; each one is shown once at
+'mob-claude start'.`,
+		Args: cobra.NoArgs,
+		RunE: runAnnouncements,
+	}
+}
+
+func runAnnouncements(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+
+	announcements, err := newAPIClient(cfg).GetAnnouncements(cmd.Context())
+	if err != nil {
+		return err
+	}
+	setResult(announcements)
+	if len(announcements) == 0 {
+		i18n.Println("No announcements")
+		return nil
+	}
+	printAnnouncements(announcements)
+	return nil
+}
+
+// showNewAnnouncements prints pinned announcements the user hasn't seen yet
+// and marks them as seen
+func showNewAnnouncements(ctx context.Context, cfg *config.Config) {
+	announcements, err := newAPIClient(cfg).GetAnnouncements(ctx)
+	if err != nil {
+		i18n.Printf("Warning: could not fetch announcements: %v\n", err)
+		return
+	}
+
+	seen := config.SeenAnnouncements(cfg.APIURL, cfg.TeamName)
+	var fresh []api.Announcement
+	ids := make([]string, 0, len(announcements))
+	for _, a := range announcements {
+		ids = append(ids, a.ID)
+		if !seen[a.ID] {
+			fresh = append(fresh, a)
+		}
+	}
+	if len(fresh) == 0 {
+		return
+	}
+
+	i18n.Println("=== Team announcements ===")
+	printAnnouncements(fresh)
+	fmt.Println()
+
+	if err := config.MarkAnnouncementsSeen(cfg.APIURL, cfg.TeamName, ids); err != nil {
+		i18n.Printf("Warning: could not save seen announcements: %v\n", err)
+	}
+}
+
+func printAnnouncements(announcements []api.Announcement) {
+	for _, a := range announcements {
+		if a.Author != "" {
+			fmt.Printf("  [%s, %s] %s\n", a.Author, a.CreatedAt.Local().Format("2006-01-02"), a.Message)
+		} else {
+			fmt.Printf("  [%s] %s\n", a.CreatedAt.Local().Format("2006-01-02"), a.Message)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+)
+
+var (
+	doneArchive       bool
+	doneUploadArchive bool
+)
+
+// archiveBranch bundles a finished branch's plan, summaries, handoff brief,
+// and diff stats into an archive, uploads it if asked, and removes the
+// working files. Problems are warnings, so they never stop 'done'.
+func archiveBranch(ctx context.Context, cfg *config.Config, mobWrapper *mob.Wrapper, branch string) {
+	if branch == "" {
+		return
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		i18n.Printf("Warning: could not archive session: %v\n", err)
+		return
+	}
+
+	diffStat, err := mobWrapper.GetDiffStatFromBase()
+	if err != nil {
+		i18n.Printf("Warning: could not get diff stats: %v\n", err)
+	}
+	archivePath, err := planMgr.Archive(branch, diffStat, clk.Now())
+	if err != nil {
+		// Keep the working files, since nothing preserves them
+		i18n.Printf("Warning: could not archive session: %v\n", err)
+		return
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, archivePath); err == nil {
+			archivePath = rel
+		}
+	}
+	i18n.Printf("Archived session to %s\n", archivePath)
+
+	if doneUploadArchive {
+		uploadArchive(ctx, cfg, branch, archivePath)
+	}
+	if err := planMgr.CleanBranch(branch); err != nil {
+		i18n.Printf("Warning: could not clean up session files: %v\n", err)
+	}
+}
+
+// uploadArchive attaches the archive to the branch's workstream on the dashboard
+func uploadArchive(ctx context.Context, cfg *config.Config, branch, archivePath string) {
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		i18n.Println("Warning: dashboard not configured, archive kept locally only")
+		return
+	}
+	contentType := "application/gzip"
+	if strings.HasSuffix(archivePath, ".age") {
+		contentType = "application/octet-stream"
+	}
+	data, err := os.ReadFile(archivePath)
+	if err == nil {
+		err = newAPIClient(cfg).UploadArtifact(ctx, branch, filepath.Base(archivePath), contentType, data)
+	}
+	if err != nil {
+		i18n.Printf("Warning: could not upload archive: %v\n", err)
+		return
+	}
+	i18n.Println("Archive uploaded to the dashboard")
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var backfillDryRun bool
+
+func newBackfillCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Upload local session history to the dashboard",
+		Long: `Walks the local summaries for every branch, including the ones kept in
+archives by 'done --archive' and 'prune', and uploads them to the dashboard
+as workstreams and rotations, preserving their original timestamps.
+
+Useful when a team adopts the dashboard after working locally for a while.
+Re-running is safe: each rotation is sent with a stable idempotency key.`,
+		Args: cobra.NoArgs,
+		RunE: runBackfill,
+	}
+	cmd.Flags().BoolVar(&backfillDryRun, "dry-run", false, "Show what would be uploaded without uploading")
+	return cmd
+}
+
+func runBackfill(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	files, err := planMgr.ListSummaries()
+	if err != nil {
+		return fmt.Errorf("failed to list summaries: %w", err)
+	}
+	archives, err := planMgr.ListArchives()
+	if err != nil {
+		return fmt.Errorf("failed to list archives: %w", err)
+	}
+	if len(files) == 0 && len(archives) == 0 {
+		i18n.Println("No local summaries to backfill")
+		return nil
+	}
+
+	// Group summaries by branch, oldest first. An archived summary may
+	// still be on disk too, so each rotation is counted once.
+	byBranch := make(map[string][]*plans.Summary)
+	seen := make(map[string]bool)
+	add := func(source string, s *plans.Summary) {
+		if s.Branch == "" {
+			i18n.Printf("Warning: skipping %s: no branch recorded\n", source)
+			return
+		}
+		key := fmt.Sprintf("%s\x00%d\x00%d", s.Branch, s.Rotation, s.Timestamp.UnixNano())
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		byBranch[s.Branch] = append(byBranch[s.Branch], s)
+	}
+	for _, file := range files {
+		s, err := planMgr.LoadSummary(file)
+		if err != nil {
+			i18n.Printf("Warning: skipping %v\n", err)
+			continue
+		}
+		add(file, s)
+	}
+	for _, archive := range archives {
+		summaries, err := planMgr.LoadArchiveSummaries(archive)
+		if err != nil {
+			i18n.Printf("Warning: skipping %v\n", err)
+			continue
+		}
+		for _, s := range summaries {
+			add(archive, s)
+		}
+	}
+
+	branches := make([]string, 0, len(byBranch))
+	for branch := range byBranch {
+		branches = append(branches, branch)
+		sort.Slice(byBranch[branch], func(i, j int) bool {
+			return byBranch[branch][i].Timestamp.Before(byBranch[branch][j].Timestamp)
+		})
+	}
+	sort.Strings(branches)
+
+	mobWrapper := mob.NewWrapper()
+	mobWrapper.SetRemote(cfg.GitRemote)
+	repoURL, err := mobWrapper.GetRepoURL()
+	if err != nil {
+		repoURL = "unknown"
+	}
+
+	client := newAPIClient(cfg)
+	uploaded, failed := 0, 0
+
+	for _, branch := range branches {
+		summaries := byBranch[branch]
+		fmt.Printf("%s: %d rotation(s)\n", branch, len(summaries))
+		if backfillDryRun {
+			continue
+		}
+
+		if _, err := client.CreateWorkstream(ctx, repoURL, branch); err != nil {
+			i18n.Printf("Warning: could not register workstream %s: %v\n", branch, err)
+			failed += len(summaries)
+			continue
+		}
+
+		for _, s := range summaries {
+			if _, err := client.CreateRotation(ctx, branch, backfillRotationRequest(s)); err != nil {
+				i18n.Printf("Warning: could not upload rotation from %s: %v\n", s.Timestamp.Format("2006-01-02 15:04"), err)
+				failed++
+				continue
+			}
+			uploaded++
+		}
+
+		if planText, _ := planMgr.LoadPlan(branch); planText != "" {
+			if err := client.UpdatePlan(ctx, branch, planText); err != nil {
+				i18n.Printf("Warning: could not sync plan for %s: %v\n", branch, err)
+			} else {
+				recordPlanSync(branch)
+			}
+		}
+	}
+
+	if backfillDryRun {
+		i18n.Println("\nDry run: nothing was uploaded")
+		return nil
+	}
+
+	i18n.Printf("\nBackfilled %d rotation(s) across %d branch(es)\n", uploaded, len(branches))
+	if failed > 0 {
+		return fmt.Errorf("%d rotation(s) failed to upload", failed)
+	}
+	return nil
+}
+
+// backfillRotationRequest rebuilds a rotation payload from a local summary.
+// The idempotency key is derived from the summary itself so repeated
+// backfills don't create duplicates.
+func backfillRotationRequest(s *plans.Summary) *api.CreateRotationRequest {
+	summary := map[string]interface{}{
+		"schemaVersion": plans.SummarySchemaVersion,
+		"changes":       s.Changes,
+		"nextSteps":     s.NextSteps,
+	}
+	if s.Rotation > 0 {
+		summary["rotation"] = s.Rotation
+	}
+	if len(s.Tags) > 0 {
+		summary["tags"] = s.Tags
+	}
+
+	// Summaries written before rotation times were recorded only carry the
+	// timestamp, which is used as the start as before
+	startedAt := s.Timestamp
+	var endedAt time.Time
+	if !s.StartedAt.IsZero() {
+		startedAt, endedAt = s.StartedAt, s.EndedAt
+		summary["startedAt"] = s.StartedAt
+		summary["endedAt"] = s.EndedAt
+		summary["durationSeconds"] = s.Duration
+	}
+	summaryJSON, _ := json.Marshal(summary)
+
+	// The key the rotation was uploaded with live, so the dashboard drops
+	// it as a repeat; older summaries get one unique to their rotation
+	key := s.UploadKey
+	if key == "" {
+		key = fmt.Sprintf("backfill-%s-%d-%d", s.Branch, s.Rotation, s.Timestamp.UnixNano())
+	}
+
+	return &api.CreateRotationRequest{
+		DriverName:      s.DriverName,
+		DriverNote:      s.DriverNote,
+		SummaryTLDR:     s.TLDR,
+		SummaryJSON:     summaryJSON,
+		StartedAt:       startedAt,
+		EndedAt:         endedAt,
+		DurationSeconds: s.Duration,
+		Tags:            s.Tags,
+		IdempotencyKey:  key,
+	}
+}
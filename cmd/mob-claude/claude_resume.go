@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/claudecode"
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/summary"
+	"github.com/spf13/cobra"
+)
+
+func newClaudeResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "claude-resume [session-id]",
+		Short: "Resume the previous driver's Claude Code conversation",
+		Long: `Runs 'claude --resume' with the Claude Code conversation recorded on the
+branch's latest rotation, so the next driver picks up where the previous
+one's conversation left off. Pass a session ID to resume another one.
+
+The rotation's conversation is taken from the dashboard when it's
+configured, otherwise from the latest local summary. Claude Code keeps
+transcripts on the machine they were made on, so this works when the mob
+shares a machine (or the transcript was copied over).`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runClaudeResume,
+	}
+}
+
+func newClaudeHookCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "claude-hook",
+		Short: "Record the Claude Code conversation for the current rotation",
+		Long: `Reads the JSON a Claude Code hook receives on stdin and records its
+session ID on the current rotation, so 'next' and 'done' can link the
+conversation to the rotation. Configure it as a SessionStart hook in
+.claude/settings.json; it prints nothing, since SessionStart output is
+added to Claude's context.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runClaudeHook,
+	}
+}
+
+func runClaudeHook(cmd *cobra.Command, args []string) error {
+	// mob-claude's own summary calls start sessions too
+	if os.Getenv(claudecode.InternalEnv) != "" {
+		return nil
+	}
+	input, err := claudecode.ParseHookInput(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil || session.ClaudeSessionID == input.SessionID {
+		return nil
+	}
+	session.ClaudeSessionID = input.SessionID
+	if err := config.SaveCurrentSession(session); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+func runClaudeResume(cmd *cobra.Command, args []string) error {
+	sessionID := ""
+	if len(args) == 1 {
+		sessionID = args[0]
+	} else {
+		branch, err := currentPlanBranch()
+		if err != nil {
+			return err
+		}
+		if sessionID, err = latestClaudeSession(cmd.Context(), branch); err != nil {
+			return err
+		}
+		if sessionID == "" {
+			return fmt.Errorf("no Claude Code conversation recorded for %s. Add the claude-hook SessionStart hook to record them", branch)
+		}
+	}
+
+	if !claudecode.SessionExists(".", sessionID) {
+		return fmt.Errorf("conversation %s isn't on this machine. Claude Code keeps transcripts where they were made, so resume it there", sessionID)
+	}
+
+	cli, err := summary.DetectCLI()
+	if err != nil {
+		return err
+	}
+	if !cli.Resume {
+		return fmt.Errorf("claude CLI %s can't resume conversations. Update it with 'claude update'", cli.Version)
+	}
+	i18n.Printf("Resuming Claude Code conversation %s...\n", sessionID)
+	claude := exec.Command(cli.Path, "--resume", sessionID)
+	claude.Stdin, claude.Stdout, claude.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return claude.Run()
+}
+
+// latestClaudeSession returns the conversation recorded on the branch's
+// latest rotation, from the dashboard if it's configured and reachable and
+// from the local summaries otherwise
+func latestClaudeSession(ctx context.Context, branch string) (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	if cfg.TeamName != "" && cfg.APIURL != "" {
+		rotation, err := newAPIClient(cfg).GetLatestRotation(ctx, branch)
+		if err == nil && rotation != nil && rotation.ClaudeSessionID != "" {
+			return rotation.ClaudeSessionID, nil
+		}
+		if err != nil {
+			i18n.Printf("Warning: could not fetch latest rotation: %v\n", err)
+		}
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	files, err := planMgr.ListBranchSummaries(branch)
+	if err != nil || len(files) == 0 {
+		return "", err
+	}
+	latest, err := planMgr.LoadSummary(files[len(files)-1])
+	if err != nil {
+		return "", err
+	}
+	return latest.ClaudeSessionID, nil
+}
+
+// claudeSessionID returns the driver's Claude Code conversation for the
+// rotation: the one the hook recorded, or else a guess from the transcripts
+// updated since the rotation started
+func claudeSessionID(session *config.CurrentSession) string {
+	if session.ClaudeSessionID != "" {
+		return session.ClaudeSessionID
+	}
+	startedAt, err := time.Parse(time.RFC3339, session.StartedAt)
+	if err != nil {
+		return ""
+	}
+	id, err := claudecode.LatestSession(".", startedAt)
+	if err != nil {
+		i18n.Printf("Warning: could not look up Claude Code conversation: %v\n", err)
+	}
+	return id
+}
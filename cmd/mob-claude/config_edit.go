@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/mob-claude/mob-claude/internal/secrets"
+	"github.com/spf13/cobra"
+)
+
+func newConfigUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a configuration value",
+		Long: `Removes a value from config.json so it falls back to its default.
+Secrets are removed from the keychain.
+
+Available keys: ` + strings.Join(configKeys, ", "),
+		Args: cobra.ExactArgs(1),
+		RunE: runConfigUnset,
+	}
+}
+
+func newConfigEditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: "Edit config.json in your editor",
+		Long: `Opens config.json in $VISUAL or $EDITOR (vi, or notepad on Windows).
+
+The edited file is checked like 'config validate' before it's saved; if it
+has problems you can edit it again or discard the changes. Secrets are not
+in config.json; set them with 'config set'.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runConfigEdit,
+	}
+}
+
+// isConfigKey reports whether key is one of configKeys, matching
+// profiles.<name>.<field> keys against their profiles.<name> template
+func isConfigKey(key string) bool {
+	if name, field, ok := config.SplitProfileKey(key); ok && name != "" {
+		key = "profiles.<name>." + field
+	}
+	for _, k := range configKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	if !isConfigKey(key) {
+		return fmt.Errorf("unknown config key: %s\nAvailable keys: %s", key, strings.Join(configKeys, ", "))
+	}
+
+	removed, err := config.Unset(key)
+	if err != nil {
+		return fmt.Errorf("failed to unset %s: %w", key, err)
+	}
+	if !removed {
+		i18n.Printf("%s is not set\n", key)
+		return nil
+	}
+	i18n.Printf("Unset %s\n", key)
+	return nil
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	if !isInteractive() {
+		return fmt.Errorf("config edit needs an interactive terminal")
+	}
+
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	original, err := config.LoadRaw()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if original == nil {
+		// Start from the defaults so the available settings are visible
+		if original, err = json.MarshalIndent(config.DefaultConfig(), "", "  "); err != nil {
+			return err
+		}
+	}
+
+	// Edit a copy, so config.json is only replaced once it checks out
+	tmp, err := os.CreateTemp(dir, "config-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	_, err = tmp.Write(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	for {
+		if err := runEditor(tmpPath); err != nil {
+			return err
+		}
+		edited, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return fmt.Errorf("failed to read edited config: %w", err)
+		}
+		if bytes.Equal(edited, original) {
+			i18n.Println("No changes made")
+			return nil
+		}
+
+		problems, err := configProblems(edited)
+		if err != nil {
+			problems = []string{err.Error()}
+		}
+		if len(problems) == 0 {
+			if err := os.WriteFile(filepath.Join(dir, config.ConfigFileName), edited, platform.FilePerm); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			i18n.Println("Config saved")
+			return nil
+		}
+
+		for _, p := range problems {
+			fmt.Printf("  - %s\n", p)
+		}
+		if !confirm(i18n.T("Edit again?"), false) {
+			i18n.Println("Changes discarded")
+			return nil
+		}
+	}
+}
+
+// runEditor opens path in the user's editor and waits for it to exit
+func runEditor(path string) error {
+	editor := platform.Editor()
+	editorPath, err := platform.FindExecutable(editor[0])
+	if err != nil {
+		return fmt.Errorf("editor %s not found. Set $EDITOR", editor[0])
+	}
+	edit := exec.Command(editorPath, append(editor[1:], path)...)
+	edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := edit.Run(); err != nil {
+		return fmt.Errorf("editor failed: %w", err)
+	}
+	return nil
+}
+
+// editText opens text in the user's editor, in a temporary file named
+// after pattern, and returns what was saved
+func editText(text, pattern string) (string, error) {
+	tmp, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	_, err = tmp.WriteString(text)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := runEditor(tmpPath); err != nil {
+		return "", err
+	}
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return string(edited), nil
+}
+
+// printConfigJSON prints the config in effect as JSON for scripts, with
+// secrets redacted
+func printConfigJSON(cfg *config.Config) error {
+	data, err := json.MarshalIndent(redactedConfig(cfg), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// redactedConfig is the config in effect with its secrets redacted and the
+// active profile named
+func redactedConfig(cfg *config.Config) interface{} {
+	return struct {
+		*config.Config
+		ActiveProfile string `json:"activeProfile,omitempty"`
+		APIToken      string `json:"apiToken,omitempty"`
+		SlackWebhook  string `json:"slackWebhook,omitempty"`
+		SigningSecret string `json:"signingSecret,omitempty"`
+		EncryptionKey string `json:"encryptionKey,omitempty"`
+	}{
+		Config:        cfg,
+		ActiveProfile: config.ActiveProfile(cfg),
+		APIToken:      secrets.Redact(cfg.APIToken),
+		SlackWebhook:  secrets.Redact(cfg.SlackWebhook),
+		SigningSecret: secrets.Redact(cfg.SigningSecret),
+		EncryptionKey: secrets.Redact(cfg.EncryptionKey),
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var validateOffline bool
+
+func newConfigValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration for errors",
+		Long: `Checks config.json for invalid URLs, unknown models, out-of-range values,
+and unrecognized keys, and verifies the dashboard is reachable.
+
+Exits non-zero if any problem is found, so it can be used in scripts and CI.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runConfigValidate,
+	}
+	cmd.Flags().BoolVar(&validateOffline, "offline", false, "Skip the dashboard reachability check")
+	return cmd
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	data, err := config.LoadRaw()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg, err := config.LoadFile()
+	if errors.Is(err, config.ErrNewerVersion) {
+		return err
+	} else if err != nil {
+		return fmt.Errorf("config.json is not valid JSON: %w", err)
+	}
+
+	// Without a config.json the defaults are in use, and they're valid
+	var problems []string
+	if data != nil {
+		if problems, err = configProblems(data); err != nil {
+			return err
+		}
+	}
+
+	// Check reachability with the active profile applied
+	effective, err := config.Load()
+	if err != nil {
+		// An unknown saved profile is already reported by Validate
+		if config.ActiveProfile(cfg) != cfg.Profile {
+			problems = append(problems, i18n.Sprintf("profile: %v", err))
+		}
+	} else if !validateOffline && effective.TeamName != "" && effective.APIURL != "" {
+		if err := newAPIClient(effective).Ping(cmd.Context()); err != nil {
+			problems = append(problems, i18n.Sprintf("apiUrl: %v", err))
+		}
+	}
+
+	if len(problems) == 0 {
+		i18n.Println("Configuration is valid")
+		return nil
+	}
+
+	for _, p := range problems {
+		fmt.Printf("  - %s\n", p)
+	}
+	return fmt.Errorf("%d configuration problem(s) found", len(problems))
+}
+
+// configProblems checks config.json content for invalid values and
+// unrecognized keys
+func configProblems(data []byte) ([]string, error) {
+	cfg, err := config.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("config.json is not valid JSON: %w", err)
+	}
+
+	var problems []string
+	for _, p := range config.Validate(cfg) {
+		problems = append(problems, p.String())
+	}
+
+	unknown, err := config.UnknownKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("config.json is not valid JSON: %w", err)
+	}
+	for _, key := range unknown {
+		problems = append(problems, i18n.Sprintf("%s: unknown key (ignored)", key))
+	}
+	return problems, nil
+}
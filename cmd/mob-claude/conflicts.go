@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/summary"
+)
+
+// explainConflicts checks for merge conflicts left by mob.sh and, if there
+// are any, prints Claude's explanation of them before the user resolves
+// them by hand
+func explainConflicts(cfg *config.Config, mobWrapper *mob.Wrapper) {
+	conflicts, err := mobWrapper.GetConflicts()
+	if err != nil || len(conflicts) == 0 {
+		return
+	}
+
+	i18n.Println("\n=== Merge conflicts ===")
+	for _, c := range conflicts {
+		fmt.Printf("  %s\n", c.File)
+	}
+
+	if err := summary.CheckClaudeAvailable(); err == nil {
+		i18n.Println("\nAsking Claude to explain the conflicts...")
+		explanation, err := newGenerator(cfg).ExplainConflicts(mob.FormatConflicts(conflicts))
+		if err != nil {
+			i18n.Printf("Warning: could not explain conflicts: %v\n", err)
+		} else {
+			fmt.Printf("\n%s\n", explanation)
+		}
+	}
+
+	i18n.Println("\nResolve the conflicts and commit them before continuing.")
+}
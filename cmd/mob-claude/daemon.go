@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/daemon"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/internal/notify"
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/mob-claude/mob-claude/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonPort int
+	daemonPair bool
+)
+
+func newDaemonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Serve a localhost control interface for the session",
+		Long: `Serves a small HTTP interface on 127.0.0.1 so stream decks, Shortcuts, and
+desktop widgets can read the rotation timer and trigger a handoff.
+
+  GET  /timer   Current rotation timer
+  GET  /deck    Compact status for button displays (state, seconds, initials, color)
+  POST /next    Run 'mob-claude next' (optional JSON body: {"message": "..."})
+
+While running, the port is written to .claude/mob/daemon.port so clients can
+find the daemon without configuration.
+
+Every request must carry the pairing token, either as
+"Authorization: Bearer <token>" or as a "token" query parameter. The token is
+created on first run and kept in the secret store; --pair replaces it.`,
+		Args: cobra.NoArgs,
+		RunE: runDaemon,
+	}
+	cmd.Flags().IntVar(&daemonPort, "port", daemon.DefaultPort, "Port to listen on (localhost only, 0 picks a free port)")
+	cmd.Flags().BoolVar(&daemonPair, "pair", false, "Generate a new pairing token, revoking the old one")
+	return cmd
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	token, created, err := config.PairingToken(idGen.NewID, daemonPair)
+	if err != nil {
+		return fmt.Errorf("failed to load pairing token: %w", err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate mob-claude executable: %w", err)
+	}
+
+	// Only one handoff may run at a time
+	var nextMu sync.Mutex
+
+	server := &daemon.Server{
+		Token: token,
+		Timer: daemonTimerStatus,
+		Deck:  daemonDeckStatus,
+		Next: func(message string) error {
+			if !nextMu.TryLock() {
+				return fmt.Errorf("a handoff is already in progress")
+			}
+			defer nextMu.Unlock()
+
+			nextArgs := []string{"next"}
+			if message != "" {
+				nextArgs = append(nextArgs, "--message", message)
+			}
+			next := exec.Command(exe, nextArgs...)
+			next.Stdout = os.Stdout
+			next.Stderr = os.Stderr
+			if err := next.Run(); err != nil {
+				return fmt.Errorf("mob-claude next failed: %w", err)
+			}
+			return nil
+		},
+	}
+
+	listener, err := daemon.Listen(daemonPort)
+	if err != nil {
+		return err
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	// Advertise the port for clients while the daemon runs
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	portFile := filepath.Join(dir, daemon.PortFile)
+	if err := os.WriteFile(portFile, []byte(strconv.Itoa(port)+"\n"), platform.FilePerm); err != nil {
+		i18n.Printf("Warning: could not write port file: %v\n", err)
+	}
+	defer os.Remove(portFile)
+
+	i18n.Printf("Listening on http://127.0.0.1:%d\n", port)
+	if created {
+		i18n.Printf("Pairing token: %s\n", token)
+	} else {
+		i18n.Println("Using the existing pairing token (run with --pair to create a new one)")
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	go watchForgottenNext(ctx)
+	go watchPendingRegistration(ctx)
+	return server.Serve(ctx, listener)
+}
+
+// forgottenNextInterval is how often the daemon checks the WIP branch for
+// a handoff that bypassed mob-claude
+const forgottenNextInterval = 2 * time.Minute
+
+// watchForgottenNext fetches the session's WIP branch periodically and
+// warns, once per new commit, when someone other than the driver committed
+// to it: a handoff that happened without mob-claude
+func watchForgottenNext(ctx context.Context) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	mobWrapper, err := newMobWrapper(cfg)
+	if err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(forgottenNextInterval)
+	defer ticker.Stop()
+	warned := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		session, err := config.LoadCurrentSession()
+		if err != nil || session == nil {
+			continue
+		}
+		_ = mobWrapper.FetchWip(session.Branch)
+		f := detectForgottenNext(mobWrapper, session)
+		if f == nil || f.foreign[len(f.foreign)-1].Hash == warned {
+			continue
+		}
+		warned = f.foreign[len(f.foreign)-1].Hash
+		warnForgottenNext(f, session)
+		i18n.Println("Run 'mob-claude status' to record the rotation")
+		_ = notify.Desktop("mob-claude", i18n.Sprintf("%s committed to the mob branch; a handoff likely happened without mob-claude", strings.Join(f.authors, ", ")))
+	}
+}
+
+// Backoff bounds for retrying a workstream registration that failed at start
+const (
+	registrationRetryMin = 30 * time.Second
+	registrationRetryMax = 15 * time.Minute
+)
+
+// watchPendingRegistration retries a workstream registration deferred at
+// start, backing off while the dashboard stays unreachable, so the session
+// shows up on the dashboard without waiting for the next command
+func watchPendingRegistration(ctx context.Context) {
+	delay := registrationRetryMin
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		session, err := config.LoadCurrentSession()
+		if err != nil || session == nil || !session.PendingRegistration {
+			delay = registrationRetryMin
+			continue
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			continue
+		}
+		if err := retryRegistration(ctx, cfg, session); err != nil {
+			delay = min(delay*2, registrationRetryMax)
+			continue
+		}
+		delay = registrationRetryMin
+	}
+}
+
+// daemonTimerStatus reports the current session's rotation timer
+func daemonTimerStatus() (*daemon.TimerStatus, error) {
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil || session.TimerDeadline == "" {
+		return &daemon.TimerStatus{}, nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, session.TimerDeadline)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timer deadline in session: %w", err)
+	}
+	remaining := deadline.Sub(clk.Now())
+	return &daemon.TimerStatus{
+		Active:           true,
+		Deadline:         &deadline,
+		RemainingSeconds: int(remaining.Seconds()),
+		Message:          timer.Message(remaining),
+		Room:             mob.LoadSettings().TimerRoomURL(),
+	}, nil
+}
+
+// daemonDeckStatus reports the session state in the compact deck format
+func daemonDeckStatus() (*daemon.DeckStatus, error) {
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return daemon.NewDeckStatus(daemon.StateIdle, 0, ""), nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, session.TimerDeadline)
+	if err != nil {
+		return daemon.NewDeckStatus(daemon.StateRunning, 0, session.DriverName), nil
+	}
+
+	remaining := deadline.Sub(clk.Now())
+	state := daemon.StateRunning
+	switch timer.LevelAt(remaining) {
+	case timer.LevelNotice:
+		state = daemon.StateNotice
+	case timer.LevelUrgent:
+		state = daemon.StateUrgent
+	case timer.LevelExpired:
+		state = daemon.StateExpired
+	case timer.LevelOverdue:
+		state = daemon.StateOverdue
+	}
+	return daemon.NewDeckStatus(state, int(remaining.Seconds()), session.DriverName), nil
+}
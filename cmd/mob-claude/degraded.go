@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/outbox"
+	"github.com/mob-claude/mob-claude/pkg/api"
+)
+
+// printDegradedBanner explains, once at start, what won't work this
+// session. apiErr is the dashboard ping failure and claudeErr the Claude
+// CLI check failure; either may be nil.
+func printDegradedBanner(apiErr, claudeErr error) {
+	if apiErr == nil && claudeErr == nil {
+		return
+	}
+
+	i18n.Println("=== Running in offline/degraded mode ===")
+	if apiErr != nil {
+		i18n.Printf("Dashboard unreachable (%v).\n", apiErr)
+		i18n.Println("  Rotations and plan updates are queued and uploaded once it's back.")
+	}
+	if claudeErr != nil {
+		i18n.Printf("Claude unavailable (%v).\n", claudeErr)
+		i18n.Println("  Summaries are built from your notes and -m messages.")
+	}
+	i18n.Println("")
+}
+
+// newOutbox returns the project's queue of pending dashboard uploads,
+// encrypted with encryptionKey when one is set
+func newOutbox() (*outbox.Outbox, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	box := outbox.New(dir)
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, err
+	}
+	if planMgr.Encrypted() {
+		box.SetCipher(planMgr)
+	}
+	return box, nil
+}
+
+// flushOutbox uploads anything queued while the dashboard was unreachable
+func flushOutbox(ctx context.Context, cfg *config.Config) {
+	box, err := newOutbox()
+	if err != nil {
+		return
+	}
+	sent, parked, err := box.Flush(ctx, newAPIClient(cfg))
+	if sent > 0 {
+		i18n.Printf("Uploaded %d queued update(s) to the dashboard\n", sent)
+	}
+	if parked > 0 {
+		i18n.Printf("Warning: the dashboard refused %d queued update(s); they were set aside in %s\n", parked, outbox.RejectedFileName)
+	}
+	if err != nil {
+		i18n.Printf("Warning: could not upload queued updates: %v\n", err)
+	}
+}
+
+// queueUploads stores a rotation and plan for upload once the dashboard is reachable
+func queueUploads(branch string, rotation *api.CreateRotationRequest, planText string) {
+	box, err := newOutbox()
+	if err == nil && rotation != nil {
+		err = box.AddRotation(branch, rotation, clk.Now())
+	}
+	if err == nil && planText != "" {
+		err = box.AddPlan(branch, planText, clk.Now())
+	}
+	if err != nil {
+		i18n.Printf("Warning: could not queue upload: %v\n", err)
+		return
+	}
+	i18n.Println("Queued the rotation for upload once the dashboard is reachable")
+}
+
+// queueFailedPlan queues a plan whose upload just failed, so it goes out
+// with the rest of the outbox once the dashboard is reachable. A plan the
+// dashboard refused isn't queued, since sending it again won't help.
+func queueFailedPlan(branch, planText string, uploadErr error) {
+	if api.IsRejected(uploadErr) {
+		return
+	}
+	box, err := newOutbox()
+	if err == nil {
+		err = box.AddPlan(branch, planText, clk.Now())
+	}
+	if err != nil {
+		i18n.Printf("Warning: could not queue upload: %v\n", err)
+		return
+	}
+	i18n.Println("Queued the plan for upload once the dashboard is reachable")
+}
+
+// pendingUploads returns how many uploads are waiting in the outbox
+func pendingUploads() int {
+	box, err := newOutbox()
+	if err != nil {
+		return 0
+	}
+	entries, _ := box.Load()
+	return len(entries)
+}
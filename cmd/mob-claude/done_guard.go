@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// remainingAbort is the --remaining answer that keeps the session going
+const remainingAbort = "abort"
+
+var doneRemaining string
+
+// guardOpenTasks makes 'done' deal with the plan's unchecked tasks before
+// the session ends: carry them over to a follow-up workstream, check them
+// off, or abort. The choice comes from --remaining, or is asked for. It
+// returns what was done with them, or nil if the plan had none.
+func guardOpenTasks(ctx context.Context, cfg *config.Config, session *config.CurrentSession) (*plans.RemainingTasks, error) {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, nil
+	}
+	plan, err := planMgr.LoadPlan(session.Branch)
+	if err != nil || plan == "" {
+		return nil, nil
+	}
+	open := plans.OpenTasks(plans.ParseTasks(plan))
+	if len(open) == 0 {
+		return nil, nil
+	}
+
+	outcome := doneRemaining
+	switch outcome {
+	case "", plans.RemainingFollowUp, plans.RemainingDone, remainingAbort:
+	default:
+		return nil, fmt.Errorf("invalid --remaining value %q: use followup, done, or abort", outcome)
+	}
+	if outcome == "" {
+		if !isInteractive() {
+			return nil, fmt.Errorf("%d tasks remain in the plan. Rerun with --remaining followup, done, or abort", len(open))
+		}
+		i18n.Printf("%d tasks remain in the plan:\n", len(open))
+		for _, t := range open {
+			fmt.Printf("  - %d. %s\n", t.Number, t.Text)
+		}
+		outcome = askRemaining(len(open))
+	}
+
+	remaining := &plans.RemainingTasks{Outcome: outcome}
+	for _, t := range open {
+		remaining.Tasks = append(remaining.Tasks, t.Text)
+	}
+
+	switch outcome {
+	case remainingAbort:
+		return nil, fmt.Errorf("done aborted; %d tasks remain in the plan", len(open))
+	case plans.RemainingDone:
+		for _, t := range open {
+			if plan, err = plans.SetTaskDone(plan, t.Number, true); err != nil {
+				return nil, err
+			}
+		}
+		if err := planMgr.SavePlan(session.Branch, plan); err != nil {
+			return nil, fmt.Errorf("failed to save plan: %w", err)
+		}
+		i18n.Printf("Marked %d tasks done\n", len(open))
+	case plans.RemainingFollowUp:
+		followUp, err := planMgr.SaveFollowUps(session.Branch, open)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save follow-ups: %w", err)
+		}
+		remaining.FollowUp = followUp
+		registerFollowUp(ctx, cfg, planMgr, session, followUp)
+		i18n.Printf("Moved %d tasks to the follow-up workstream %s\n", len(open), followUp)
+		i18n.Printf("Pick them up with 'mob-claude start -b %s'\n", followUp)
+	}
+	return remaining, nil
+}
+
+// askRemaining asks what to do with the open tasks until it gets an answer
+func askRemaining(count int) string {
+	question := i18n.Sprintf("%d tasks remain in the plan — archive as follow-ups (f), mark done (d), or abort (a)?", count)
+	for {
+		switch strings.ToLower(ask(question, "a")) {
+		case "f", "followup", "follow-up", "follow-ups":
+			return plans.RemainingFollowUp
+		case "d", "done":
+			return plans.RemainingDone
+		case "a", "abort":
+			return remainingAbort
+		}
+	}
+}
+
+// registerFollowUp registers the follow-up workstream with the dashboard
+// and uploads its plan. Failures only warn: 'sync-plans' can push the plan
+// later.
+func registerFollowUp(ctx context.Context, cfg *config.Config, planMgr *plans.Manager, session *config.CurrentSession, followUp string) {
+	if cfg.TeamName == "" || cfg.APIURL == "" || session.Offline {
+		recordPlanMetadata(planMgr, followUp, "")
+		return
+	}
+
+	client := newAPIClient(cfg)
+	workstream, err := client.CreateWorkstream(ctx, session.RepoURL, followUp)
+	if err != nil {
+		i18n.Printf("Warning: could not register follow-up workstream: %v\n", err)
+		recordPlanMetadata(planMgr, followUp, "")
+		return
+	}
+	recordPlanMetadata(planMgr, followUp, workstream.ID)
+	if plan, _ := planMgr.LoadPlan(followUp); plan != "" {
+		if err := client.UpdatePlan(ctx, followUp, plan); err != nil {
+			i18n.Printf("Warning: could not upload plan: %v\n", err)
+		}
+	}
+}
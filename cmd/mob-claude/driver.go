@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+)
+
+// mentionPattern matches an @name that starts a word, so email addresses
+// don't count as mentions; a trailing full stop isn't part of the name
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@-])@([\w-]+(?:\.[\w-]+)*)`)
+
+// confirmExpectedDriver checks the latest rotation on the dashboard for who
+// should drive next and, if it isn't driverName, asks the user to confirm.
+// It returns false if the user declines.
+func confirmExpectedDriver(ctx context.Context, cfg *config.Config, branch, driverName string, roster []string) bool {
+	rotation, err := newAPIClient(cfg).GetLatestRotation(ctx, branch)
+	if err != nil || rotation == nil {
+		return true
+	}
+
+	expected := expectedDriver(rotation, roster)
+	if expected == "" || strings.EqualFold(expected, driverName) {
+		return true
+	}
+
+	i18n.Printf("The last rotation (%s) suggests %s drives next.\n", rotation.DriverName, expected)
+	return confirm(i18n.Sprintf("You're about to drive as %s — correct?", driverName), true)
+}
+
+// expectedDriver guesses who should drive after a rotation: an @mention or
+// roster name in its next steps wins, otherwise the next name in roster order
+func expectedDriver(rotation *api.Rotation, roster []string) string {
+	var summary struct {
+		NextSteps []string `json:"nextSteps"`
+	}
+	_ = json.Unmarshal(rotation.SummaryJSON, &summary)
+
+	for _, step := range summary.NextSteps {
+		if m := mentionPattern.FindStringSubmatch(step); m != nil {
+			return m[1]
+		}
+		for _, name := range roster {
+			if containsWord(step, name) {
+				return name
+			}
+		}
+	}
+
+	for i, name := range roster {
+		if strings.EqualFold(name, rotation.DriverName) {
+			return roster[(i+1)%len(roster)]
+		}
+	}
+	return ""
+}
+
+// containsWord reports whether word appears in s as a whole word, ignoring case
+func containsWord(s, word string) bool {
+	pattern := `(?i)\b` + regexp.QuoteMeta(word) + `\b`
+	matched, _ := regexp.MatchString(pattern, s)
+	return matched
+}
+
+// resolveDriverName works out who is driving: the --driver flag, then
+// MOB_CLAUDE_DRIVER, then the driverName config. Failing those, a roster
+// lets the user pick themselves on shared machines, defaulting to the git
+// user or OS user, which is used as is when there's no roster.
+func resolveDriverName(flag string, cfg *config.Config, roster []string) string {
+	if flag != "" {
+		return flag
+	}
+	if env := strings.TrimSpace(os.Getenv("MOB_CLAUDE_DRIVER")); env != "" {
+		return env
+	}
+	if cfg.DriverName != "" {
+		return cfg.DriverName
+	}
+
+	fallback := getDriverName()
+	if len(roster) == 0 || !isInteractive() {
+		return fallback
+	}
+
+	def := ""
+	for i, name := range roster {
+		fmt.Printf("  %d) %s\n", i+1, name)
+		if strings.EqualFold(name, fallback) {
+			def = name
+		}
+	}
+	answer := ask(i18n.T("Who is driving? (number or name)"), def)
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(roster) {
+		return roster[n-1]
+	}
+	if answer == "" {
+		return fallback
+	}
+	return answer
+}
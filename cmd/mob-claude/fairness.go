@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var fairnessSessions int
+
+func newFairnessCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fairness",
+		Short: "Show how keyboard time is shared between drivers",
+		Long: `Totals each driver's time at the keyboard over the last sessions, from the
+rotations in local summaries, and flags drivers well above or below an even
+share. Rotations on the same branch less than 4 hours apart count as one
+session.`,
+		Args: cobra.NoArgs,
+		RunE: runFairness,
+	}
+	cmd.Flags().IntVarP(&fairnessSessions, "sessions", "n", 5, "Number of recent sessions to include (0 for all)")
+	return cmd
+}
+
+// fairnessResult is the result of fairness
+type fairnessResult struct {
+	Sessions int                `json:"sessions"`
+	Seconds  int                `json:"seconds"`
+	Drivers  []plans.DriverTime `json:"drivers"`
+}
+
+func runFairness(cmd *cobra.Command, args []string) error {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	files, err := planMgr.ListSummaries()
+	if err != nil {
+		return fmt.Errorf("failed to list summaries: %w", err)
+	}
+
+	var summaries []*plans.Summary
+	for _, file := range files {
+		s, err := planMgr.LoadSummary(file)
+		if err != nil {
+			i18n.Printf("Warning: skipping %v\n", err)
+			continue
+		}
+		summaries = append(summaries, s)
+	}
+
+	report := plans.Fairness(summaries, fairnessSessions)
+	setResult(&fairnessResult{Sessions: report.Sessions, Seconds: int(report.Total.Seconds()), Drivers: report.Drivers})
+	if len(report.Drivers) == 0 {
+		i18n.Println("No rotations recorded yet")
+		return nil
+	}
+
+	i18n.Printf("Keyboard time over the last %d session(s): %s\n\n", report.Sessions, formatDuration(report.Total))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DRIVER\tROTATIONS\tTIME\tSHARE")
+	flagged := false
+	for _, d := range report.Drivers {
+		note := ""
+		switch {
+		case d.Over:
+			note, flagged = i18n.T("above an even share"), true
+		case d.Under:
+			note, flagged = i18n.T("below an even share"), true
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%.0f%%\t%s\n", d.Name, d.Rotations, formatDuration(d.Time), d.Share*100, note)
+	}
+	w.Flush()
+
+	if report.Total == 0 {
+		i18n.Println("\nNo rotation durations were recorded, so only rotation counts are shown")
+	} else if flagged {
+		i18n.Printf("\nEven share with %d drivers: %.0f%%\n", len(report.Drivers), 100/float64(len(report.Drivers)))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/summary"
+)
+
+// needsFirstRunSetup reports whether start should set the project up
+// first: there's no config.json yet and someone is there to answer
+func needsFirstRunSetup() bool {
+	return !config.FileExists() && isInteractive() && outputFormat == outputText
+}
+
+// runFirstRunWizard asks for the few settings a new project needs, the
+// team name and whether to use the dashboard and Claude, and saves them.
+// Without it a first session would run against the default localhost
+// dashboard and warn about it throughout.
+func runFirstRunWizard() (*config.Config, error) {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	i18n.Println("=== Setting up mob-claude for this project ===")
+	i18n.Println("Press Enter to accept the default. Change anything later with 'mob-claude config set'.")
+	fmt.Println()
+
+	team := ""
+	if cwd, err := os.Getwd(); err == nil {
+		team = filepath.Base(cwd)
+	}
+	cfg.TeamName = ask(i18n.T("Team name"), team)
+
+	if confirm(i18n.T("Record rotations on a team dashboard?"), false) {
+		cfg.APIURL = ask(i18n.T("Dashboard URL"), cfg.APIURL)
+		if cfg.TeamName == "" {
+			cfg.TeamName = ask(i18n.T("The dashboard needs a team name"), team)
+		}
+		i18n.Println("If it needs a token, set it with 'mob-claude config set apiToken <token>'")
+	} else {
+		cfg.APIURL = ""
+	}
+
+	claudeErr := summary.CheckClaudeAvailable()
+	cfg.SkipSummary = !confirm(i18n.T("Summarize rotations with Claude?"), claudeErr == nil)
+	if !cfg.SkipSummary && claudeErr != nil {
+		i18n.Printf("Claude unavailable (%v).\n", claudeErr)
+		i18n.Printf("  Install the claude CLI or set %s; until then summaries are built from your notes.\n", summary.APIKeyEnv)
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+	if _, err := syncGitignore(cfg); err != nil {
+		i18n.Printf("Warning: could not write .gitignore: %v\n", err)
+	}
+	dir, _ := config.GetConfigDir()
+	i18n.Printf("\nSaved %s\n\n", filepath.Join(dir, config.ConfigFileName))
+
+	// Reload so the profile and secrets apply as they would on any start
+	if loaded, err := config.Load(); err == nil {
+		cfg = loaded
+	}
+	return cfg, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+// defaultFollowInterval is how often 'follow' polls the dashboard
+const defaultFollowInterval = 30 * time.Second
+
+var (
+	followInterval time.Duration
+	followAPIURL   string
+	followTeam     string
+)
+
+func newFollowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "follow <branch>",
+		Short: "Follow a workstream from the dashboard, read-only",
+		Long: `Polls the dashboard and prints a workstream's progress as it happens: each
+new rotation with its driver and TLDR, changes to the plan, and the session
+starting or ending.
+
+Following is read-only and doesn't need a checkout of the repository, so
+stakeholders can keep up with a mob from any terminal. Outside a configured
+project, give the dashboard with --api-url and --team.
+
+Example: mob-claude follow feature-login
+Example: mob-claude follow feature-login --api-url https://mob.example.com --team platform`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE:         runFollow,
+	}
+	cmd.Flags().DurationVar(&followInterval, "interval", defaultFollowInterval, "How often to poll the dashboard")
+	cmd.Flags().StringVar(&followAPIURL, "api-url", "", "Dashboard URL (defaults to the project's apiUrl)")
+	cmd.Flags().StringVar(&followTeam, "team", "", "Team name (defaults to the project's teamName)")
+	return cmd
+}
+
+// followState is what 'follow' has already reported, so each poll prints
+// only what changed
+type followState struct {
+	active     bool
+	rotationID string
+	plan       string
+}
+
+func runFollow(cmd *cobra.Command, args []string) error {
+	if followInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if followAPIURL != "" {
+		cfg.APIURL = followAPIURL
+	}
+	if followTeam != "" {
+		cfg.TeamName = followTeam
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first, or pass --api-url and --team")
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	branch := args[0]
+	client := newAPIClient(cfg)
+	ws, err := client.GetWorkstream(ctx, branch)
+	if err != nil {
+		return fmt.Errorf("failed to fetch workstream: %w", err)
+	}
+	if ws == nil {
+		return fmt.Errorf("no workstream for %s on the dashboard", branch)
+	}
+
+	i18n.Printf("Following %s (Ctrl-C to stop)\n", branch)
+	state := &followState{active: ws.IsActive}
+	if !ws.IsActive {
+		i18n.Println("The workstream has no active session")
+	}
+
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	reachable := true
+	for first := true; ; first = false {
+		if err := pollFollow(ctx, client, branch, state, first); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// Say so once per outage rather than on every poll
+			if reachable {
+				i18n.Printf("Warning: could not reach the dashboard, will keep trying: %v\n", err)
+			}
+			reachable = false
+		} else {
+			if !reachable {
+				i18n.Println("Dashboard reachable again")
+			}
+			reachable = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollFollow fetches the workstream's latest rotation, plan, and session
+// state and prints what changed since the last poll. The first poll shows
+// where things stand instead.
+func pollFollow(ctx context.Context, client *api.Client, branch string, state *followState, first bool) error {
+	rotation, err := client.GetLatestRotation(ctx, branch)
+	if err != nil {
+		return err
+	}
+	plan, err := client.GetPlan(ctx, branch)
+	if err != nil {
+		return err
+	}
+	ws, err := client.GetWorkstream(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	stamp := clk.Now().Format("15:04")
+	if rotation != nil && rotation.ID != state.rotationID {
+		state.rotationID = rotation.ID
+		if first {
+			i18n.Printf("Last rotation: %s, %s\n", rotation.DriverName, formatAge(rotation.StartedAt))
+		} else {
+			fmt.Printf("\n[%s] ", stamp)
+			i18n.Printf("New rotation by %s\n", rotation.DriverName)
+		}
+		if rotation.SummaryTLDR != "" {
+			fmt.Printf("  %s\n", rotation.SummaryTLDR)
+		}
+	}
+
+	if plan != state.plan {
+		switch {
+		case first:
+			if title := plans.PlanTitle(plan); title != "" {
+				i18n.Printf("Plan: %s\n", title)
+			}
+		default:
+			fmt.Printf("\n[%s] ", stamp)
+			i18n.Println("Plan updated:")
+			for _, line := range strings.Split(strings.TrimRight(plans.DiffLines(state.plan, plan), "\n"), "\n") {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+		state.plan = plan
+	}
+
+	if ws != nil && ws.IsActive != state.active {
+		state.active = ws.IsActive
+		fmt.Printf("\n[%s] ", stamp)
+		if ws.IsActive {
+			i18n.Println("Session started")
+		} else {
+			i18n.Println("Session ended")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// forgottenNext is a handoff that seems to have happened without
+// mob-claude: the session's WIP branch has commits by someone other than
+// its driver, made after the driver started
+type forgottenNext struct {
+	ref string
+	// own are the driver's commits before the first foreign one, which
+	// make up the rotation that was never recorded
+	own     []mob.CommitInfo
+	foreign []mob.CommitInfo
+	authors []string
+}
+
+// detectForgottenNext checks the session's WIP branch for commits by
+// other authors, or returns nil if there are none. The driver is matched
+// by the session's driver name and the git user.name.
+func detectForgottenNext(mobWrapper *mob.Wrapper, session *config.CurrentSession) *forgottenNext {
+	started, err := time.Parse(time.RFC3339, session.StartedAt)
+	if err != nil {
+		return nil
+	}
+	ref, commits, err := mobWrapper.WipCommitsSince(session.Branch, started)
+	if err != nil || len(commits) == 0 {
+		return nil
+	}
+
+	driver := []string{session.DriverName, getGitConfigValue("user.name")}
+	f := &forgottenNext{ref: ref}
+	for _, c := range commits {
+		if slices.Contains(driver, c.Author) {
+			if len(f.foreign) == 0 {
+				f.own = append(f.own, c)
+			}
+			continue
+		}
+		f.foreign = append(f.foreign, c)
+		if !slices.Contains(f.authors, c.Author) {
+			f.authors = append(f.authors, c.Author)
+		}
+	}
+	if len(f.foreign) == 0 {
+		return nil
+	}
+	return f
+}
+
+// warnForgottenNext explains a detected handoff that bypassed mob-claude
+func warnForgottenNext(f *forgottenNext, session *config.CurrentSession) {
+	i18n.Printf("\nWarning: %s has %d commit(s) by %s since %s started driving.\n", f.ref, len(f.foreign), strings.Join(f.authors, ", "), session.DriverName)
+	i18n.Println("A handoff likely happened without mob-claude, so this session's rotation was never recorded.")
+}
+
+// offerForgottenNextBackfill asks to record the driver's missed rotation
+// after the fact and end the stale session
+func offerForgottenNextBackfill(ctx context.Context, cfg *config.Config, mobWrapper *mob.Wrapper, f *forgottenNext, session *config.CurrentSession) {
+	if !confirm(i18n.Sprintf("Record %s's rotation now and end this session?", session.DriverName), false) {
+		return
+	}
+	if err := backfillForgottenNext(ctx, cfg, mobWrapper, f, session); err != nil {
+		i18n.Printf("Warning: could not record the rotation: %v\n", err)
+		return
+	}
+	if err := config.ClearCurrentSession(); err != nil {
+		i18n.Printf("Warning: could not clear session: %v\n", err)
+		return
+	}
+	i18n.Println("Session ended. Run 'mob-claude start' to join the mob again")
+}
+
+// backfillForgottenNext summarizes the driver's commits up to the foreign
+// ones and records them as the session's rotation, locally and on the
+// dashboard if it's configured
+func backfillForgottenNext(ctx context.Context, cfg *config.Config, mobWrapper *mob.Wrapper, f *forgottenNext, session *config.CurrentSession) error {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	// The rotation ran until the driver's last commit, or until the next
+	// driver's first one if the driver made none
+	startedAt, _ := time.Parse(time.RFC3339, session.StartedAt)
+	endedAt := f.foreign[0].Time
+	diff, diffBase, diffHead := "", "", ""
+	if len(f.own) > 0 {
+		last := f.own[len(f.own)-1]
+		endedAt = last.Time
+		diffBase, diffHead = f.own[0].Hash+"^", last.Hash
+		if diff, err = mobWrapper.GetDiffBetween(diffBase, diffHead); err != nil {
+			return err
+		}
+	}
+
+	driverNote := driverNoteFor(session)
+	var summaryObj *plans.Summary
+	if cfg.SkipSummary || session.ClaudeUnavailable {
+		// Without Claude, the commits are all there is to go on
+		summaryObj = &plans.Summary{
+			Branch:         session.Branch,
+			DriverNote:     driverNote,
+			TLDR:           i18n.Sprintf("%d commit(s), recorded after a handoff outside mob-claude", len(f.own)),
+			NavigatorNotes: navigatorNotesFor(session),
+		}
+		for _, c := range f.own {
+			summaryObj.Changes = append(summaryObj.Changes, c.Subject)
+		}
+	} else {
+		i18n.Println("Generating rotation summary...")
+		gen := newGenerator(cfg)
+		gen.SetNavigatorNotes(navigatorNotesFor(session))
+		setPreviousSummaries(gen, cfg, planMgr, session.Branch)
+		if summaryObj, err = gen.Generate(diff, driverNote, session.Branch); err != nil {
+			return fmt.Errorf("failed to generate summary: %w", err)
+		}
+	}
+	summaryObj.DriverName = session.DriverName
+	summaryObj.Timestamp = endedAt
+	summaryObj.SetRotationTimes(startedAt, endedAt)
+	summaryObj.ClaudeSessionID = claudeSessionID(session)
+	summaryObj.DiffBase, summaryObj.DiffHead = diffBase, diffHead
+	printSummary(summaryObj)
+
+	if err := planMgr.SaveSummary(summaryObj); err != nil {
+		return fmt.Errorf("failed to save summary: %w", err)
+	}
+	i18n.Println("\nSummary saved")
+
+	if cfg.TeamName != "" && cfg.APIURL != "" {
+		planText, _ := planMgr.LoadPlan(session.Branch)
+		rotation := newRotationRequest(session, summaryObj, planText, driverNote)
+		if _, err := newAPIClient(cfg).CreateRotation(ctx, session.Branch, rotation); err != nil {
+			return fmt.Errorf("failed to upload rotation: %w", err)
+		}
+		i18n.Println("Rotation recorded in dashboard")
+	}
+	return nil
+}
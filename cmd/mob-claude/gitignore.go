@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/activity"
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/daemon"
+	"github.com/mob-claude/mob-claude/internal/metrics"
+	"github.com/mob-claude/mob-claude/internal/outbox"
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// gitignoreMarker opens the .gitignore mob-claude keeps in the config
+// directory. A .gitignore without it belongs to the team and is left alone.
+const gitignoreMarker = "# Managed by mob-claude"
+
+// runtimeFiles are the config directory's machine-local state. They're
+// always ignored: a committed session would follow the WIP branch to the
+// next driver's machine.
+var runtimeFiles = []string{
+	config.CurrentFile,
+	config.FreshnessFile,
+	outbox.FileName,
+	outbox.RejectedFileName,
+	metrics.FileName,
+	activity.FileName,
+	daemon.PortFile,
+	heartbeatPIDFile,
+	api.CacheDir + "/",
+	filepath.Base(plans.JournalFile),
+	filepath.Base(plans.SyncStateFile),
+	"config-*.json", // 'config edit' scratch copies
+}
+
+// artifactPatterns are the .gitignore patterns of each config.Artifacts entry
+var artifactPatterns = map[string]string{
+	"config":            config.ConfigFileName,
+	"summaries":         filepath.Base(plans.SummariesDir) + "/",
+	"archive":           filepath.Base(plans.ArchiveDir) + "/",
+	"handoff":           filepath.Base(plans.HandoffFile),
+	"templates":         filepath.Base(plans.TemplatesDir) + "/",
+	"session-templates": config.SessionTemplatesDir + "/",
+}
+
+// renderGitignore builds the managed .gitignore for cfg's untracked artifacts
+func renderGitignore(cfg *config.Config) string {
+	var b strings.Builder
+	b.WriteString(gitignoreMarker + "; edits are overwritten.\n")
+	b.WriteString("# Choose what's committed with 'mob-claude config set untrackedArtifacts'.\n")
+	b.WriteString("# Remove the first line to manage this file yourself.\n\n")
+	b.WriteString("# Runtime state\n")
+	for _, name := range runtimeFiles {
+		fmt.Fprintf(&b, "/%s\n", name)
+	}
+
+	var untracked []string
+	for _, artifact := range config.Artifacts {
+		if !cfg.Tracks(artifact) {
+			untracked = append(untracked, artifact)
+		}
+	}
+	if len(untracked) > 0 {
+		b.WriteString("\n# Untracked artifacts\n")
+		for _, artifact := range untracked {
+			fmt.Fprintf(&b, "/%s\n", artifactPatterns[artifact])
+		}
+	}
+	return b.String()
+}
+
+// syncGitignore brings the managed .gitignore in the config directory up
+// to date, writing one if there is none. It does nothing in a project
+// without a config directory, and reports whether the file changed.
+func syncGitignore(cfg *config.Config) (bool, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return false, nil
+	}
+
+	path := filepath.Join(dir, ".gitignore")
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if err == nil && !strings.HasPrefix(string(current), gitignoreMarker) {
+		return false, nil
+	}
+
+	content := renderGitignore(cfg)
+	if string(current) == content {
+		return false, nil
+	}
+	if err := os.WriteFile(path, []byte(content), platform.FilePerm); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// isManagedGitignore reports whether the config directory's .gitignore is
+// mob-claude's to rewrite
+func isManagedGitignore() bool {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	return err != nil || strings.HasPrefix(string(data), gitignoreMarker)
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/claudecode"
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/handoff"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/spf13/cobra"
+)
+
+var (
+	handoffTo             string
+	handoffEncrypt        bool
+	handoffAllowPlaintext bool
+	handoffFile           string
+)
+
+func newHandoffCmd() *cobra.Command {
+	handoffCmd := &cobra.Command{
+		Use:   "handoff",
+		Short: "Pass context to the next driver on another machine",
+		Long: `Sends the handoff brief, the rotation timer, and the Claude Code
+conversation to the next driver when the mob doesn't share a machine.
+
+The bundle goes through the dashboard, or through a file with --file for
+any other channel. 'start' receives a bundle waiting on the dashboard
+automatically. Set MOB_CLAUDE_HANDOFF_PASSPHRASE on both machines to
+encrypt it end to end.`,
+	}
+
+	sendCmd := &cobra.Command{
+		Use:   "send",
+		Short: "Send the handoff to the next driver",
+		Long: `Bundles the branch's handoff brief, the rotation length and any running
+timer, and the latest Claude Code conversation with its transcript, and
+leaves it on the dashboard for the next driver.
+
+The transcript holds the whole conversation, so it's only sent encrypted,
+with MOB_CLAUDE_HANDOFF_PASSPHRASE set, unless --allow-plaintext says
+otherwise.
+
+Example: mob-claude handoff send --to alice --encrypt`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runHandoffSend,
+	}
+	sendCmd.Flags().StringVar(&handoffTo, "to", "", "Only hand off to this driver")
+	sendCmd.Flags().BoolVar(&handoffEncrypt, "encrypt", false, "Require encryption with "+handoff.PassphraseEnv)
+	sendCmd.Flags().BoolVar(&handoffAllowPlaintext, "allow-plaintext", false, "Send the Claude Code transcript unencrypted")
+	sendCmd.Flags().StringVar(&handoffFile, "file", "", "Write the bundle to a file instead of the dashboard")
+
+	receiveCmd := &cobra.Command{
+		Use:   "receive",
+		Short: "Receive the handoff left for you",
+		Long: `Fetches the handoff bundle left on the dashboard, writes its brief to
+.claude/mob/handoff.md, and imports the Claude Code transcript so
+'mob-claude claude-resume' can continue the conversation.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runHandoffReceive,
+	}
+	receiveCmd.Flags().StringVar(&handoffFile, "file", "", "Read the bundle from a file instead of the dashboard")
+
+	handoffCmd.AddCommand(sendCmd, receiveCmd)
+	return handoffCmd
+}
+
+func runHandoffSend(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if handoffFile == "" && (cfg.TeamName == "" || cfg.APIURL == "") {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first, or use --file")
+	}
+	passphrase := os.Getenv(handoff.PassphraseEnv)
+	if handoffEncrypt && passphrase == "" {
+		return fmt.Errorf("--encrypt needs a passphrase in %s", handoff.PassphraseEnv)
+	}
+
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	bundle := &handoff.Bundle{
+		Branch:          branch,
+		From:            resolveDriverName("", cfg, nil),
+		To:              handoffTo,
+		SentAt:          clk.Now(),
+		RotationMinutes: cfg.RotationMinutes,
+	}
+	if bundle.Brief, err = planMgr.LoadHandoff(branch); err != nil {
+		return err
+	}
+
+	// A rotation still under way hands over what's left of its timer
+	if session, _ := config.LoadCurrentSession(); session != nil {
+		bundle.From = session.DriverName
+		applySessionPreset(cfg, session)
+		bundle.RotationMinutes = cfg.RotationMinutes
+		if deadline, err := time.Parse(time.RFC3339, session.TimerDeadline); err == nil && deadline.After(clk.Now()) {
+			bundle.TimerDeadline = &deadline
+		}
+	}
+
+	if bundle.ClaudeSessionID, err = latestClaudeSession(ctx, branch); err != nil {
+		i18n.Printf("Warning: could not look up Claude Code conversation: %v\n", err)
+	}
+	if bundle.ClaudeSessionID != "" {
+		bundle.Transcript = readTranscript(bundle.ClaudeSessionID)
+	}
+	if bundle.Brief == "" && bundle.ClaudeSessionID == "" {
+		return fmt.Errorf("nothing to hand off for %s. Run 'mob-claude next' first to write the handoff brief", branch)
+	}
+	if bundle.Transcript != nil && passphrase == "" && !handoffAllowPlaintext {
+		return fmt.Errorf("the handoff includes the Claude Code conversation, which is only sent encrypted. Set %s and pass --encrypt, or pass --allow-plaintext", handoff.PassphraseEnv)
+	}
+
+	data, err := handoff.Encode(bundle, passphrase)
+	if err != nil {
+		return err
+	}
+	if handoffFile != "" {
+		if err := os.WriteFile(handoffFile, data, platform.FilePerm); err != nil {
+			return fmt.Errorf("failed to write handoff: %w", err)
+		}
+		i18n.Printf("Handoff written to %s\n", handoffFile)
+	} else {
+		if err := newAPIClient(cfg).SendHandoff(ctx, branch, data); err != nil {
+			return err
+		}
+		i18n.Println("Handoff sent to the dashboard")
+	}
+
+	if handoffTo != "" {
+		i18n.Printf("For: %s\n", handoffTo)
+	}
+	if bundle.Transcript != nil {
+		i18n.Printf("Includes Claude Code conversation %s\n", bundle.ClaudeSessionID)
+	}
+	if passphrase != "" {
+		i18n.Printf("Encrypted with %s\n", handoff.PassphraseEnv)
+	}
+	return nil
+}
+
+func runHandoffReceive(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if handoffFile == "" && (cfg.TeamName == "" || cfg.APIURL == "") {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first, or use --file")
+	}
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+
+	driver := resolveDriverName("", cfg, nil)
+	if session, _ := config.LoadCurrentSession(); session != nil {
+		driver = session.DriverName
+	}
+
+	var bundle *handoff.Bundle
+	if handoffFile != "" {
+		data, err := os.ReadFile(handoffFile)
+		if err != nil {
+			return fmt.Errorf("failed to read handoff: %w", err)
+		}
+		if bundle, err = handoff.Decode(data, os.Getenv(handoff.PassphraseEnv)); err != nil {
+			return handoffDecodeError(err)
+		}
+	} else if bundle, err = fetchHandoff(cmd.Context(), cfg, branch, driver); err != nil {
+		return err
+	}
+	if bundle == nil {
+		i18n.Printf("No handoff waiting for %s\n", branch)
+		return nil
+	}
+	if !bundle.IsFor(driver) {
+		return fmt.Errorf("the handoff is for %s, not %s", bundle.To, driver)
+	}
+	return applyHandoff(bundle)
+}
+
+// fetchHandoff fetches and decodes the handoff bundle left on the dashboard
+// for branch. It's removed once driver takes it, but left for whoever it's
+// meant for otherwise. It returns nil if there's none.
+func fetchHandoff(ctx context.Context, cfg *config.Config, branch, driver string) (*handoff.Bundle, error) {
+	client := newAPIClient(cfg)
+	data, err := client.GetHandoff(ctx, branch)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	bundle, err := handoff.Decode(data, os.Getenv(handoff.PassphraseEnv))
+	if err != nil {
+		return nil, handoffDecodeError(err)
+	}
+	if !bundle.IsFor(driver) {
+		return bundle, nil
+	}
+	if err := client.DeleteHandoff(ctx, branch); err != nil {
+		i18n.Printf("Warning: could not remove handoff from dashboard: %v\n", err)
+	}
+	return bundle, nil
+}
+
+func handoffDecodeError(err error) error {
+	if errors.Is(err, handoff.ErrEncrypted) {
+		return fmt.Errorf("the handoff is encrypted. Set %s to its passphrase", handoff.PassphraseEnv)
+	}
+	if errors.Is(err, handoff.ErrNotEncrypted) {
+		return fmt.Errorf("the handoff isn't encrypted, but %s is set, so it may not come from your mob. Ask the sender to set the passphrase too", handoff.PassphraseEnv)
+	}
+	return err
+}
+
+// applyHandoff writes a received bundle's brief and imports its Claude Code
+// transcript into this machine's transcripts for the project
+func applyHandoff(bundle *handoff.Bundle) error {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	i18n.Printf("Received handoff from %s (sent %s)\n", bundle.From, bundle.SentAt.Local().Format("15:04"))
+	if bundle.Brief != "" {
+		if err := planMgr.SaveHandoff(bundle.Brief); err != nil {
+			return err
+		}
+	}
+	if bundle.TimerDeadline != nil && bundle.TimerDeadline.After(clk.Now()) {
+		i18n.Printf("The previous rotation's timer runs until %s\n", bundle.TimerDeadline.Local().Format("15:04"))
+	}
+	if len(bundle.Transcript) > 0 {
+		if err := writeTranscript(bundle.ClaudeSessionID, bundle.Transcript); err != nil {
+			i18n.Printf("Warning: could not import Claude Code conversation: %v\n", err)
+		} else {
+			i18n.Printf("Run 'mob-claude claude-resume %s' to continue the previous driver's conversation\n", bundle.ClaudeSessionID)
+		}
+	}
+	return nil
+}
+
+// readTranscript returns a conversation's transcript, or nil if it isn't
+// on this machine
+func readTranscript(sessionID string) []byte {
+	dir, err := claudecode.TranscriptDir(".")
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, sessionID+".jsonl"))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// writeTranscript stores a conversation's transcript where Claude Code
+// looks for this project's conversations
+func writeTranscript(sessionID string, transcript []byte) error {
+	if sessionID == "" || filepath.Base(sessionID) != sessionID {
+		return fmt.Errorf("invalid session ID %q", sessionID)
+	}
+	dir, err := claudecode.TranscriptDir(".")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, sessionID+".jsonl"), transcript, platform.FilePerm)
+}
+
+// receiveStartHandoff applies a handoff waiting on the dashboard when a
+// session starts, returning it so start can take over its timer
+func receiveStartHandoff(ctx context.Context, cfg *config.Config, branch, driver string) *handoff.Bundle {
+	bundle, err := fetchHandoff(ctx, cfg, branch, driver)
+	if err != nil {
+		i18n.Printf("Warning: could not receive handoff: %v\n", err)
+		return nil
+	}
+	if bundle == nil {
+		return nil
+	}
+	if !bundle.IsFor(driver) {
+		i18n.Printf("A handoff is waiting for %s\n", bundle.To)
+		return nil
+	}
+	if err := applyHandoff(bundle); err != nil {
+		i18n.Printf("Warning: could not receive handoff: %v\n", err)
+	}
+	return bundle
+}
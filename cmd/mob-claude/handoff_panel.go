@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// printHandoffPanel shows a joining driver where the previous rotation left
+// off: its TL;DR, driver note, next steps, and the plan's outstanding tasks.
+// Without a previous rotation, it prints the handoff brief if there is one.
+func printHandoffPanel(ctx context.Context, cfg *config.Config, planMgr *plans.Manager, branch string, online bool) {
+	last := lastRotation(ctx, cfg, planMgr, branch, online)
+	if last == nil {
+		if brief, err := planMgr.LoadHandoff(branch); err == nil && brief != "" {
+			i18n.Println("\n=== Handoff from the previous driver ===")
+			fmt.Print(brief)
+		}
+		return
+	}
+
+	driver := last.DriverName
+	if driver == "" {
+		driver = i18n.T("previous driver")
+	}
+	ago := formatDuration(clk.Now().Sub(last.Timestamp).Round(time.Minute))
+	i18n.Printf("\n=== Handoff from %s, %s ago ===\n", driver, ago)
+	if last.TLDR != "" {
+		i18n.Printf("TL;DR: %s\n", last.TLDR)
+	}
+	if last.DriverNote != "" {
+		fmt.Printf("\n%s:\n", i18n.T("Driver note"))
+		for _, line := range strings.Split(strings.TrimSpace(last.DriverNote), "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	printPanelList(i18n.T("Next steps"), last.NextSteps)
+	if plan, err := planMgr.LoadPlan(branch); err == nil {
+		printPanelList(i18n.T("Outstanding tasks"), plans.OutstandingTasks(plan, i18n.Locale()))
+	}
+	if brief, err := planMgr.LoadHandoff(branch); err == nil && brief != "" {
+		i18n.Printf("\nFull handoff brief: %s\n", plans.HandoffFile)
+	}
+}
+
+func printPanelList(heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Printf("\n%s:\n", heading)
+	for _, item := range items {
+		fmt.Printf("  - %s\n", item)
+	}
+}
+
+// lastRotation returns the branch's most recent rotation: the latest local
+// summary, or the dashboard's latest rotation when it's newer, e.g. because
+// the previous driver was on another machine
+func lastRotation(ctx context.Context, cfg *config.Config, planMgr *plans.Manager, branch string, online bool) *plans.Summary {
+	var last *plans.Summary
+	if summaries, err := planMgr.LoadBranchSummaries(branch); err == nil && len(summaries) > 0 {
+		last = summaries[len(summaries)-1]
+	}
+	if !online {
+		return last
+	}
+	rotation, err := newAPIClient(cfg).GetLatestRotation(ctx, branch)
+	if err != nil || rotation == nil {
+		return last
+	}
+	if remote := rotationSummary(branch, rotation); last == nil || remote.Timestamp.After(last.Timestamp) {
+		return remote
+	}
+	return last
+}
+
+// rotationSummary reads the summary of a dashboard rotation
+func rotationSummary(branch string, rotation *api.Rotation) *plans.Summary {
+	summary := &plans.Summary{
+		Branch:     branch,
+		Timestamp:  rotation.EndedAt,
+		DriverName: rotation.DriverName,
+		DriverNote: rotation.DriverNote,
+		TLDR:       rotation.SummaryTLDR,
+	}
+	if summary.Timestamp.IsZero() {
+		summary.Timestamp = rotation.StartedAt
+	}
+	var details struct {
+		NextSteps []string `json:"nextSteps"`
+	}
+	if json.Unmarshal(rotation.SummaryJSON, &details) == nil {
+		summary.NextSteps = details.NextSteps
+	}
+	return summary
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// heartbeatInterval is how often a live session tells the dashboard
+	// it's still there
+	heartbeatInterval = time.Minute
+
+	// heartbeatPIDFile names the process sending the repository's
+	// heartbeats, so a newer one can take over from it
+	heartbeatPIDFile = "heartbeat.pid"
+)
+
+func newHeartbeatCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "heartbeat",
+		Short: "Tell the dashboard the session is still alive",
+		Long: `Sends a heartbeat for the current session every minute until the session
+ends, so the dashboard can mark a workstream stale when its session dies
+without 'mob-claude done', and 'status' can show when it was last seen.
+
+'mob-claude start' runs this in the background, so there's rarely a reason to
+run it yourself. Only one heartbeat runs per repository: a new one takes over
+and the old one exits.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runHeartbeat,
+	}
+}
+
+func runHeartbeat(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	pidFile := filepath.Join(dir, heartbeatPIDFile)
+	pid := strconv.Itoa(os.Getpid())
+	if err := os.WriteFile(pidFile, []byte(pid+"\n"), platform.FilePerm); err != nil {
+		return fmt.Errorf("failed to write heartbeat pid file: %w", err)
+	}
+	defer func() {
+		if heartbeatOwner(pidFile) == pid {
+			os.Remove(pidFile)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	client := newAPIClient(cfg)
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		if heartbeatOwner(pidFile) != pid {
+			i18n.Println("Another heartbeat took over; stopping")
+			return nil
+		}
+		session, err := config.LoadCurrentSession()
+		if err != nil {
+			i18n.Printf("Warning: could not load session: %v\n", err)
+		} else if session == nil {
+			i18n.Println("No active mob session; heartbeat stopped")
+			return nil
+		} else if err := sendHeartbeat(ctx, client, session); err != nil {
+			i18n.Printf("Warning: could not send heartbeat: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendHeartbeat reports the session's driver to the dashboard and records
+// when the heartbeat got through
+func sendHeartbeat(ctx context.Context, client *api.Client, session *config.CurrentSession) error {
+	heartbeat := api.Heartbeat{DriverName: session.DriverName, SentAt: clk.Now()}
+	if deadline, err := time.Parse(time.RFC3339, session.TimerDeadline); err == nil {
+		heartbeat.Deadline = &deadline
+	}
+	if err := client.SendHeartbeat(ctx, session.Branch, heartbeat); err != nil {
+		return err
+	}
+	return config.UpdateFreshness(func(f *config.Freshness) { f.Heartbeat = clk.Now() })
+}
+
+// heartbeatOwner returns the pid recorded in the heartbeat pid file, or ""
+// if there is none
+func heartbeatOwner(pidFile string) string {
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// startHeartbeat runs 'mob-claude heartbeat' in the background for the
+// session just started. It takes over from any heartbeat already running and
+// exits by itself once the session ends.
+func startHeartbeat(cfg *config.Config) {
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		i18n.Printf("Warning: could not start heartbeat: %v\n", err)
+		return
+	}
+
+	args := []string{"heartbeat"}
+	if name := config.ActiveProfile(cfg); name != "" {
+		args = append(args, "--profile", name)
+	}
+	heartbeat := exec.Command(exe, args...)
+	if err := heartbeat.Start(); err != nil {
+		i18n.Printf("Warning: could not start heartbeat: %v\n", err)
+		return
+	}
+	_ = heartbeat.Process.Release()
+}
+
+// printLastSeen shows when the dashboard last heard a heartbeat for the
+// branch's workstream, and from whom
+func printLastSeen(ctx context.Context, cfg *config.Config, branch string) {
+	ws, err := newAPIClient(cfg).GetWorkstream(ctx, branch)
+	if err != nil || ws == nil || ws.LastSeenAt == nil {
+		return
+	}
+	if ws.LastSeenBy != "" {
+		i18n.Printf("Last seen: %s (%s)\n", formatAge(*ws.LastSeenAt), ws.LastSeenBy)
+	} else {
+		i18n.Printf("Last seen: %s\n", formatAge(*ws.LastSeenAt))
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyAll    bool
+	historyRemote bool
+	historyLimit  int
+	historyTags   []string
+)
+
+func newHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List past rotations",
+		Long: `Lists the rotations recorded in local summaries for the current branch,
+oldest first, with their driver, start time, and duration.
+
+With --remote, lists the most recent rotations recorded on the dashboard
+instead, including their IDs (for 'plan revert --to-rotation').
+
+With --tag, lists only rotations tagged with one of the given tags (see
+'next --tags').`,
+		Args: cobra.NoArgs,
+		RunE: runHistory,
+	}
+	cmd.Flags().BoolVar(&historyAll, "all", false, "Include rotations from every branch")
+	cmd.Flags().BoolVar(&historyRemote, "remote", false, "List rotations from the dashboard")
+	cmd.Flags().IntVar(&historyLimit, "limit", 20, "Maximum number of rotations to list with --remote")
+	cmd.Flags().StringSliceVar(&historyTags, "tag", nil, "Only list rotations with one of these tags (comma-separated or repeated)")
+	return cmd
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if historyRemote {
+		return runRemoteHistory(cmd.Context())
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	branch := ""
+	if !historyAll {
+		if branch, err = currentPlanBranch(); err != nil {
+			return err
+		}
+	}
+
+	var files []string
+	if historyAll {
+		files, err = planMgr.ListSummaries()
+	} else {
+		files, err = planMgr.ListBranchSummaries(branch)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list summaries: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	shown := []*plans.Summary{}
+	for _, file := range files {
+		s, err := planMgr.LoadSummary(file)
+		if err != nil {
+			i18n.Printf("Warning: skipping %v\n", err)
+			continue
+		}
+		if !plans.HasAnyTag(s.Tags, historyTags) {
+			continue
+		}
+		started := s.Timestamp
+		if !s.StartedAt.IsZero() {
+			started = s.StartedAt
+		}
+		duration := "-"
+		if d := s.RotationDuration(); d > 0 {
+			duration = formatDuration(d)
+		}
+		if historyAll {
+			fmt.Fprintf(w, "%s\t", s.Branch)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", started.Format("2006-01-02 15:04"), duration, s.DriverName, strings.Join(s.Tags, ","), s.TLDR)
+		shown = append(shown, s)
+	}
+	w.Flush()
+	setResult(shown)
+
+	if len(shown) == 0 {
+		i18n.Println("No rotations recorded yet")
+	}
+	return nil
+}
+
+// runRemoteHistory lists the branch's latest rotations from the dashboard,
+// fetching only as many pages as the limit needs
+func runRemoteHistory(ctx context.Context) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+
+	client := newAPIClient(cfg)
+	var rotations []api.Rotation
+	opts := api.ListOptions{Limit: historyLimit}
+	for len(rotations) < historyLimit {
+		page, err := client.ListRotations(ctx, branch, opts)
+		if err != nil {
+			return err
+		}
+		for _, r := range page.Rotations {
+			if plans.HasAnyTag(r.Tags, historyTags) {
+				rotations = append(rotations, r)
+			}
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+	if len(rotations) > historyLimit {
+		rotations = rotations[:historyLimit]
+	}
+	setResult(rotations)
+	if len(rotations) == 0 {
+		i18n.Println("No rotations recorded yet")
+		return nil
+	}
+
+	// Pages are newest first; list oldest first like local history
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i := len(rotations) - 1; i >= 0; i-- {
+		r := rotations[i]
+		duration := "-"
+		if r.DurationSeconds > 0 {
+			duration = formatDuration(time.Duration(r.DurationSeconds) * time.Second)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.StartedAt.Local().Format("2006-01-02 15:04"), duration, r.DriverName, strings.Join(r.Tags, ","), r.SummaryTLDR)
+	}
+	return w.Flush()
+}
+
+// formatAge renders how long ago t was, e.g. "5m ago"
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return i18n.T("never")
+	}
+	age := clk.Now().Sub(t)
+	if age < time.Minute {
+		return i18n.T("just now")
+	}
+	return i18n.Sprintf("%s ago", formatDuration(age))
+}
+
+// formatDuration renders a duration as "1h05m" or "25m"
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "init",
+		Short: "Set up mob-claude in this repository",
+		Long: `Creates .claude/mob with a default config.json, unless there is one
+already, and the managed .gitignore that keeps runtime files such as the
+session, outbox, and logs out of git while config, summaries, archives, the
+handoff brief, and templates can be committed.
+
+Choose which of those are committed with 'config set untrackedArtifacts',
+e.g. "archive,handoff". mob-claude also writes the .gitignore on first use, so
+running init is optional.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runInit,
+	}
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	configPath := filepath.Join(dir, config.ConfigFileName)
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		i18n.Printf("Created %s\n", configPath)
+	}
+
+	changed, err := syncGitignore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	gitignorePath := filepath.Join(dir, ".gitignore")
+	switch {
+	case !isManagedGitignore():
+		i18n.Printf("Leaving %s alone: it isn't managed by mob-claude\n", gitignorePath)
+		return nil
+	case changed:
+		i18n.Printf("Wrote %s\n", gitignorePath)
+	default:
+		i18n.Printf("%s is up to date\n", gitignorePath)
+	}
+
+	var tracked []string
+	for _, artifact := range config.Artifacts {
+		if cfg.Tracks(artifact) {
+			tracked = append(tracked, artifact)
+		}
+	}
+	if len(tracked) == 0 {
+		tracked = []string{i18n.T("nothing")}
+	}
+	i18n.Printf("Committable: %s\n", strings.Join(tracked, ", "))
+	return nil
+}
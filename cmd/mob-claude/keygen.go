@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"filippo.io/age"
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var keygenForce bool
+
+func newKeygenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate a team key to encrypt summaries at rest",
+		Long: `Generates an age X25519 key and stores it as encryptionKey. From then on,
+summaries, the summary journal, and session archives are written encrypted,
+and read back transparently. Plans stay plaintext: they're edited by hand.
+
+Share the printed key with the rest of the team over a secure channel. Each
+teammate stores it with 'mob-claude config set encryptionKey <key>'. Without
+it, encrypted summaries can't be read, by anyone.
+
+Example: mob-claude keygen`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runKeygen,
+	}
+	cmd.Flags().BoolVar(&keygenForce, "force", false, "Replace an existing key; summaries encrypted with it become unreadable")
+	return cmd
+}
+
+func runKeygen(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.EncryptionKey != "" && !keygenForce {
+		return fmt.Errorf("an encryptionKey is already set. Rerun with --force to replace it")
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	if err := config.SetSecret("encryptionKey", identity.String()); err != nil {
+		return fmt.Errorf("failed to store encryptionKey: %w", err)
+	}
+
+	i18n.Println("Stored a new encryptionKey. Share it with your team:")
+	fmt.Printf("\n  %s\n\n", identity.String())
+	i18n.Println("Each teammate runs: mob-claude config set encryptionKey <key>")
+	return nil
+}
@@ -1,19 +1,35 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"os/exec"
+	"os/signal"
 	"os/user"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
-	"github.com/mob-claude/mob-claude/internal/api"
+	"filippo.io/age"
 	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/events"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/ids"
+	"github.com/mob-claude/mob-claude/internal/metrics"
 	"github.com/mob-claude/mob-claude/internal/mob"
-	"github.com/mob-claude/mob-claude/internal/plans"
-	"github.com/mob-claude/mob-claude/internal/summary"
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/mob-claude/mob-claude/internal/secrets"
+	"github.com/mob-claude/mob-claude/internal/timer"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/clock"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/mob-claude/mob-claude/pkg/summary"
 	"github.com/spf13/cobra"
 )
 
@@ -21,18 +37,42 @@ var (
 	version = "dev"
 
 	// Global flags
-	skipSummary bool
-	message     string
+	skipSummary  bool
+	message      string
+	rotationTags []string
+	profileName  string
+	diffBase     string
+	outputFlag   string
+	httpTimeout  int
+
+	configShowJSON bool
+
+	// configKeys lists the keys accepted by 'config set' and 'config unset'
+	configKeys = []string{"apiUrl", "teamName", "model", "maxTurns", "skipSummary", "rotationMinutes", "autoNext", "plainWipCommits", "timerHighContrast", "timerLargeText", "timerAlert", "language", "summaryLanguage", "baseBranch", "gitRemote", "mobPath", "engine", "httpTimeout", "httpProxy", "caBundle", "storage", "storageEndpoint", "storageRegion", "untrackedArtifacts", "keepSummariesDays", "keepArchives", "summaryRetries", "summaryContext", "redactSecrets", "redactPatterns", "summaryIncludePaths", "summaryExcludePaths", "claude.systemPrompt", "claude.allowedTools", "claude.maxOutputTokens", "claude.extraArgs", "statusPreview.sections", "statusPreview.maxLines", "driverName", "profile", "profiles.<name>.apiUrl", "profiles.<name>.teamName", "profiles.<name>.model", "profiles.<name>.apiToken", "profiles.<name>.signingSecret", "apiToken", "slackWebhook", "signingSecret", "encryptionKey"}
+
+	// Time and ID sources; every timestamp and idempotency key comes from
+	// these rather than from time.Now or crypto/rand directly
+	clk   clock.Clock   = clock.System
+	idGen ids.Generator = ids.Random
 )
 
 func main() {
+	initLocale()
+
 	rootCmd := &cobra.Command{
 		Use:   "mob-claude",
 		Short: "Mob programming with Claude Code integration",
 		Long: `mob-claude wraps mob.sh with Claude Code context management.
-It manages plan files and generates AI-powered rotation summaries.`,
-		Version: version,
+It manages plan files and generates AI-powered rotation summaries.
+
+With --output json, a command prints a single JSON object to stdout when it
+finishes ({"command", "ok", "error", "data"}) and everything else to stderr.`,
+		Version:           version,
+		PersistentPreRunE: preRun,
 	}
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Config profile to use for this command")
+	rootCmd.PersistentFlags().StringVar(&outputFlag, "output", outputText, "Output format: text or json")
+	rootCmd.PersistentFlags().IntVar(&httpTimeout, "http-timeout", 0, "Dashboard request timeout in seconds for this command (overrides httpTimeout)")
 
 	// Start command
 	startCmd := &cobra.Command{
@@ -40,9 +80,29 @@ It manages plan files and generates AI-powered rotation summaries.`,
 		Short: "Start or join a mob session",
 		Long: `Wraps 'mob start', fetches the current plan, and initializes session tracking.
 
-All arguments are passed through to mob.sh.
+All other arguments are passed through to mob.sh.
+
+mob-claude flags:
+  --template <name>   Create a new plan from a named template
+                      (.claude/mob/templates/<name>.md or the dashboard)
+  --ai-plan           Have Claude draft a new plan's goal and tasks from the
+                      branch name, its ticket, and recent commits, then edit it
+  --pick              Choose the branch from the team's active workstreams on
+                      the dashboard (branch, plan title, last driver)
+  --preset <name>     Apply a session preset from config (timer, template,
+                      alerts, roster)
+  --driver <name>     Record <name> as the driver instead of the git user
+  --profile <name>    Use a config profile for this command
+  --http-timeout <s>  Dashboard request timeout in seconds for this command
+  --output json       Print the result as JSON on stdout
+
 Example: mob-claude start -i
-Example: mob-claude start -b my-feature --include-uncommitted-changes`,
+Example: mob-claude start -b my-feature --include-uncommitted-changes
+Example: mob-claude start --template bugfix
+Example: mob-claude start -b proj-123-login --ai-plan
+Example: mob-claude start --preset bug-bash
+Example: mob-claude start --pick
+Example: mob-claude start --driver "Ana Lima"`,
 		Args:               cobra.ArbitraryArgs,
 		DisableFlagParsing: true,
 		RunE:               runStart,
@@ -56,14 +116,17 @@ Example: mob-claude start -b my-feature --include-uncommitted-changes`,
 
 Use -- to pass flags through to mob.sh.
 Example: mob-claude next -- --stay
-Example: mob-claude next -m "my note" -- --stay`,
-		Args:                  cobra.ArbitraryArgs,
-		FParseErrWhitelist:    cobra.FParseErrWhitelist{UnknownFlags: true},
-		RunE:                  runNext,
+Example: mob-claude next -m "my note" -- --stay
+Example: mob-claude next --tags backend,tests`,
+		Args:               cobra.ArbitraryArgs,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
+		RunE:               runNext,
 	}
 	nextCmd.Flags().SetInterspersed(false)
 	nextCmd.Flags().StringVarP(&message, "message", "m", "", "Note for the next driver")
 	nextCmd.Flags().BoolVar(&skipSummary, "skip-summary", false, "Skip AI summary generation")
+	nextCmd.Flags().StringSliceVar(&rotationTags, "tags", nil, "Comma-separated tags for what the rotation was spent on, e.g. backend,tests")
+	addBaseFlag(nextCmd)
 
 	// Done command
 	doneCmd := &cobra.Command{
@@ -71,15 +134,31 @@ Example: mob-claude next -m "my note" -- --stay`,
 		Short: "Complete the mob session",
 		Long: `Generates a final summary and runs 'mob done'.
 
+With --archive, the branch's plan, summaries, and handoff brief are bundled
+with the final diff stats into .claude/mob/archive/<branch>/<date>.tar.gz
+and then removed, so they don't pile up. --upload-archive also attaches the
+archive to the workstream on the dashboard.
+
+If plan tasks are still unchecked, done asks whether to move them to a
+follow-up workstream (<branch>-followups), mark them done, or abort. Without
+a terminal, say which with --remaining followup, done, or abort.
+
 Use -- to pass flags through to mob.sh.
-Example: mob-claude done -- --no-squash`,
-		Args:                  cobra.ArbitraryArgs,
-		FParseErrWhitelist:    cobra.FParseErrWhitelist{UnknownFlags: true},
-		RunE:                  runDone,
+Example: mob-claude done -- --no-squash
+Example: mob-claude done --remaining followup`,
+		Args:               cobra.ArbitraryArgs,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
+		SilenceUsage:       true,
+		RunE:               runDone,
 	}
 	doneCmd.Flags().SetInterspersed(false)
 	doneCmd.Flags().StringVarP(&message, "message", "m", "", "Final note for the session")
 	doneCmd.Flags().BoolVar(&skipSummary, "skip-summary", false, "Skip AI summary generation")
+	doneCmd.Flags().StringSliceVar(&rotationTags, "tags", nil, "Comma-separated tags for what the last rotation was spent on")
+	doneCmd.Flags().BoolVar(&doneArchive, "archive", false, "Archive the plan, summaries, and handoff brief, then remove them")
+	doneCmd.Flags().BoolVar(&doneUploadArchive, "upload-archive", false, "Archive as with --archive and upload the archive to the dashboard")
+	doneCmd.Flags().StringVar(&doneRemaining, "remaining", "", "What to do with unchecked plan tasks: followup, done, or abort")
+	addBaseFlag(doneCmd)
 
 	// Status command
 	statusCmd := &cobra.Command{
@@ -99,22 +178,26 @@ Example: mob-claude done -- --no-squash`,
 	configShowCmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show current configuration",
+		Long:  "Shows the configuration in effect, with the active profile applied and secrets redacted.",
 		RunE:  runConfigShow,
 	}
+	configShowCmd.Flags().BoolVar(&configShowJSON, "json", false, "Print the configuration as JSON")
 
 	configSetCmd := &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Set a configuration value",
-		Long:  "Available keys: apiUrl, teamName, model, maxTurns, skipSummary",
+		Long:  "Available keys: " + strings.Join(configKeys, ", "),
 		Args:  cobra.ExactArgs(2),
 		RunE:  runConfigSet,
 	}
 
-	configCmd.AddCommand(configShowCmd, configSetCmd)
+	configCmd.AddCommand(configShowCmd, configSetCmd, newConfigUnsetCmd(), newConfigEditCmd(), newConfigValidateCmd())
 
-	rootCmd.AddCommand(startCmd, nextCmd, doneCmd, statusCmd, configCmd)
+	rootCmd.AddCommand(startCmd, nextCmd, doneCmd, statusCmd, configCmd, newBackfillCmd(), newTimerCmd(), newNoteCmd(), newTaskCmd(), newWatchCmd(), newDaemonCmd(), newSyncPlansCmd(), newHistoryCmd(), newSummarizeCmd(), newSummaryCmd(), newProfileCmd(), newPlanCmd(), newAnnouncementsCmd(), newActivityCmd(), newPresenceCmd(), newFairnessCmd(), newClaudeResumeCmd(), newClaudeHookCmd(), newMetricsCmd(), newServeCmd(), newSearchCmd(), newHandoffCmd(), newRebindCmd(), newNavCmd(), newHeartbeatCmd(), newFollowCmd(), newInitCmd(), newStorageCmd(), newReplayCmd(), newKeygenCmd(), newWorkstreamCmd(), newStatsCmd(), newNotifyCmd(), newScheduleCmd(), newAmendLastCmd(), newSessionCmd(), newPruneCmd())
 
-	if err := rootCmd.Execute(); err != nil {
+	cmd, err := rootCmd.ExecuteC()
+	finishOutput(cmd, err)
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -127,30 +210,136 @@ func runStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	mobWrapper := mob.NewWrapper()
+	// Pull out our own flags before passing the rest to mob.sh
+	templateName, args := takeStartFlag(args, "template")
+	presetName, args := takeStartFlag(args, "preset")
+	driverFlag, args := takeStartFlag(args, "driver")
+	aiPlan, args := takeStartSwitch(args, "ai-plan")
+	pick, args := takeStartSwitch(args, "pick")
+	if name, rest := takeStartFlag(args, "profile"); name != "" {
+		config.SetProfileOverride(name)
+		args = rest
+	}
+	if seconds, rest := takeStartFlag(args, "http-timeout"); seconds != "" {
+		if _, err := fmt.Sscanf(seconds, "%d", &httpTimeout); err != nil || httpTimeout < 0 {
+			return fmt.Errorf("invalid --http-timeout value: %s", seconds)
+		}
+		args = rest
+	}
+	if format, rest := takeStartFlag(args, "output"); format != "" {
+		if err := setOutputFormat(format); err != nil {
+			return err
+		}
+		args = rest
+	}
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		i18n.Printf("Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+	mobWrapper, err := newMobWrapper(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Check mob.sh is installed
 	if err := mobWrapper.CheckMobInstalled(); err != nil {
 		return err
 	}
 
-	// Load config
-	cfg, err := config.Load()
-	if err != nil {
-		fmt.Printf("Warning: could not load config: %v\n", err)
+	// Set up a new project before its first session
+	if needsFirstRunSetup() {
+		if cfg, err = runFirstRunWizard(); err != nil {
+			return err
+		}
+	}
+
+	// Apply the session preset on top of config
+	var preset config.Preset
+	if presetName != "" {
+		var ok bool
+		if preset, ok = cfg.Presets[presetName]; !ok {
+			return fmt.Errorf("unknown preset: %s", presetName)
+		}
+		preset.Apply(cfg)
+		if templateName == "" {
+			templateName = preset.Template
+		}
 	}
 
 	// Initialize plan manager
-	planMgr, err := plans.NewManager()
+	planMgr, err := newPlanManager()
 	if err != nil {
 		return fmt.Errorf("failed to initialize plan manager: %w", err)
 	}
 
+	ctx := cmd.Context()
+
+	// Check the dashboard and Claude up front, so a degraded session is
+	// announced once instead of warning on every call
+	apiConfigured := cfg.TeamName != "" && cfg.APIURL != ""
+	apiHealthy := false
+	var apiErr, claudeErr error
+	if apiConfigured {
+		if apiErr = newAPIClient(cfg).Ping(ctx); apiErr == nil {
+			apiHealthy = true
+		}
+	}
+	if !cfg.SkipSummary {
+		claudeErr = summary.CheckClaudeAvailable()
+	}
+	printDegradedBanner(apiErr, claudeErr)
+
+	// Show pinned team announcements the user hasn't seen yet
+	if apiHealthy {
+		showNewAnnouncements(ctx, cfg)
+	}
+
+	// Let a joiner choose the branch from the dashboard's workstreams
+	if pick {
+		if !apiHealthy {
+			return fmt.Errorf("--pick needs the dashboard, which isn't reachable")
+		}
+		branch, err := pickWorkstream(ctx, cfg, mobWrapper)
+		if err != nil {
+			return err
+		}
+		if err := mobWrapper.Checkout(branch); err != nil {
+			return fmt.Errorf("failed to check out %s: %w", branch, err)
+		}
+	}
+
+	// Set the session up from the team's schedule, unless the preset did
+	roster := preset.Roster
+	block := scheduledBlock(ctx, cfg, apiHealthy)
+	if block != nil {
+		if preset.RotationMinutes == 0 && block.Block.RotationMinutes > 0 {
+			cfg.RotationMinutes = block.Block.RotationMinutes
+		}
+		if len(roster) == 0 {
+			roster = block.Block.Roster
+		}
+	}
+
+	// Work out who is driving
+	driverName := resolveDriverName(driverFlag, cfg, roster)
+
+	// Catch ordering mistakes before joining
+	if apiHealthy {
+		if branch, err := mobWrapper.GetStartBranch(args); err == nil && !confirmExpectedDriver(ctx, cfg, branch, driverName, roster) {
+			return fmt.Errorf("start cancelled")
+		}
+	}
+
 	// Run mob start (pass all args through to mob.sh)
-	fmt.Println("Starting mob session...")
+	i18n.Println("Starting mob session...")
 	if err := mobWrapper.Start("", args...); err != nil {
+		explainConflicts(cfg, mobWrapper)
 		return fmt.Errorf("mob start failed: %w", err)
 	}
+	explainConflicts(cfg, mobWrapper)
 
 	// Get the actual branch we're on now
 	currentBranch, err := mobWrapper.GetCurrentBranch()
@@ -169,16 +358,18 @@ func runStart(cmd *cobra.Command, args []string) error {
 		repoURL = "unknown"
 	}
 
-	// Try to fetch plan from API if configured
+	// Fetch the plan and register the workstream at the same time, sending
+	// what earlier offline rotations left queued alongside
+	var remote *startRemote
 	var planText string
-	if cfg.TeamName != "" && cfg.APIURL != "" {
-		client := api.NewClient(cfg.APIURL, cfg.TeamName)
-		remotePlan, err := client.GetPlan(baseBranch)
-		if err != nil {
-			fmt.Printf("Warning: could not fetch plan from API: %v\n", err)
-		} else if remotePlan != "" {
-			planText = remotePlan
-			fmt.Println("Fetched plan from dashboard")
+	if apiHealthy {
+		remote = fetchStartRemote(ctx, cfg, repoURL, baseBranch)
+		if remote.planErr != nil {
+			i18n.Printf("Warning: could not fetch plan from API: %v\n", remote.planErr)
+		} else if remote.plan != "" {
+			planText = remote.plan
+			recordPlanSync(baseBranch)
+			i18n.Println("Fetched plan from dashboard")
 		}
 	}
 
@@ -188,59 +379,127 @@ func runStart(cmd *cobra.Command, args []string) error {
 	// Decide which plan to use
 	if planText == "" && localPlan == "" {
 		// Create a new plan
-		fmt.Printf("Creating new plan for branch: %s\n", baseBranch)
-		if err := planMgr.CreateDefaultPlan(baseBranch); err != nil {
-			fmt.Printf("Warning: could not create plan: %v\n", err)
+		i18n.Printf("Creating new plan for branch: %s\n", baseBranch)
+		var err error
+		template := resolvePlanTemplate(ctx, cfg, planMgr, templateName, apiHealthy)
+		switch {
+		case aiPlan:
+			err = planMgr.SavePlan(baseBranch, draftPlan(cfg, planMgr, mobWrapper, baseBranch, template, claudeErr))
+		case template != "":
+			err = planMgr.CreatePlanFromTemplate(baseBranch, template)
+		default:
+			err = planMgr.CreateDefaultPlan(baseBranch)
+		}
+		if err == nil {
+			err = planMgr.UpdatePlanFrontMatter(baseBranch, func(fm *plans.FrontMatter) { fm.Owner = driverName })
+		}
+		if err != nil {
+			i18n.Printf("Warning: could not create plan: %v\n", err)
 		} else {
-			fmt.Printf("Plan created at: %s\n", planMgr.GetPlanPath(baseBranch))
+			i18n.Printf("Plan created at: %s\n", planMgr.GetPlanPath(baseBranch))
 		}
 	} else if planText != "" && planText != localPlan {
 		// Remote plan is newer, save it locally
 		if err := planMgr.SavePlan(baseBranch, planText); err != nil {
-			fmt.Printf("Warning: could not save plan locally: %v\n", err)
+			i18n.Printf("Warning: could not save plan locally: %v\n", err)
 		} else {
-			fmt.Println("Synced plan from dashboard")
+			i18n.Println("Synced plan from dashboard")
 		}
 	} else if localPlan != "" {
-		fmt.Printf("Using existing plan: %s\n", planMgr.GetPlanPath(baseBranch))
+		i18n.Printf("Using existing plan: %s\n", planMgr.GetPlanPath(baseBranch))
 	}
 
-	// Get current user for driver name
-	driverName := getDriverName()
-
 	// Save current session
 	session := &config.CurrentSession{
 		Branch:     baseBranch,
 		RepoURL:    repoURL,
-		StartedAt:  time.Now().Format(time.RFC3339),
+		StartedAt:  clk.Now().Format(time.RFC3339),
 		DriverName: driverName,
+		Preset:     presetName,
+		Roster:     roster,
+
+		Offline:           apiConfigured && !apiHealthy,
+		ClaudeUnavailable: claudeErr != nil,
+	}
+	if cfg.RotationMinutes > 0 {
+		session.TimerDeadline = timer.Deadline(clk.Now(), cfg.RotationMinutes).Format(time.RFC3339)
 	}
 
-	// Try to register workstream with API
-	if cfg.TeamName != "" && cfg.APIURL != "" {
-		client := api.NewClient(cfg.APIURL, cfg.TeamName)
-		workstream, err := client.CreateWorkstream(repoURL, baseBranch)
-		if err != nil {
-			fmt.Printf("Warning: could not register with dashboard: %v\n", err)
+	// Try to register workstream with API, deferring it if the dashboard is down
+	if remote != nil {
+		if remote.wsErr != nil {
+			i18n.Printf("Warning: could not register with dashboard, will retry later: %v\n", remote.wsErr)
+			session.PendingRegistration = true
 		} else {
-			session.WorkstreamID = workstream.ID
-			fmt.Printf("Registered with dashboard: %s/team/%s\n", cfg.APIURL, cfg.TeamName)
+			session.WorkstreamID = remote.ws.ID
+			i18n.Printf("Registered with dashboard: %s/team/%s\n", cfg.APIURL, cfg.TeamName)
+			syncPlanFields(planMgr, baseBranch, remote.ws.CustomFields)
+		}
+	} else if apiConfigured {
+		session.PendingRegistration = true
+	}
+	recordPlanMetadata(planMgr, baseBranch, session.WorkstreamID)
+
+	// Pick up what a driver on another machine handed off through the dashboard
+	if apiHealthy {
+		if bundle := receiveStartHandoff(ctx, cfg, baseBranch, driverName); bundle != nil &&
+			session.TimerDeadline == "" && bundle.RotationMinutes > 0 {
+			cfg.RotationMinutes = bundle.RotationMinutes
+			session.TimerDeadline = timer.Deadline(clk.Now(), cfg.RotationMinutes).Format(time.RFC3339)
 		}
 	}
 
 	if err := config.SaveCurrentSession(session); err != nil {
-		fmt.Printf("Warning: could not save session: %v\n", err)
+		i18n.Printf("Warning: could not save session: %v\n", err)
+	}
+	if _, err := syncGitignore(cfg); err != nil {
+		i18n.Printf("Warning: could not update .gitignore: %v\n", err)
+	}
+	emitNotification(ctx, cfg, events.SessionStarted,
+		i18n.Sprintf("%s started a mob session on %s", driverName, session.Branch),
+		"", session.Branch, driverName, nil)
+	startHeartbeat(cfg)
+
+	setResult(session)
+	i18n.Printf("\nMob session started!\n")
+	i18n.Printf("Driver: %s\n", driverName)
+	i18n.Printf("Branch: %s\n", currentBranch)
+	if session.TimerDeadline != "" {
+		i18n.Printf("Timer: %d minutes (run 'mob-claude timer' to get reminders)\n", cfg.RotationMinutes)
+		startTimerRoom(ctx, cfg.RotationMinutes, driverName)
+	}
+	if presetName != "" {
+		i18n.Printf("Preset: %s\n", presetName)
+	}
+	if block != nil {
+		i18n.Printf("Scheduled block: %s, until %s\n", block.Name(), block.End.Format("15:04"))
+	}
+	if len(session.Roster) > 0 {
+		i18n.Printf("Roster: %s\n", strings.Join(session.Roster, ", "))
 	}
 
-	fmt.Printf("\nMob session started!\n")
-	fmt.Printf("Driver: %s\n", driverName)
-	fmt.Printf("Branch: %s\n", currentBranch)
+	// Put where the previous rotation left off front and center
+	printHandoffPanel(ctx, cfg, planMgr, baseBranch, apiHealthy)
+	printDriverTasks(planMgr, baseBranch, driverName)
 
 	return nil
 }
 
 func runNext(cmd *cobra.Command, args []string) error {
-	mobWrapper := mob.NewWrapper()
+	// Let Ctrl-C abort summary generation and uploads without killing the process
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	mobWrapper, err := newMobWrapper(cfg)
+	if err != nil {
+		return err
+	}
+	mobWrapper.SetDiffBase(diffBaseFor(cfg))
 
 	if err := mobWrapper.CheckMobInstalled(); err != nil {
 		return err
@@ -254,106 +513,212 @@ func runNext(cmd *cobra.Command, args []string) error {
 	if session == nil {
 		return fmt.Errorf("no active mob session. Run 'mob-claude start' first")
 	}
-
-	// Load config
-	cfg, err := config.Load()
-	if err != nil {
-		cfg = config.DefaultConfig()
-	}
+	applySessionPreset(cfg, session)
 
 	// Initialize managers
-	planMgr, err := plans.NewManager()
+	planMgr, err := newPlanManager()
 	if err != nil {
 		return fmt.Errorf("failed to initialize plan manager: %w", err)
 	}
 
-	// Generate summary unless skipped
-	var summaryObj *plans.Summary
-	if !skipSummary && !cfg.SkipSummary {
-		fmt.Println("Generating rotation summary...")
+	// If the dashboard was down at start, check once whether it's back
+	// rather than failing every request
+	online := cfg.TeamName != "" && cfg.APIURL != ""
+	if online && session.Offline {
+		if err := newAPIClient(cfg).Ping(ctx); err != nil {
+			online = false
+		} else {
+			session.Offline = false
+			i18n.Println("Dashboard is reachable again")
+		}
+	}
+	if online {
+		// Register the workstream now if the dashboard was down at start,
+		// then send anything queued while it was
+		_ = retryRegistration(ctx, cfg, session)
+		flushOutbox(ctx, cfg)
+	}
+
+	result := &rotationResult{Branch: session.Branch, Driver: session.DriverName}
+	setResult(result)
+
+	// Fold journal notes into the driver note
+	driverNote := driverNoteFor(session)
+	navNotes := navigatorNotesFor(session)
 
+	// mob next commits whatever is in the working tree, so make sure the
+	// driver means to hand all of it over
+	uncommitted := checkUncommitted(mobWrapper)
+
+	// Pick up a summary left behind by a handoff that died part way,
+	// otherwise generate one unless skipped
+	summaryObj := recoverSummary(planMgr, session)
+	recovered := summaryObj != nil
+	if recovered {
+		i18n.Printf("Recovered summary from an interrupted handoff: %s\n", summaryObj.TLDR)
+	} else if !skipSummary && !cfg.SkipSummary && !session.ClaudeUnavailable {
+		i18n.Println("Generating rotation summary...")
+
+		diffBase, diffHead, rangeErr := mobWrapper.GetDiffRange()
 		diff, err := mobWrapper.GetDiffFromBase()
 		if err != nil {
 			diff = ""
-			fmt.Printf("Warning: could not get diff: %v\n", err)
+			i18n.Printf("Warning: could not get diff: %v\n", err)
 		}
 
-		gen := summary.NewGenerator(cfg.Model, cfg.MaxTurns)
-		summaryObj, err = gen.Generate(diff, message, session.Branch)
+		gen := newGenerator(cfg)
+		if brief, _ := planMgr.LoadHandoff(session.Branch); brief != "" {
+			gen.SetHandoff(brief)
+		}
+		setPreviousSummaries(gen, cfg, planMgr, session.Branch)
+		gen.SetActivity(rotationActivity(session, mobWrapper))
+		gen.SetNavigatorNotes(navNotes)
+		summaryObj, err = gen.Generate(diff, driverNote, session.Branch)
 		if err != nil {
-			fmt.Printf("Warning: summary generation failed: %v\n", err)
+			i18n.Printf("Warning: summary generation failed: %v\n", err)
 		} else {
 			summaryObj.DriverName = session.DriverName
-			fmt.Printf("Summary: %s\n", summaryObj.TLDR)
+			if rangeErr == nil {
+				summaryObj.DiffBase, summaryObj.DiffHead = diffBase, diffHead
+			}
+			i18n.Printf("Summary: %s\n", summaryObj.TLDR)
 		}
-	} else if message != "" {
+	} else if driverNote != "" || len(navNotes) > 0 {
 		// Create minimal summary with just the message
+		tldr := message
+		if tldr == "" && len(session.Notes) > 0 {
+			tldr = session.Notes[len(session.Notes)-1].Text
+		} else if tldr == "" {
+			tldr = session.NavNotes[len(session.NavNotes)-1].Text
+		}
 		summaryObj = &plans.Summary{
-			Timestamp:  time.Now(),
+			Timestamp:  clk.Now(),
 			DriverName: session.DriverName,
-			DriverNote: message,
-			TLDR:       message,
+			DriverNote: driverNote,
+			TLDR:       tldr,
 			Branch:     session.Branch,
+
+			NavigatorNotes: navNotes,
+		}
+	}
+	if summaryObj != nil && uncommitted != nil {
+		summaryObj.Uncommitted = uncommitted
+	}
+	if summaryObj != nil && len(rotationTags) > 0 {
+		summaryObj.Tags = plans.NormalizeTags(rotationTags)
+	}
+	if summaryObj != nil && !recovered {
+		summaryObj.DiffStats = branchDiffStats(mobWrapper)
+	}
+	result.Summary = summaryObj
+	if summaryObj != nil && !recovered {
+		journalSummary(planMgr, session, summaryObj)
+	}
+
+	// Point the next driver at the next task they can pick up
+	if planText, _ := planMgr.LoadPlan(session.Branch); planText != "" {
+		if next := plans.NextUnblockedTask(plans.ParseTasks(planText)); next != nil {
+			i18n.Printf("Next unblocked task: %d. %s\n", next.Number, next.Text)
 		}
 	}
 
 	// Save summary locally
 	if summaryObj != nil {
 		if err := planMgr.SaveSummary(summaryObj); err != nil {
-			fmt.Printf("Warning: could not save summary: %v\n", err)
+			i18n.Printf("Warning: could not save summary: %v\n", err)
+		}
+
+		// Leave a brief for the next driver; mob next commits it with the WIP
+		planText, _ := planMgr.LoadPlan(session.Branch)
+		files, _ := mobWrapper.GetChangedFilesFromBase()
+		if err := planMgr.SaveHandoff(plans.BuildHandoff(summaryObj, planText, files, i18n.LanguageCode(cfg.SummaryLanguage))); err != nil {
+			i18n.Printf("Warning: could not write handoff brief: %v\n", err)
 		}
 	}
 
-	// Upload to API
-	if cfg.TeamName != "" && cfg.APIURL != "" && summaryObj != nil {
-		client := api.NewClient(cfg.APIURL, cfg.TeamName)
+	// Upload to API, or queue it while the dashboard is down
+	if !online && session.Offline && summaryObj != nil {
+		planText, _ := planMgr.LoadPlan(session.Branch)
+		queueUploads(session.Branch, newRotationRequest(session, summaryObj, planText, driverNote), planText)
+		result.Upload = uploadQueued
+	} else if online && summaryObj != nil {
+		client := newAPIClient(cfg)
 
 		// Get current plan for snapshot
 		planText, _ := planMgr.LoadPlan(session.Branch)
 
-		startedAt, _ := time.Parse(time.RFC3339, session.StartedAt)
-
-		summaryJSON, _ := json.Marshal(map[string]interface{}{
-			"changes":   summaryObj.Changes,
-			"nextSteps": summaryObj.NextSteps,
-		})
-
-		rotation := &api.CreateRotationRequest{
-			DriverName:   session.DriverName,
-			DriverNote:   message,
-			SummaryTLDR:  summaryObj.TLDR,
-			SummaryJSON:  summaryJSON,
-			PlanSnapshot: planText,
-			StartedAt:    startedAt,
-		}
-
-		_, err := client.CreateRotation(session.Branch, rotation)
+		rotation := newRotationRequest(session, summaryObj, planText, driverNote)
+		_, err := client.CreateRotation(ctx, session.Branch, rotation)
 		if err != nil {
-			fmt.Printf("Warning: could not upload rotation: %v\n", err)
+			i18n.Printf("Warning: could not upload rotation: %v\n", err)
+			queueUploads(session.Branch, rotation, "")
+			result.Upload = uploadQueued
 		} else {
-			fmt.Println("Rotation recorded in dashboard")
+			i18n.Println("Rotation recorded in dashboard")
+			result.Upload = uploadRecorded
 		}
 
 		// Sync plan to API
 		if planText != "" {
-			if err := client.UpdatePlan(session.Branch, planText); err != nil {
-				fmt.Printf("Warning: could not sync plan: %v\n", err)
+			if err := uploadPlan(ctx, client, planMgr, session.Branch, planText); err != nil {
+				i18n.Printf("Warning: could not sync plan: %v\n", err)
+				queueFailedPlan(session.Branch, planText, err)
+			} else {
+				result.PlanSynced = true
 			}
 		}
 	}
 
+	// Bail out before handing off if the user pressed Ctrl-C. The summary
+	// is regenerated next time, since work may continue in the meantime.
+	if ctx.Err() != nil {
+		_ = planMgr.ClearJournal()
+		return fmt.Errorf("interrupted, handoff cancelled")
+	}
+	stop()
+
+	if err := planMgr.ClearJournal(); err != nil {
+		i18n.Printf("Warning: could not clear summary journal: %v\n", err)
+	}
+	clearActivity()
+	emitNotification(cmd.Context(), cfg, events.Handoff,
+		i18n.Sprintf("%s handed off %s", session.DriverName, session.Branch),
+		summaryTLDR(summaryObj), session.Branch, session.DriverName, summaryObj)
+
 	// Clear session before mob next
 	if err := config.ClearCurrentSession(); err != nil {
-		fmt.Printf("Warning: could not clear session: %v\n", err)
+		i18n.Printf("Warning: could not clear session: %v\n", err)
 	}
 
-	// Run mob next
-	fmt.Println("\nHanding off to next driver...")
-	return mobWrapper.Next(args...)
+	if uncommitted != nil && !uncommitted.Included {
+		stashUncommitted(mobWrapper, session.Branch, uncommitted.Files)
+	}
+	if session.TimerDeadline != "" {
+		stopTimerRoom(cmd.Context(), session.DriverName)
+	}
+
+	// Run mob next, naming the WIP commit after the rotation
+	if summaryObj != nil && !cfg.PlainWipCommits {
+		mobWrapper.SetWipCommitSummary(summaryObj.TLDR)
+	}
+	i18n.Println("\nHanding off to next driver...")
+	err = mobWrapper.Next(args...)
+	explainConflicts(cfg, mobWrapper)
+	return err
 }
 
 func runDone(cmd *cobra.Command, args []string) error {
-	mobWrapper := mob.NewWrapper()
+	ctx := cmd.Context()
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	mobWrapper, err := newMobWrapper(cfg)
+	if err != nil {
+		return err
+	}
+	mobWrapper.SetDiffBase(diffBaseFor(cfg))
 
 	if err := mobWrapper.CheckMobInstalled(); err != nil {
 		return err
@@ -364,68 +729,122 @@ func runDone(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load session: %w", err)
 	}
+	applySessionPreset(cfg, session)
 
-	// Load config
-	cfg, err := config.Load()
-	if err != nil {
-		cfg = config.DefaultConfig()
+	// Settle the plan's open tasks before anything is uploaded or cleared
+	var remaining *plans.RemainingTasks
+	if session != nil {
+		if remaining, err = guardOpenTasks(ctx, cfg, session); err != nil {
+			return err
+		}
 	}
 
+	result := &rotationResult{}
+	setResult(result)
+
 	// Generate final summary if we have a session
+	var finalSummary *plans.Summary
 	if session != nil && !skipSummary && !cfg.SkipSummary {
-		fmt.Println("Generating final summary...")
+		i18n.Println("Generating final summary...")
 
-		planMgr, err := plans.NewManager()
+		planMgr, err := newPlanManager()
 		if err == nil {
-			diff, _ := mobWrapper.GetDiffFromBase()
-			gen := summary.NewGenerator(cfg.Model, cfg.MaxTurns)
-			summaryObj, err := gen.Generate(diff, message, session.Branch)
+			driverNote := driverNoteFor(session)
+			summaryObj := recoverSummary(planMgr, session)
+			if summaryObj == nil {
+				diffBase, diffHead, rangeErr := mobWrapper.GetDiffRange()
+				diff, _ := mobWrapper.GetDiffFromBase()
+				gen := newGenerator(cfg)
+				setPreviousSummaries(gen, cfg, planMgr, session.Branch)
+				gen.SetActivity(rotationActivity(session, mobWrapper))
+				gen.SetNavigatorNotes(navigatorNotesFor(session))
+				if summaryObj, err = gen.Generate(diff, driverNote, session.Branch); err == nil {
+					summaryObj.DriverName = session.DriverName
+					summaryObj.DiffStats = branchDiffStats(mobWrapper)
+					if rangeErr == nil {
+						summaryObj.DiffBase, summaryObj.DiffHead = diffBase, diffHead
+					}
+					journalSummary(planMgr, session, summaryObj)
+				}
+			}
 			if err == nil {
-				summaryObj.DriverName = session.DriverName
+				summaryObj.RemainingTasks = remaining
+				if len(rotationTags) > 0 {
+					summaryObj.Tags = plans.NormalizeTags(rotationTags)
+				}
 				_ = planMgr.SaveSummary(summaryObj)
-				fmt.Printf("Final summary: %s\n", summaryObj.TLDR)
+				i18n.Printf("Final summary: %s\n", summaryObj.TLDR)
+				finalSummary = summaryObj
 
-				// Upload to API
+				// Upload to API, queueing it if the dashboard is down
 				if cfg.TeamName != "" && cfg.APIURL != "" {
-					client := api.NewClient(cfg.APIURL, cfg.TeamName)
+					client := newAPIClient(cfg)
 					planText, _ := planMgr.LoadPlan(session.Branch)
-					startedAt, _ := time.Parse(time.RFC3339, session.StartedAt)
-
-					summaryJSON, _ := json.Marshal(map[string]interface{}{
-						"changes":   summaryObj.Changes,
-						"nextSteps": summaryObj.NextSteps,
-					})
-
-					rotation := &api.CreateRotationRequest{
-						DriverName:   session.DriverName,
-						DriverNote:   message,
-						SummaryTLDR:  summaryObj.TLDR,
-						SummaryJSON:  summaryJSON,
-						PlanSnapshot: planText,
-						StartedAt:    startedAt,
+					rotation := newRotationRequest(session, summaryObj, planText, driverNote)
+					if session.Offline {
+						queueUploads(session.Branch, rotation, "")
+						result.Upload = uploadQueued
+					} else if _, err := client.CreateRotation(ctx, session.Branch, rotation); err != nil {
+						i18n.Printf("Warning: could not upload rotation: %v\n", err)
+						queueUploads(session.Branch, rotation, "")
+						result.Upload = uploadQueued
+					} else {
+						result.Upload = uploadRecorded
 					}
-					_, _ = client.CreateRotation(session.Branch, rotation)
 				}
+				_ = planMgr.ClearJournal()
 			}
 		}
 	}
 
+	// Draft the squash commit message from the session's rotations
+	branch, driverName := "", ""
+	if session != nil {
+		branch, driverName = session.Branch, session.DriverName
+	} else if base, err := mobWrapper.GetBaseBranch(); err == nil {
+		branch = base
+	}
+	commitMessage := squashCommitMessage(cfg, branch)
+	result.Branch, result.Driver, result.Summary = branch, driverName, finalSummary
+
+	if doneArchive || doneUploadArchive {
+		archiveBranch(ctx, cfg, mobWrapper, branch)
+	}
+
+	if session != nil && session.TimerDeadline != "" {
+		stopTimerRoom(ctx, session.DriverName)
+	}
+
 	// Clear session
 	_ = config.ClearCurrentSession()
+	clearActivity()
 
 	// Run mob done
-	fmt.Println("\nCompleting mob session...")
-	return mobWrapper.Done(args...)
+	i18n.Println("\nCompleting mob session...")
+	if err := mobWrapper.Done(args...); err != nil {
+		return err
+	}
+	commitSquash(mobWrapper, commitMessage)
+	autoPrune(cfg, branch)
+	emitNotification(ctx, cfg, events.Done,
+		i18n.Sprintf("The mob session on %s is done", branch),
+		summaryTLDR(finalSummary), branch, driverName, finalSummary)
+	return nil
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
 	mobWrapper := mob.NewWrapper()
+	if cfg, err := config.Load(); err == nil {
+		if configured, err := newMobWrapper(cfg); err == nil {
+			mobWrapper = configured
+		}
+	}
 
 	// Show mob status
-	fmt.Println("=== Mob Status ===")
+	i18n.Println("=== Mob Status ===")
 	status, err := mobWrapper.Status()
 	if err != nil {
-		fmt.Printf("mob status: %v\n", err)
+		i18n.Printf("mob status: %v\n", err)
 	} else {
 		fmt.Print(status)
 	}
@@ -433,68 +852,200 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	// Show current session
 	session, _ := config.LoadCurrentSession()
 	if session != nil {
-		fmt.Println("\n=== Current Session ===")
-		fmt.Printf("Branch: %s\n", session.Branch)
-		fmt.Printf("Driver: %s\n", session.DriverName)
-		fmt.Printf("Started: %s\n", session.StartedAt)
+		if cfg, err := config.Load(); err == nil && !session.Offline {
+			_ = retryRegistration(cmd.Context(), cfg, session)
+		}
+
+		i18n.Println("\n=== Current Session ===")
+		i18n.Printf("Branch: %s\n", session.Branch)
+		i18n.Printf("Driver: %s\n", session.DriverName)
+		i18n.Printf("Started: %s\n", session.StartedAt)
+		if startedAt, err := time.Parse(time.RFC3339, session.StartedAt); err == nil {
+			i18n.Printf("Elapsed: %s\n", formatDuration(clk.Now().Sub(startedAt)))
+		}
+		if len(session.Roster) > 0 {
+			i18n.Printf("Roster: %s\n", strings.Join(session.Roster, ", "))
+		}
+		if session.PendingRegistration {
+			i18n.Println("Dashboard: not registered yet (dashboard unreachable)")
+		}
+		if session.Offline {
+			i18n.Println("Mode: offline (uploads are queued)")
+		}
+		if session.ClaudeUnavailable {
+			i18n.Println("Mode: Claude unavailable (summaries come from notes)")
+		}
+		if deadline, err := time.Parse(time.RFC3339, session.TimerDeadline); err == nil {
+			i18n.Printf("Timer: %s\n", timer.Message(deadline.Sub(clk.Now())))
+		}
+		if url := mob.LoadSettings().TimerRoomURL(); url != "" {
+			i18n.Printf("Timer room: %s\n", url)
+		}
+		if f := detectForgottenNext(mobWrapper, session); f != nil {
+			warnForgottenNext(f, session)
+			if cfg, err := config.Load(); err == nil {
+				offerForgottenNextBackfill(cmd.Context(), cfg, mobWrapper, f, session)
+			}
+		}
 	}
 
 	// Show plan
-	planMgr, err := plans.NewManager()
+	branch := ""
+	if session != nil {
+		branch = session.Branch
+	} else {
+		branch, _ = mobWrapper.GetBaseBranch()
+	}
+	planMgr, err := newPlanManager()
 	if err == nil {
-		branch := ""
-		if session != nil {
-			branch = session.Branch
-		} else {
-			branch, _ = mobWrapper.GetBaseBranch()
-		}
-
 		if branch != "" {
 			plan, err := planMgr.LoadPlan(branch)
 			if err == nil && plan != "" {
-				fmt.Println("\n=== Plan ===")
-				// Show first 20 lines
-				lines := splitLines(plan)
-				maxLines := 20
-				if len(lines) > maxLines {
-					for i := 0; i < maxLines; i++ {
-						fmt.Println(lines[i])
-					}
-					fmt.Printf("... (%d more lines)\n", len(lines)-maxLines)
-				} else {
-					fmt.Print(plan)
+				i18n.Println("\n=== Plan ===")
+				// Show the sections that matter, per statusPreview
+				var sections []string
+				maxLines := 0
+				if cfg, err := config.Load(); err == nil && cfg.StatusPreview != nil {
+					sections, maxLines = cfg.StatusPreview.Sections, cfg.StatusPreview.MaxLines
+				}
+				lines, hidden := plans.Preview(plan, sections, maxLines)
+				for _, line := range lines {
+					fmt.Println(line)
+				}
+				if hidden > 0 {
+					i18n.Printf("... (%d more lines)\n", hidden)
+				}
+				if next := plans.NextUnblockedTask(plans.ParseTasks(plan)); next != nil {
+					i18n.Printf("\nNext unblocked task: %d. %s\n", next.Number, next.Text)
 				}
 			}
+			printFreshness(cmd.Context(), branch)
 		}
 	}
 
-	// Show latest summary
-	if planMgr != nil {
-		latest, _ := planMgr.GetLatestSummary()
+	// Show the branch's latest summary
+	if planMgr != nil && branch != "" {
+		latest, _ := planMgr.GetLatestSummaryForBranch(branch)
 		if latest != "" {
-			fmt.Println("\n=== Latest Summary ===")
+			i18n.Println("\n=== Latest Summary ===")
 			fmt.Println(latest)
 		}
 	}
 
+	if jsonOutput() {
+		setResult(newStatusResult(branch, status))
+	}
 	return nil
 }
 
+// printFreshness shows how long ago the plan and dashboard data were synced,
+// so cached information isn't mistaken for live data
+func printFreshness(ctx context.Context, branch string) {
+	cfg, err := config.Load()
+	if err != nil || cfg.TeamName == "" || cfg.APIURL == "" {
+		return
+	}
+	freshness, _ := config.LoadFreshness()
+
+	i18n.Println("\n=== Freshness ===")
+	i18n.Printf("Plan synced: %s\n", formatAge(freshness.PlanSynced[branch]))
+	i18n.Printf("Dashboard contact: %s\n", formatAge(freshness.DashboardContact))
+	if !freshness.Heartbeat.IsZero() {
+		i18n.Printf("Heartbeat: %s\n", formatAge(freshness.Heartbeat))
+	}
+	printLastSeen(ctx, cfg, branch)
+	if pending := pendingUploads(); pending > 0 {
+		i18n.Printf("Queued uploads: %d\n", pending)
+	}
+}
+
 func runConfigShow(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
-
-	fmt.Println("Current configuration:")
-	fmt.Printf("  apiUrl:      %s\n", cfg.APIURL)
-	fmt.Printf("  teamName:    %s\n", cfg.TeamName)
-	fmt.Printf("  model:       %s\n", cfg.Model)
-	fmt.Printf("  maxTurns:    %d\n", cfg.MaxTurns)
-	fmt.Printf("  skipSummary: %v\n", cfg.SkipSummary)
+	if configShowJSON {
+		return printConfigJSON(cfg)
+	}
+	setResult(redactedConfig(cfg))
+
+	i18n.Println("Current configuration:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "  apiUrl:\t%s\n", cfg.APIURL)
+	fmt.Fprintf(w, "  teamName:\t%s\n", cfg.TeamName)
+	fmt.Fprintf(w, "  model:\t%s\n", cfg.Model)
+	fmt.Fprintf(w, "  maxTurns:\t%d\n", cfg.MaxTurns)
+	fmt.Fprintf(w, "  skipSummary:\t%v\n", cfg.SkipSummary)
+	fmt.Fprintf(w, "  rotationMinutes:\t%d\n", cfg.RotationMinutes)
+	fmt.Fprintf(w, "  autoNext:\t%v\n", cfg.AutoNext)
+	fmt.Fprintf(w, "  plainWipCommits:\t%v\n", cfg.PlainWipCommits)
+	fmt.Fprintf(w, "  timerHighContrast:\t%v\n", cfg.TimerHighContrast)
+	fmt.Fprintf(w, "  timerLargeText:\t%v\n", cfg.TimerLargeText)
+	fmt.Fprintf(w, "  timerAlert:\t%s\n", cfg.TimerAlert)
+	fmt.Fprintf(w, "  language:\t%s\n", cfg.Language)
+	fmt.Fprintf(w, "  summaryLanguage:\t%s\n", cfg.SummaryLanguage)
+	fmt.Fprintf(w, "  baseBranch:\t%s\n", cfg.BaseBranch)
+	fmt.Fprintf(w, "  gitRemote:\t%s\n", cfg.GitRemote)
+	fmt.Fprintf(w, "  mobPath:\t%s\n", cfg.MobPath)
+	fmt.Fprintf(w, "  engine:\t%s\n", cfg.Engine)
+	fmt.Fprintf(w, "  httpTimeout:\t%d\n", cfg.HTTPTimeout)
+	fmt.Fprintf(w, "  httpProxy:\t%s\n", cfg.HTTPProxy)
+	fmt.Fprintf(w, "  caBundle:\t%s\n", cfg.CABundle)
+	fmt.Fprintf(w, "  storage:\t%s\n", cfg.Storage)
+	fmt.Fprintf(w, "  storageEndpoint:\t%s\n", cfg.StorageEndpoint)
+	fmt.Fprintf(w, "  storageRegion:\t%s\n", cfg.StorageRegion)
+	fmt.Fprintf(w, "  untrackedArtifacts:\t%s\n", strings.Join(cfg.UntrackedArtifacts, ","))
+	fmt.Fprintf(w, "  keepSummariesDays:\t%d\n", cfg.KeepSummariesDays)
+	fmt.Fprintf(w, "  keepArchives:\t%d\n", cfg.KeepArchives)
+	if cfg.SummaryRetries != nil {
+		fmt.Fprintf(w, "  summaryRetries:\t%d\n", *cfg.SummaryRetries)
+	} else {
+		fmt.Fprintf(w, "  summaryRetries:\t%d\n", summary.DefaultRetries)
+	}
+	if cfg.SummaryContext != nil {
+		fmt.Fprintf(w, "  summaryContext:\t%d\n", *cfg.SummaryContext)
+	} else {
+		fmt.Fprintf(w, "  summaryContext:\t%d\n", summary.DefaultContextSummaries)
+	}
+	fmt.Fprintf(w, "  summaryIncludePaths:\t%s\n", strings.Join(cfg.SummaryIncludePaths, ","))
+	fmt.Fprintf(w, "  summaryExcludePaths:\t%s\n", strings.Join(cfg.SummaryExcludePaths, ","))
+	fmt.Fprintf(w, "  redactSecrets:\t%v\n", cfg.RedactSecrets)
+	if len(cfg.RedactPatterns) > 0 {
+		fmt.Fprintf(w, "  redactPatterns:\t%s\n", strings.Join(cfg.RedactPatterns, " "))
+	}
+	if cfg.Claude != nil {
+		fmt.Fprintf(w, "  claude.systemPrompt:\t%s\n", cfg.Claude.SystemPrompt)
+		fmt.Fprintf(w, "  claude.allowedTools:\t%s\n", strings.Join(cfg.Claude.AllowedTools, ", "))
+		fmt.Fprintf(w, "  claude.maxOutputTokens:\t%d\n", cfg.Claude.MaxOutputTokens)
+		fmt.Fprintf(w, "  claude.extraArgs:\t%s\n", strings.Join(cfg.Claude.ExtraArgs, " "))
+	}
+	if cfg.StatusPreview != nil {
+		fmt.Fprintf(w, "  statusPreview.sections:\t%s\n", strings.Join(cfg.StatusPreview.Sections, ", "))
+		fmt.Fprintf(w, "  statusPreview.maxLines:\t%d\n", cfg.StatusPreview.MaxLines)
+	}
+	fmt.Fprintf(w, "  driverName:\t%s\n", cfg.DriverName)
+	if name := config.ActiveProfile(cfg); name != "" {
+		fmt.Fprintf(w, "  profile:\t%s\n", name)
+	}
+	fmt.Fprintf(w, "  apiToken:\t%s\n", secrets.Redact(cfg.APIToken))
+	fmt.Fprintf(w, "  slackWebhook:\t%s\n", secrets.Redact(cfg.SlackWebhook))
+	fmt.Fprintf(w, "  signingSecret:\t%s\n", secrets.Redact(cfg.SigningSecret))
+	fmt.Fprintf(w, "  encryptionKey:\t%s\n", secrets.Redact(cfg.EncryptionKey))
+	if len(cfg.Presets) > 0 {
+		names := make([]string, 0, len(cfg.Presets))
+		for name := range cfg.Presets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "  presets:\t%s\n", strings.Join(names, ", "))
+	}
+	if len(cfg.Schedule) > 0 {
+		fmt.Fprintf(w, "  schedule:\t%d block(s)\n", len(cfg.Schedule))
+	}
+	w.Flush()
 
 	dir, _ := config.GetConfigDir()
-	fmt.Printf("\nConfig file: %s/config.json\n", dir)
+	i18n.Printf("\nConfig file: %s\n", filepath.Join(dir, config.ConfigFileName))
 
 	return nil
 }
@@ -503,11 +1054,36 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	value := args[1]
 
-	cfg, err := config.Load()
+	// Secrets go to the keychain, never into config.json
+	if config.IsSecretKey(key) {
+		if key == "encryptionKey" && value != "" {
+			if _, err := age.ParseX25519Identity(value); err != nil {
+				return fmt.Errorf("invalid encryptionKey (generate one with 'mob-claude keygen'): %w", err)
+			}
+		}
+		if err := config.SetSecret(key, value); err != nil {
+			return fmt.Errorf("failed to store %s: %w", key, err)
+		}
+		i18n.Printf("Set %s = %s\n", key, secrets.Redact(value))
+		return nil
+	}
+
+	cfg, err := config.LoadFile()
 	if err != nil {
 		cfg = config.DefaultConfig()
 	}
 
+	if name, field, ok := config.SplitProfileKey(key); ok {
+		if err := setProfileValue(cfg, name, field, value); err != nil {
+			return err
+		}
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		i18n.Printf("Set %s = %s\n", key, value)
+		return nil
+	}
+
 	switch key {
 	case "apiUrl":
 		cfg.APIURL = value
@@ -523,18 +1099,712 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		cfg.MaxTurns = turns
 	case "skipSummary":
 		cfg.SkipSummary = value == "true" || value == "1"
+	case "rotationMinutes":
+		var minutes int
+		if _, err := fmt.Sscanf(value, "%d", &minutes); err != nil || minutes < 0 {
+			return fmt.Errorf("invalid rotationMinutes value: %s", value)
+		}
+		cfg.RotationMinutes = minutes
+	case "autoNext":
+		cfg.AutoNext = value == "true" || value == "1"
+	case "plainWipCommits":
+		cfg.PlainWipCommits = value == "true" || value == "1"
+	case "timerHighContrast":
+		cfg.TimerHighContrast = value == "true" || value == "1"
+	case "timerLargeText":
+		cfg.TimerLargeText = value == "true" || value == "1"
+	case "timerAlert":
+		if value != timer.AlertBell && value != timer.AlertFlash && value != timer.AlertNone {
+			return fmt.Errorf("invalid timerAlert value: %s (use bell, flash, or none)", value)
+		}
+		cfg.TimerAlert = value
+	case "language":
+		cfg.Language = value
+	case "summaryLanguage":
+		cfg.SummaryLanguage = value
+	case "baseBranch":
+		cfg.BaseBranch = value
+	case "gitRemote":
+		cfg.GitRemote = value
+	case "mobPath":
+		if value != "" {
+			// Paths are kept absolute so mob is found from any directory;
+			// bare names are looked up in PATH when mob runs
+			if strings.ContainsAny(value, `/\`) {
+				abs, err := filepath.Abs(value)
+				if err != nil {
+					return fmt.Errorf("invalid mobPath: %w", err)
+				}
+				value = abs
+			}
+			if _, err := platform.FindExecutable(value); err != nil {
+				return fmt.Errorf("invalid mobPath: %w", err)
+			}
+		}
+		cfg.MobPath = value
+	case "engine":
+		if !mob.IsEngine(value) {
+			return fmt.Errorf("invalid engine value: %s (use %s)", value, strings.Join(mob.Engines, " or "))
+		}
+		cfg.Engine = value
+	case "keepSummariesDays":
+		var days int
+		if _, err := fmt.Sscanf(value, "%d", &days); err != nil || days < 0 {
+			return fmt.Errorf("invalid keepSummariesDays value: %s (must be 0 or more)", value)
+		}
+		cfg.KeepSummariesDays = days
+	case "keepArchives":
+		var n int
+		if _, err := fmt.Sscanf(value, "%d", &n); err != nil || n < 0 {
+			return fmt.Errorf("invalid keepArchives value: %s (must be 0 or more)", value)
+		}
+		cfg.KeepArchives = n
+	case "summaryRetries":
+		var retries int
+		if _, err := fmt.Sscanf(value, "%d", &retries); err != nil || retries < 0 || retries > config.MaxSummaryRetries {
+			return fmt.Errorf("invalid summaryRetries value: %s (must be 0-%d)", value, config.MaxSummaryRetries)
+		}
+		cfg.SummaryRetries = &retries
+	case "summaryContext":
+		var n int
+		if _, err := fmt.Sscanf(value, "%d", &n); err != nil || n < 0 || n > config.MaxSummaryContext {
+			return fmt.Errorf("invalid summaryContext value: %s (must be 0-%d)", value, config.MaxSummaryContext)
+		}
+		cfg.SummaryContext = &n
+	case "redactSecrets":
+		cfg.RedactSecrets = value == "true" || value == "1"
+	case "redactPatterns":
+		patterns := strings.Fields(value)
+		if _, err := summary.NewRedactor(patterns); err != nil {
+			return err
+		}
+		cfg.RedactPatterns = patterns
+	case "summaryIncludePaths":
+		cfg.SummaryIncludePaths = splitList(value)
+	case "summaryExcludePaths":
+		cfg.SummaryExcludePaths = splitList(value)
+	case "claude.systemPrompt", "claude.allowedTools", "claude.maxOutputTokens", "claude.extraArgs":
+		if cfg.Claude == nil {
+			cfg.Claude = &config.ClaudeOptions{}
+		}
+		if err := setClaudeOption(cfg.Claude, strings.TrimPrefix(key, "claude."), value); err != nil {
+			return err
+		}
+	case "statusPreview.sections":
+		if cfg.StatusPreview == nil {
+			cfg.StatusPreview = &config.StatusPreview{}
+		}
+		cfg.StatusPreview.Sections = nil
+		for _, section := range strings.Split(value, ",") {
+			if section = strings.TrimSpace(section); section != "" {
+				cfg.StatusPreview.Sections = append(cfg.StatusPreview.Sections, section)
+			}
+		}
+	case "statusPreview.maxLines":
+		var lines int
+		if _, err := fmt.Sscanf(value, "%d", &lines); err != nil || lines < 0 {
+			return fmt.Errorf("invalid statusPreview.maxLines value: %s", value)
+		}
+		if cfg.StatusPreview == nil {
+			cfg.StatusPreview = &config.StatusPreview{}
+		}
+		cfg.StatusPreview.MaxLines = lines
+	case "driverName":
+		cfg.DriverName = value
+	case "profile":
+		if _, ok := cfg.Profiles[value]; value != "" && !ok {
+			return fmt.Errorf("unknown profile: %s", value)
+		}
+		cfg.Profile = value
+	case "httpTimeout":
+		var seconds int
+		if _, err := fmt.Sscanf(value, "%d", &seconds); err != nil || seconds < 0 {
+			return fmt.Errorf("invalid httpTimeout value: %s", value)
+		}
+		cfg.HTTPTimeout = seconds
+	case "httpProxy":
+		if value != "" {
+			if _, err := api.ParseProxyURL(value); err != nil {
+				return err
+			}
+		}
+		cfg.HTTPProxy = value
+	case "caBundle":
+		if value != "" {
+			abs, err := filepath.Abs(value)
+			if err != nil {
+				return fmt.Errorf("invalid caBundle path: %w", err)
+			}
+			if _, err := os.Stat(abs); err != nil {
+				return fmt.Errorf("cannot read CA bundle: %w", err)
+			}
+			value = abs
+		}
+		cfg.CABundle = value
+	case "storage":
+		if value != "" {
+			if u, err := url.Parse(value); err != nil || (u.Scheme != "s3" && u.Scheme != "gs") || u.Host == "" {
+				return fmt.Errorf("invalid storage value: %s (use s3://bucket/prefix or gs://bucket/prefix)", value)
+			}
+		}
+		cfg.Storage = value
+	case "storageEndpoint":
+		if value != "" {
+			if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid storageEndpoint value: %s", value)
+			}
+		}
+		cfg.StorageEndpoint = value
+	case "storageRegion":
+		cfg.StorageRegion = value
+	case "untrackedArtifacts":
+		cfg.UntrackedArtifacts = nil
+		for _, artifact := range strings.Split(value, ",") {
+			if artifact = strings.TrimSpace(artifact); artifact == "" {
+				continue
+			}
+			if !config.IsArtifact(artifact) {
+				return fmt.Errorf("unknown artifact %q (expected %s)", artifact, strings.Join(config.Artifacts, ", "))
+			}
+			cfg.UntrackedArtifacts = append(cfg.UntrackedArtifacts, artifact)
+		}
 	default:
-		return fmt.Errorf("unknown config key: %s\nAvailable keys: apiUrl, teamName, model, maxTurns, skipSummary", key)
+		return fmt.Errorf("unknown config key: %s\nAvailable keys: %s", key, strings.Join(configKeys, ", "))
 	}
 
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
+	if key == "untrackedArtifacts" {
+		if _, err := syncGitignore(cfg); err != nil {
+			i18n.Printf("Warning: could not update .gitignore: %v\n", err)
+		}
+	}
+
+	i18n.Printf("Set %s = %s\n", key, value)
+	return nil
+}
+
+// splitList splits a comma-separated config value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
 
-	fmt.Printf("Set %s = %s\n", key, value)
+// setClaudeOption sets one of the claude CLI options. Tool lists are
+// comma-separated and extra arguments space-separated.
+func setClaudeOption(opts *config.ClaudeOptions, field, value string) error {
+	switch field {
+	case "systemPrompt":
+		opts.SystemPrompt = value
+	case "allowedTools":
+		opts.AllowedTools = nil
+		for _, tool := range strings.Split(value, ",") {
+			if tool = strings.TrimSpace(tool); tool != "" {
+				opts.AllowedTools = append(opts.AllowedTools, tool)
+			}
+		}
+	case "maxOutputTokens":
+		var tokens int
+		if _, err := fmt.Sscanf(value, "%d", &tokens); err != nil || tokens < 0 {
+			return fmt.Errorf("invalid claude.maxOutputTokens value: %s", value)
+		}
+		opts.MaxOutputTokens = tokens
+	case "extraArgs":
+		opts.ExtraArgs = strings.Fields(value)
+	}
 	return nil
 }
 
+// newAPIClient creates a dashboard client from config
+func newAPIClient(cfg *config.Config) *api.Client {
+	client := api.NewClient(cfg.APIURL, cfg.TeamName)
+	client.SetToken(cfg.APIToken)
+	client.SetSigningSecret(cfg.SigningSecret)
+	client.SetContactHook(func() {
+		_ = config.UpdateFreshness(func(f *config.Freshness) { f.DashboardContact = clk.Now() })
+	})
+	client.SetErrorHook(func() {
+		recordMetrics(func(c *metrics.Counters) { c.APIErrors++ })
+	})
+	if dir, err := config.GetConfigDir(); err == nil {
+		setAPICache(client, filepath.Join(dir, api.CacheDir), cfg)
+	}
+	if err := client.SetProxy(cfg.HTTPProxy); err != nil {
+		i18n.Printf("Warning: ignoring httpProxy: %v\n", err)
+	}
+	if err := client.SetCABundle(cfg.CABundle); err != nil {
+		i18n.Printf("Warning: ignoring caBundle: %v\n", err)
+	}
+
+	// --http-timeout wins over the configured timeout for this command
+	timeout := cfg.HTTPTimeout
+	if httpTimeout > 0 {
+		timeout = httpTimeout
+	}
+	if timeout > 0 {
+		client.SetTimeout(time.Duration(timeout) * time.Second)
+	}
+	return client
+}
+
+// initLocale selects the CLI language from config or the environment and
+// loads any project catalogs from .claude/mob/locales
+func initLocale() {
+	locale := i18n.Detect()
+	if cfg, err := config.Load(); err == nil && cfg.Language != "" {
+		locale = cfg.Language
+	}
+	i18n.SetLocale(locale)
+
+	if dir, err := config.GetConfigDir(); err == nil {
+		if err := i18n.LoadCatalogDir(filepath.Join(dir, "locales")); err != nil {
+			fmt.Printf("Warning: could not load translations: %v\n", err)
+		}
+	}
+}
+
+// preRun applies the global --profile flag and anchors the command to the
+// right repository before any command runs, warning if the session's branch
+// has gone out of step with git
+func preRun(cmd *cobra.Command, args []string) error {
+	if profileName != "" {
+		config.SetProfileOverride(profileName)
+	}
+	if err := setOutputFormat(outputFlag); err != nil {
+		return err
+	}
+	if err := resolveNestedRepo(cmd, args); err != nil {
+		return err
+	}
+	warnBranchMismatch(cmd)
+
+	if cfg, err := config.LoadFile(); err == nil {
+		// A mistyped profile fails here rather than each command quietly
+		// running without it; the commands that fix it still run
+		if err := config.CheckProfile(cfg); err != nil && !fixesProfile(cmd) {
+			return fmt.Errorf("%w (see 'mob-claude profile list')", err)
+		}
+
+		// Keep runtime files out of git in projects already using mob-claude
+		_, _ = syncGitignore(cfg)
+	}
+	return nil
+}
+
+// fixesProfile reports whether cmd can repair an unknown profile: the
+// config and profile commands, and help
+func fixesProfile(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "config", "profile", "help", "completion":
+			return true
+		}
+	}
+	return false
+}
+
+// takeStartFlag removes a "--name value" or "--name=value" flag from args,
+// returning its value and the remaining args for mob.sh
+func takeStartFlag(args []string, name string) (string, []string) {
+	flag := "--" + name
+	var value string
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == flag && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], flag+"="):
+			value = strings.TrimPrefix(args[i], flag+"=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return value, rest
+}
+
+// takeStartSwitch removes a boolean --name flag from start's args,
+// reporting whether it was present
+func takeStartSwitch(args []string, name string) (bool, []string) {
+	found := false
+	rest := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--"+name {
+			found = true
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+	return found, rest
+}
+
+// resolvePlanTemplate finds a named plan template, preferring the local
+// templates directory over the dashboard. It returns "" if none is found.
+func resolvePlanTemplate(ctx context.Context, cfg *config.Config, planMgr *plans.Manager, name string, apiHealthy bool) string {
+	if name == "" {
+		return ""
+	}
+
+	template, err := planMgr.LoadTemplate(name)
+	if err != nil {
+		i18n.Printf("Warning: could not load template: %v\n", err)
+	}
+	if template != "" {
+		return template
+	}
+
+	if apiHealthy {
+		templates, err := newAPIClient(cfg).GetPlanTemplates(ctx)
+		if err != nil {
+			i18n.Printf("Warning: could not fetch plan templates: %v\n", err)
+		}
+		for _, t := range templates {
+			if t.Name == name {
+				return t.Content
+			}
+		}
+	}
+
+	i18n.Printf("Warning: plan template %q not found, using the default\n", name)
+	return ""
+}
+
+// retryRegistration registers the session's workstream if registration was
+// deferred at start, persisting the result so later commands don't retry.
+// It returns the dashboard's error when registration is still pending.
+func retryRegistration(ctx context.Context, cfg *config.Config, session *config.CurrentSession) error {
+	if !session.PendingRegistration || cfg.TeamName == "" || cfg.APIURL == "" {
+		return nil
+	}
+
+	client := newAPIClient(cfg)
+	workstream, err := client.CreateWorkstream(ctx, session.RepoURL, session.Branch)
+	if err != nil {
+		return err
+	}
+
+	session.WorkstreamID = workstream.ID
+	session.PendingRegistration = false
+	if err := config.SaveCurrentSession(session); err != nil {
+		i18n.Printf("Warning: could not save session: %v\n", err)
+	}
+	i18n.Printf("Registered with dashboard: %s/team/%s\n", cfg.APIURL, cfg.TeamName)
+	return nil
+}
+
+// applySessionPreset re-applies the preset the session was started with, so
+// commands run during the session use the same settings start did
+func applySessionPreset(cfg *config.Config, session *config.CurrentSession) {
+	if session == nil || session.Preset == "" {
+		return
+	}
+	if preset, ok := cfg.Presets[session.Preset]; ok {
+		preset.Apply(cfg)
+	}
+}
+
+// recordPlanSync notes that branch's plan now matches the dashboard
+func recordPlanSync(branch string) {
+	_ = config.RecordPlanSync(branch, clk.Now())
+}
+
+// newMobWrapper creates a mob wrapper that runs sessions with the
+// configured engine and mob binary, on the configured remote
+func newMobWrapper(cfg *config.Config) (*mob.Wrapper, error) {
+	mobWrapper := mob.NewWrapper()
+	mobWrapper.SetSprintf(i18n.Sprintf)
+	mobWrapper.SetMobPath(cfg.MobPath)
+	mobWrapper.SetRemote(cfg.GitRemote)
+	if err := mobWrapper.SetEngine(cfg.Engine); err != nil {
+		return nil, err
+	}
+	return mobWrapper, nil
+}
+
+// newPlanManager creates a plan manager wired to the shared clock
+func newPlanManager() (*plans.Manager, error) {
+	planMgr, err := plans.NewManager()
+	if err != nil {
+		return nil, err
+	}
+	planMgr.SetClock(clk)
+	cfg, err := config.Load()
+	if err != nil {
+		return planMgr, nil
+	}
+	if err := setEncryptionKey(planMgr, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Storage != "" {
+		setRemoteStorage(planMgr)
+	}
+	return planMgr, nil
+}
+
+// setAPICache caches dashboard responses in dir, encrypted with
+// encryptionKey when one is set. With a key that doesn't parse nothing is
+// cached, rather than writing plans in plaintext.
+func setAPICache(client *api.Client, dir string, cfg *config.Config) {
+	if cfg.EncryptionKey == "" {
+		client.SetCache(dir, nil)
+		return
+	}
+	planMgr := plans.NewManagerAt(".")
+	if err := setEncryptionKey(planMgr, cfg); err != nil {
+		return
+	}
+	client.SetCache(dir, planMgr)
+}
+
+// setEncryptionKey has the plan manager encrypt what it writes with the
+// team's encryptionKey, if one is set
+func setEncryptionKey(planMgr *plans.Manager, cfg *config.Config) error {
+	if cfg.EncryptionKey == "" {
+		return nil
+	}
+	if err := planMgr.SetEncryptionKey(cfg.EncryptionKey); err != nil {
+		return fmt.Errorf("invalid encryptionKey: %w", err)
+	}
+	return nil
+}
+
+// setPreviousSummaries gives the generator the branch's latest summaries,
+// as many as summaryContext allows, so a new summary only reports what
+// changed since
+func setPreviousSummaries(gen *summary.Generator, cfg *config.Config, planMgr *plans.Manager, branch string) {
+	n := summary.DefaultContextSummaries
+	if cfg.SummaryContext != nil {
+		n = *cfg.SummaryContext
+	}
+	if n == 0 {
+		return
+	}
+	summaries, err := planMgr.LoadBranchSummaries(branch)
+	if err != nil {
+		i18n.Printf("Warning: could not load previous summaries: %v\n", err)
+		return
+	}
+	if len(summaries) > n {
+		summaries = summaries[len(summaries)-n:]
+	}
+	gen.SetPreviousSummaries(summaries)
+}
+
+// newGenerator creates a summary generator from config, wired to the shared clock
+func newGenerator(cfg *config.Config) *summary.Generator {
+	gen := summary.NewGenerator(cfg.Model, cfg.MaxTurns)
+	gen.SetClock(clk)
+	if cfg.SummaryRetries != nil {
+		gen.SetRetries(*cfg.SummaryRetries)
+	}
+	if cfg.SummaryLanguage != "" {
+		gen.SetLanguage(i18n.LanguageName(cfg.SummaryLanguage))
+	}
+	if c := cfg.Claude; c != nil {
+		gen.SetCLIOptions(summary.CLIOptions{
+			SystemPrompt:    c.SystemPrompt,
+			AllowedTools:    c.AllowedTools,
+			MaxOutputTokens: c.MaxOutputTokens,
+			ExtraArgs:       c.ExtraArgs,
+		})
+	}
+	if len(cfg.SummaryIncludePaths) > 0 || len(cfg.SummaryExcludePaths) > 0 {
+		gen.SetPathFilter(summary.NewPathFilter(cfg.SummaryIncludePaths, cfg.SummaryExcludePaths))
+	}
+	if cfg.RedactSecrets {
+		redactor, err := summary.NewRedactor(cfg.RedactPatterns)
+		if err != nil {
+			i18n.Printf("Warning: ignoring redactPatterns: %v\n", err)
+			redactor, _ = summary.NewRedactor(nil)
+		}
+		gen.SetRedactor(redactor, func(redactions []summary.Redaction) {
+			i18n.Printf("Redacted from the diff before sending it to Claude: %s\n", summary.FormatRedactions(redactions))
+		})
+	}
+	gen.SetGenerateHook(func(elapsed time.Duration, fallback bool) {
+		recordMetrics(func(c *metrics.Counters) {
+			c.SummaryCount++
+			c.SummarySeconds += elapsed.Seconds()
+			if fallback {
+				c.SummaryFallbacks++
+			}
+		})
+	})
+	return gen
+}
+
+// addBaseFlag adds --base to a command that diffs against the base branch
+func addBaseFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&diffBase, "base", "", "Branch to diff against (default: baseBranch config, then origin's HEAD, main, master)")
+}
+
+// diffBaseFor returns the branch diffs are taken from: the --base flag,
+// then the baseBranch setting. "" leaves it to detection.
+func diffBaseFor(cfg *config.Config) string {
+	if diffBase != "" {
+		return diffBase
+	}
+	return cfg.BaseBranch
+}
+
+// driverNoteFor combines the session's journal notes with the -m message
+func driverNoteFor(session *config.CurrentSession) string {
+	var lines []string
+	for _, note := range session.Notes {
+		lines = append(lines, fmt.Sprintf("- [%s] %s", noteTime(note), note.Text))
+	}
+	if message != "" {
+		lines = append(lines, message)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recoverSummary returns the journaled summary of this session's rotation,
+// if an earlier next or done died before finishing with it. Journals from
+// other sessions are discarded.
+func recoverSummary(planMgr *plans.Manager, session *config.CurrentSession) *plans.Summary {
+	summaryObj, err := planMgr.RecoverSummary()
+	if err != nil || summaryObj == nil {
+		return nil
+	}
+	startedAt, _ := time.Parse(time.RFC3339, session.StartedAt)
+	if summaryObj.Branch != session.Branch || !summaryObj.StartedAt.Equal(startedAt) {
+		_ = planMgr.ClearJournal()
+		return nil
+	}
+	return summaryObj
+}
+
+// journalSummary stamps a new summary with the rotation times and its
+// upload key, and journals it so it survives a crash before it's saved and
+// uploaded
+func journalSummary(planMgr *plans.Manager, session *config.CurrentSession, summaryObj *plans.Summary) {
+	endRotation(session, summaryObj)
+	summaryObj.UploadKey = idGen.NewID()
+	if err := planMgr.JournalSummary(summaryObj); err != nil {
+		i18n.Printf("Warning: could not journal summary: %v\n", err)
+	}
+}
+
+// endRotation stamps the summary with the session start and the current
+// time, and links the driver's Claude Code conversation
+func endRotation(session *config.CurrentSession, summaryObj *plans.Summary) {
+	startedAt, _ := time.Parse(time.RFC3339, session.StartedAt)
+	summaryObj.SetRotationTimes(startedAt, clk.Now())
+	summaryObj.ClaudeSessionID = claudeSessionID(session)
+}
+
+// newRotationRequest builds the dashboard payload for a finished rotation
+func newRotationRequest(session *config.CurrentSession, summaryObj *plans.Summary, planText, driverNote string) *api.CreateRotationRequest {
+	startedAt, _ := time.Parse(time.RFC3339, session.StartedAt)
+
+	var diffStats *api.DiffStats
+	if d := summaryObj.DiffStats; d != nil {
+		diffStats = &api.DiffStats{FilesChanged: d.FilesChanged, Insertions: d.Insertions, Deletions: d.Deletions, TestFiles: d.TestFiles}
+	}
+
+	// A journaled summary keeps its key, so the dashboard drops a repeat
+	// upload after a crash
+	key := summaryObj.UploadKey
+	if key == "" {
+		key = idGen.NewID()
+		summaryObj.UploadKey = key
+	}
+
+	return &api.CreateRotationRequest{
+		DriverName:      session.DriverName,
+		DriverNote:      driverNote,
+		SummaryTLDR:     summaryObj.TLDR,
+		SummaryJSON:     rotationSummaryJSON(summaryObj),
+		PlanSnapshot:    planText,
+		StartedAt:       startedAt,
+		EndedAt:         summaryObj.EndedAt,
+		DurationSeconds: summaryObj.Duration,
+		ClaudeSessionID: summaryObj.ClaudeSessionID,
+		CustomFields:    plans.PlanFields(planText),
+		DiffStats:       diffStats,
+		Tags:            summaryObj.Tags,
+		IdempotencyKey:  key,
+	}
+}
+
+// rotationSummaryJSON is the summary as the dashboard stores it with a
+// rotation
+func rotationSummaryJSON(summaryObj *plans.Summary) json.RawMessage {
+	summaryJSON, _ := json.Marshal(map[string]interface{}{
+		"schemaVersion":   plans.SummarySchemaVersion,
+		"changes":         summaryObj.Changes,
+		"nextSteps":       summaryObj.NextSteps,
+		"rotation":        summaryObj.Rotation,
+		"tags":            summaryObj.Tags,
+		"startedAt":       summaryObj.StartedAt,
+		"endedAt":         summaryObj.EndedAt,
+		"durationSeconds": summaryObj.Duration,
+		"uncommitted":     summaryObj.Uncommitted,
+		"navigatorNotes":  summaryObj.NavigatorNotes,
+		"remainingTasks":  summaryObj.RemainingTasks,
+		"diffStats":       summaryObj.DiffStats,
+	})
+	return summaryJSON
+}
+
+// branchDiffStats counts the branch's changes against its base, or returns
+// nil if git can't tell
+func branchDiffStats(mobWrapper *mob.Wrapper) *plans.DiffStats {
+	numstat, err := mobWrapper.GetNumStatFromBase()
+	if err != nil {
+		return nil
+	}
+	return plans.ParseNumStat(numstat)
+}
+
+// recordPlanMetadata fills in the plan front matter that start knows: the
+// branch, the ticket its name refers to, and the dashboard workstream. Values
+// already set are kept, so they can be corrected by hand.
+func recordPlanMetadata(planMgr *plans.Manager, branch, workstreamID string) {
+	err := planMgr.UpdatePlanFrontMatter(branch, func(fm *plans.FrontMatter) {
+		if fm.Branch == "" {
+			fm.Branch = branch
+		}
+		if fm.Ticket == "" {
+			fm.Ticket = plans.TicketFromBranch(branch)
+		}
+		if workstreamID != "" {
+			fm.WorkstreamID = workstreamID
+		}
+	})
+	if err != nil {
+		i18n.Printf("Warning: could not update plan metadata: %v\n", err)
+	}
+}
+
+// syncPlanFields copies the workstream's custom fields from the dashboard
+// into the plan's front matter. Nil fields (a dashboard without custom
+// field support) leave the plan alone.
+func syncPlanFields(planMgr *plans.Manager, branch string, fields map[string]string) {
+	if fields == nil {
+		return
+	}
+	planText, err := planMgr.LoadPlan(branch)
+	if err != nil || planText == "" || maps.Equal(plans.PlanFields(planText), fields) {
+		return
+	}
+
+	updated, err := plans.SetPlanFields(planText, fields)
+	if err == nil {
+		err = planMgr.SavePlan(branch, updated)
+	}
+	if err != nil {
+		i18n.Printf("Warning: could not update plan fields: %v\n", err)
+	}
+}
+
 func getDriverName() string {
 	// Try git config first
 	if name := getGitConfigValue("user.name"); name != "" {
@@ -557,7 +1827,3 @@ func getGitConfigValue(key string) string {
 	}
 	return strings.TrimSpace(string(output))
 }
-
-func splitLines(s string) []string {
-	return strings.Split(s, "\n")
-}
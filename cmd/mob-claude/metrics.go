@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/metrics"
+	"github.com/spf13/cobra"
+)
+
+var (
+	metricsTextfile string
+	metricsPort     int
+	metricsBind     string
+)
+
+func newMetricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Export session metrics for Prometheus",
+		Long: `Prints the mob's metrics in the Prometheus text format: rotations and their
+durations per branch (from the local summaries), summary generation time and
+fallbacks, dashboard errors, and the running session's timer.
+
+Nothing is sent anywhere. With --textfile the metrics are written to a file
+for node_exporter's textfile collector (run it from cron or a timer);
+'metrics serve' exposes them for Prometheus to scrape instead.`,
+		Args: cobra.NoArgs,
+		RunE: runMetrics,
+	}
+	cmd.Flags().StringVar(&metricsTextfile, "textfile", "", "Write the metrics to this file instead of printing them")
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve metrics over HTTP for Prometheus",
+		Long: `Serves the metrics at /metrics for Prometheus to scrape. It listens on
+127.0.0.1 unless --bind says otherwise; bind to 0.0.0.0 to let a
+Prometheus on another machine scrape it.`,
+		Args: cobra.NoArgs,
+		RunE: runMetricsServe,
+	}
+	serveCmd.Flags().IntVar(&metricsPort, "port", metrics.DefaultPort, "Port to listen on")
+	serveCmd.Flags().StringVar(&metricsBind, "bind", "127.0.0.1", "Address to listen on")
+
+	cmd.AddCommand(serveCmd)
+	return cmd
+}
+
+func runMetrics(cmd *cobra.Command, args []string) error {
+	snapshot, err := metricsSnapshot()
+	if err != nil {
+		return err
+	}
+	if metricsTextfile != "" {
+		if err := metrics.WriteFile(metricsTextfile, snapshot); err != nil {
+			return fmt.Errorf("failed to write metrics: %w", err)
+		}
+		return nil
+	}
+	return metrics.Write(os.Stdout, snapshot)
+}
+
+func runMetricsServe(cmd *cobra.Command, args []string) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(metricsBind, fmt.Sprint(metricsPort)))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", metricsPort, err)
+	}
+	i18n.Printf("Serving metrics on http://%s/metrics\n", listener.Addr())
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	return metrics.Serve(ctx, listener, metricsSnapshot)
+}
+
+// metricsSnapshot gathers the metrics from the local summaries, the stored
+// counters, and the current session
+func metricsSnapshot() (*metrics.Snapshot, error) {
+	snapshot := &metrics.Snapshot{}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	files, err := planMgr.ListSummaries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list summaries: %w", err)
+	}
+	for _, file := range files {
+		s, err := planMgr.LoadSummary(file)
+		if err != nil {
+			continue
+		}
+		snapshot.AddRotation(s.Branch, s.RotationDuration(), s.Timestamp)
+	}
+
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	counters, err := metrics.New(dir).Load()
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Counters = *counters
+
+	session, _ := config.LoadCurrentSession()
+	if session != nil {
+		snapshot.Active, snapshot.Branch, snapshot.Driver = true, session.Branch, session.DriverName
+		if deadline, err := time.Parse(time.RFC3339, session.TimerDeadline); err == nil {
+			remaining := deadline.Sub(clk.Now())
+			snapshot.Remaining = &remaining
+		}
+	}
+	return snapshot, nil
+}
+
+// recordMetrics updates the stored counters. Metrics are best effort, so
+// failures are ignored.
+func recordMetrics(update func(*metrics.Counters)) {
+	if dir, err := config.GetConfigDir(); err == nil {
+		_ = metrics.New(dir).Update(update)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	navList bool
+	navBy   string
+)
+
+func newNavCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nav [observation...]",
+		Short: "Record a navigator's observation",
+		Long: `Appends a timestamped navigator observation to the current rotation.
+
+Observations go into the summary prompt and the handoff brief, so what the
+navigators noticed reaches the next driver along with the driver's notes.
+Example: mob-claude nav "the retry loop never backs off"
+Example: mob-claude nav --by alice "consider caching the token"
+Example: mob-claude nav --list`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runNav,
+	}
+	cmd.Flags().BoolVarP(&navList, "list", "l", false, "List observations for the current rotation")
+	cmd.Flags().StringVar(&navBy, "by", "", "Navigator making the observation")
+	return cmd
+}
+
+func runNav(cmd *cobra.Command, args []string) error {
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return fmt.Errorf("no active mob session. Run 'mob-claude start' first")
+	}
+
+	if navList || len(args) == 0 {
+		setResult(session.NavNotes)
+		if len(session.NavNotes) == 0 {
+			i18n.Println("No navigator observations for this rotation yet")
+			return nil
+		}
+		for _, line := range navigatorNotesFor(session) {
+			fmt.Println(line)
+		}
+		return nil
+	}
+
+	text := strings.TrimSpace(strings.Join(args, " "))
+	if text == "" {
+		return fmt.Errorf("observation text is empty")
+	}
+
+	session.NavNotes = append(session.NavNotes, config.SessionNote{
+		Time:   clk.Now().Format(time.RFC3339),
+		Text:   text,
+		Author: strings.TrimSpace(navBy),
+	})
+	if err := config.SaveCurrentSession(session); err != nil {
+		return fmt.Errorf("failed to save observation: %w", err)
+	}
+
+	setResult(session.NavNotes)
+	i18n.Printf("Observation noted (%d so far this rotation)\n", len(session.NavNotes))
+	return nil
+}
+
+// navigatorNotesFor renders the session's navigator observations as
+// "[15:04] alice: text", leaving the name out when none was given
+func navigatorNotesFor(session *config.CurrentSession) []string {
+	var notes []string
+	for _, note := range session.NavNotes {
+		if note.Author != "" {
+			notes = append(notes, fmt.Sprintf("[%s] %s: %s", noteTime(note), note.Author, note.Text))
+		} else {
+			notes = append(notes, fmt.Sprintf("[%s] %s", noteTime(note), note.Text))
+		}
+	}
+	return notes
+}
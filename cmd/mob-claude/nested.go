@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+// resolveNestedRepo makes sure the session is anchored to the right
+// repository when the working directory is a repo nested inside another.
+// If only one of them holds mob-claude state, or only one has an active
+// session, that one is used. Otherwise start asks, since the session it
+// creates settles the question for later commands; every other command
+// stays in the inner repo. Choosing the outer repo changes into its root.
+func resolveNestedRepo(cmd *cobra.Command, args []string) error {
+	inner, outer, err := mob.FindEnclosingRepo()
+	if err != nil || outer == "" {
+		return nil
+	}
+
+	innerState, outerState := hasMobState(inner), hasMobState(outer)
+	if innerState && outerState {
+		innerState, outerState = hasSession(inner), hasSession(outer)
+	}
+	switch {
+	case innerState && !outerState:
+		return nil
+	case outerState && !innerState:
+		i18n.Printf("Using the enclosing repository %s, which holds the mob session\n", outer)
+		return os.Chdir(outer)
+	}
+	if cmd.Name() != "start" {
+		return nil
+	}
+
+	i18n.Printf("Warning: %s is nested inside another repository (%s)\n", inner, outer)
+	for {
+		switch ask(i18n.Sprintf("Which repository does this session belong to? 1) %s 2) %s", inner, outer), "1") {
+		case "1":
+			return nil
+		case "2":
+			if err := os.Chdir(outer); err != nil {
+				return fmt.Errorf("failed to change to %s: %w", outer, err)
+			}
+			return nil
+		}
+	}
+}
+
+// hasSession reports whether a repository has an active session
+func hasSession(root string) bool {
+	_, err := os.Stat(filepath.Join(root, config.ConfigDir, config.CurrentFile))
+	return err == nil
+}
+
+// hasMobState reports whether a repository has an active session or plans
+func hasMobState(root string) bool {
+	for _, path := range []string{
+		filepath.Join(root, config.ConfigDir, config.CurrentFile),
+		filepath.Join(root, plans.PlansDir),
+	} {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
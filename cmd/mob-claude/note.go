@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var noteList bool
+
+func newNoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "note [text...]",
+		Short: "Add a note to the current rotation",
+		Long: `Appends a timestamped note to the current rotation's journal.
+
+Notes accumulate during the rotation and are combined into the driver note
+when you run 'mob-claude next' or 'mob-claude done'.
+Example: mob-claude note "login test is flaky on CI"
+Example: mob-claude note --list`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runNote,
+	}
+	cmd.Flags().BoolVarP(&noteList, "list", "l", false, "List notes for the current rotation")
+	return cmd
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return fmt.Errorf("no active mob session. Run 'mob-claude start' first")
+	}
+
+	if noteList || len(args) == 0 {
+		setResult(session.Notes)
+		if len(session.Notes) == 0 {
+			i18n.Println("No notes for this rotation yet")
+			return nil
+		}
+		for _, note := range session.Notes {
+			fmt.Printf("[%s] %s\n", noteTime(note), note.Text)
+		}
+		return nil
+	}
+
+	text := strings.TrimSpace(strings.Join(args, " "))
+	if text == "" {
+		return fmt.Errorf("note text is empty")
+	}
+
+	session.Notes = append(session.Notes, config.SessionNote{
+		Time: clk.Now().Format(time.RFC3339),
+		Text: text,
+	})
+	if err := config.SaveCurrentSession(session); err != nil {
+		return fmt.Errorf("failed to save note: %w", err)
+	}
+
+	setResult(session.Notes)
+	i18n.Printf("Noted (%d so far this rotation)\n", len(session.Notes))
+	return nil
+}
+
+// noteTime renders a note's timestamp as a short wall-clock time
+func noteTime(note config.SessionNote) string {
+	if t, err := time.Parse(time.RFC3339, note.Time); err == nil {
+		return t.Format("15:04")
+	}
+	return note.Time
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/events"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/notify"
+	"github.com/mob-claude/mob-claude/internal/secrets"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notifyURL    string
+	notifyName   string
+	notifyEvents string
+	notifyEvent  string
+)
+
+func newNotifyCmd() *cobra.Command {
+	notifyCmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Route session events to the desktop and chat tools",
+		Long: `Notifications tell the mob about session events wherever it works:
+
+  session-started  a driver ran 'start'
+  timer-expired    the rotation timer ran out ('mob-claude timer')
+  handoff          a driver ran 'next'; includes the rotation's TL;DR
+  done             the session was finished with 'done'
+
+Each notification goes to a channel: desktop, slack, discord, teams (a
+Workflows webhook), or webhook (a JSON POST for CI and other tools, with
+the rotation summary on handoff and done). A channel receives every event
+unless it's limited with --events. A Slack channel added without --url
+posts to the slackWebhook secret.
+
+URLs are kept in the secret store as notify.<name>, never in config.json.
+The name is the channel's unless --name gives another, for a second
+channel of the same kind.
+
+With no notifications configured, the timer shows a desktop notification
+when it expires.
+
+Example: mob-claude notify add slack --events handoff,done
+Example: mob-claude notify add teams --url https://example.logic.azure.com/workflows/...
+Example: mob-claude notify add webhook --url https://ci.example.com/hooks/mob --events handoff,done`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List notification channels",
+		Args:  cobra.NoArgs,
+		RunE:  runNotifyList,
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <channel>",
+		Short: "Add a notification channel, or change its URL or the events it receives",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runNotifyAdd,
+	}
+	addCmd.Flags().StringVar(&notifyURL, "url", "", "Incoming webhook URL for the channel, kept in the secret store")
+	addCmd.Flags().StringVar(&notifyName, "name", "", "Name of the channel (default: the channel)")
+	addCmd.Flags().StringVar(&notifyEvents, "events", "", "Comma-separated events to send (default: all)")
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a notification channel and its URL",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runNotifyRemove,
+	}
+
+	testCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Send a test notification to every channel",
+		Args:  cobra.NoArgs,
+		RunE:  runNotifyTest,
+	}
+	testCmd.Flags().StringVar(&notifyEvent, "event", "", "Only send to the channels routed for this event")
+
+	notifyCmd.AddCommand(listCmd, addCmd, removeCmd, testCmd)
+	return notifyCmd
+}
+
+// notificationResult is one route in notify list's result
+type notificationResult struct {
+	Name    string   `json:"name"`
+	Channel string   `json:"channel"`
+	URL     string   `json:"url,omitempty"`
+	Events  []string `json:"events,omitempty"`
+}
+
+func runNotifyList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	result := []notificationResult{}
+	for _, n := range cfg.Notifications {
+		url := ""
+		if stored, err := notify.URL(n); err != nil {
+			url = err.Error()
+		} else if stored != "" {
+			url = secrets.Redact(stored)
+		} else if n.Channel == events.ChannelSlack {
+			url = "slackWebhook"
+		}
+		result = append(result, notificationResult{Name: notificationName(n), Channel: n.Channel, URL: url, Events: n.Events})
+	}
+	setResult(result)
+	if len(result) == 0 {
+		i18n.Println("No notifications configured; the timer shows a desktop notification when it expires")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCHANNEL\tURL\tEVENTS")
+	for _, n := range result {
+		url := n.URL
+		if url == "" {
+			url = "-"
+		}
+		routed := strings.Join(n.Events, ", ")
+		if routed == "" {
+			routed = i18n.T("all")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", n.Name, n.Channel, url, routed)
+	}
+	return w.Flush()
+}
+
+func runNotifyAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	n := config.Notification{Channel: args[0]}
+	if !events.IsChannel(n.Channel) {
+		return fmt.Errorf("unknown channel: %s (use %s)", n.Channel, strings.Join(events.Channels, ", "))
+	}
+	if n.Channel == events.ChannelDesktop && notifyURL != "" {
+		return fmt.Errorf("desktop notifications don't take a URL")
+	}
+	name := notifyName
+	if name == "" {
+		name = n.Channel
+	}
+	for _, event := range strings.Split(notifyEvents, ",") {
+		if event = strings.TrimSpace(event); event == "" {
+			continue
+		}
+		if !events.IsEvent(event) {
+			return fmt.Errorf("unknown event: %s (use %s)", event, strings.Join(events.Events, ", "))
+		}
+		n.Events = append(n.Events, event)
+	}
+
+	i := slices.IndexFunc(cfg.Notifications, func(c config.Notification) bool { return notificationName(c) == name })
+	if i >= 0 && cfg.Notifications[i].Channel != n.Channel {
+		return fmt.Errorf("%s is a %s notification channel; pick another --name", name, cfg.Notifications[i].Channel)
+	}
+	switch {
+	case notifyURL != "":
+		n.Secret = config.NotifySecretPrefix + name
+	case i >= 0:
+		// Changing the events keeps the URL
+		n.Secret, n.URL = cfg.Notifications[i].Secret, cfg.Notifications[i].URL
+	case events.NeedsURL(n.Channel):
+		return fmt.Errorf("%s notifications need --url", n.Channel)
+	}
+	if i < 0 {
+		i = len(cfg.Notifications)
+		cfg.Notifications = append(cfg.Notifications, n)
+	} else {
+		cfg.Notifications[i] = n
+	}
+	if notifyURL != "" {
+		if u, err := url.Parse(notifyURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid notification: --url must be an absolute http(s) URL")
+		}
+	}
+	key := fmt.Sprintf("notifications[%d]", i)
+	for _, p := range config.Validate(cfg) {
+		if strings.HasPrefix(p.Key, key+".") && !strings.HasSuffix(p.Key, ".url") {
+			return fmt.Errorf("invalid notification: %s %s", p.Key, p.Message)
+		}
+	}
+
+	if notifyURL != "" {
+		if err := config.SetSecret(n.Secret, notifyURL); err != nil {
+			return fmt.Errorf("failed to store the URL: %w", err)
+		}
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	i18n.Printf("Notification channel %s saved\n", name)
+	return nil
+}
+
+func runNotifyRemove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	i := slices.IndexFunc(cfg.Notifications, func(c config.Notification) bool { return notificationName(c) == args[0] })
+	if i < 0 {
+		return fmt.Errorf("no notification channel named %s", args[0])
+	}
+	secret := cfg.Notifications[i].Secret
+	cfg.Notifications = slices.Delete(cfg.Notifications, i, i+1)
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if config.IsNotifySecret(secret) {
+		if err := config.SetSecret(secret, ""); err != nil {
+			i18n.Printf("Warning: could not remove %s from the secret store: %v\n", secret, err)
+		}
+	}
+	i18n.Printf("Notification channel %s removed\n", args[0])
+	return nil
+}
+
+// notificationName is the name a notification channel is listed and
+// removed by: the name in its secret key, or the channel's
+func notificationName(n config.Notification) string {
+	if name, ok := strings.CutPrefix(n.Secret, config.NotifySecretPrefix); ok && name != "" {
+		return name
+	}
+	return n.Channel
+}
+
+func runNotifyTest(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if notifyEvent != "" && !events.IsEvent(notifyEvent) {
+		return fmt.Errorf("unknown event: %s (use %s)", notifyEvent, strings.Join(events.Events, ", "))
+	}
+
+	routes := cfg.Notifications
+	if len(routes) == 0 {
+		routes = notify.DefaultRoutes
+	}
+	msg := notify.Message{
+		Event:     notifyEvent,
+		Timestamp: clk.Now(),
+		Title:     i18n.T("mob-claude test notification"),
+		Text:      i18n.T("Notifications are set up for this mob"),
+		Team:      cfg.TeamName,
+	}
+	if msg.Event == "" {
+		msg.Event = "test"
+	}
+	sent := 0
+	for _, route := range routes {
+		if notifyEvent != "" && !notify.Routed(route, notifyEvent) {
+			continue
+		}
+		channel, err := notify.NewChannel(route, cfg.SlackWebhook)
+		if err == nil {
+			err = channel.Send(cmd.Context(), msg)
+		}
+		if err != nil {
+			i18n.Printf("%s: failed: %v\n", notificationName(route), err)
+			continue
+		}
+		i18n.Printf("%s: sent\n", notificationName(route))
+		sent++
+	}
+	if sent == 0 {
+		return fmt.Errorf("no notification was delivered")
+	}
+	return nil
+}
+
+// emitNotification sends a session event to the notification channels
+// routed for it; webhook channels also get the summary, if there is one. Delivery is best effort: failures are reported but never
+// stop the mob flow.
+func emitNotification(ctx context.Context, cfg *config.Config, event, title, text, branch, driverName string, summaryObj *plans.Summary) {
+	err := notify.New(cfg.Notifications, cfg.SlackWebhook).Send(ctx, notify.Message{
+		Event:     event,
+		Timestamp: clk.Now(),
+		Title:     title,
+		Text:      text,
+		Team:      cfg.TeamName,
+		Branch:    branch,
+		Driver:    driverName,
+		Summary:   summaryObj,
+	})
+	if err != nil {
+		i18n.Printf("Warning: could not send notification: %v\n", err)
+	}
+}
+
+// summaryTLDR returns the summary's TL;DR, or "" if there's no summary
+func summaryTLDR(summaryObj *plans.Summary) string {
+	if summaryObj == nil {
+		return ""
+	}
+	return summaryObj.TLDR
+}
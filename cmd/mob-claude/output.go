@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/daemon"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+// Formats for --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var (
+	outputFormat = outputText
+
+	// resultOut is where the JSON result is written. With --output json
+	// everything else, mob.sh's output included, goes to stderr so stdout
+	// holds only the result.
+	resultOut io.Writer = os.Stdout
+
+	// commandResult is the structured result the command recorded, if any
+	commandResult interface{}
+)
+
+// commandOutput is the object printed with --output json once a command
+// finishes. Data is the command's result; commands that don't record one
+// only report whether they succeeded.
+type commandOutput struct {
+	Command string      `json:"command"`
+	OK      bool        `json:"ok"`
+	Error   string      `json:"error,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Rotation upload outcomes reported by next and done
+const (
+	uploadRecorded = "recorded"
+	uploadQueued   = "queued"
+)
+
+// rotationResult is the result of next and done
+type rotationResult struct {
+	Branch  string         `json:"branch"`
+	Driver  string         `json:"driver,omitempty"`
+	Summary *plans.Summary `json:"summary,omitempty"`
+
+	// Upload is uploadRecorded once the dashboard has the rotation and
+	// uploadQueued while it waits in the outbox; it's empty without a
+	// dashboard
+	Upload     string `json:"upload,omitempty"`
+	PlanSynced bool   `json:"planSynced,omitempty"`
+}
+
+// statusResult is the result of status
+type statusResult struct {
+	MobStatus     string                `json:"mobStatus,omitempty"`
+	Session       *daemon.SessionStatus `json:"session"`
+	Plan          *daemon.PlanStatus    `json:"plan,omitempty"`
+	LatestSummary *plans.Summary        `json:"latestSummary,omitempty"`
+}
+
+// newStatusResult gathers status's result from the same sources as serve
+func newStatusResult(branch, mobStatus string) *statusResult {
+	result := &statusResult{MobStatus: mobStatus}
+	result.Session, _ = serveSessionStatus()
+	if branch == "" {
+		return result
+	}
+	if plan, err := servePlan(branch); err == nil && plan.Markdown != "" {
+		result.Plan = plan
+	}
+	if list, err := serveSummaries(branch, 1); err == nil && len(list.Summaries) > 0 {
+		result.LatestSummary = list.Summaries[0]
+	}
+	return result
+}
+
+// setOutputFormat applies the --output option
+func setOutputFormat(format string) error {
+	switch format {
+	case "", outputText:
+		outputFormat = outputText
+	case outputJSON:
+		outputFormat = outputJSON
+		os.Stdout = os.Stderr
+	default:
+		return fmt.Errorf("unknown output format %q (use text or json)", format)
+	}
+	return nil
+}
+
+// jsonOutput reports whether results are printed as JSON
+func jsonOutput() bool {
+	return outputFormat == outputJSON
+}
+
+// setResult records a command's structured result for --output json
+func setResult(data interface{}) {
+	commandResult = data
+}
+
+// finishOutput prints the JSON result for the command that ran, if
+// --output json was given
+func finishOutput(cmd *cobra.Command, err error) {
+	if !jsonOutput() {
+		return
+	}
+	out := commandOutput{OK: err == nil, Data: commandResult}
+	if cmd != nil {
+		out.Command = strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	}
+	if err != nil {
+		out.Error = err.Error()
+	}
+	enc := json.NewEncoder(resultOut)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(out)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"golang.org/x/sync/errgroup"
+)
+
+// pickConcurrency caps the latest-rotation lookups the picker runs at once
+const pickConcurrency = 4
+
+// pickChoice is one workstream offered by 'start --pick'
+type pickChoice struct {
+	branch     string
+	title      string
+	lastDriver string
+	lastActive time.Time
+}
+
+// pickWorkstream lists the team's active workstreams for this repository,
+// most recently active first, and asks which one to join
+func pickWorkstream(ctx context.Context, cfg *config.Config, mobWrapper *mob.Wrapper) (string, error) {
+	if !isInteractive() {
+		return "", fmt.Errorf("--pick needs a terminal to choose a workstream")
+	}
+	choices, err := fetchPickChoices(ctx, cfg, mobWrapper)
+	if err != nil {
+		return "", err
+	}
+	if len(choices) == 0 {
+		return "", fmt.Errorf("no active workstreams on the dashboard. Start one with 'mob-claude start -b <branch>'")
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, c := range choices {
+		title, driver := c.title, c.lastDriver
+		if title == "" {
+			title = "-"
+		}
+		if driver == "" {
+			driver = "-"
+		}
+		fmt.Fprintf(w, "  %d)\t%s\t%s\t%s\t%s\n", i+1, c.branch, title, driver, formatAge(c.lastActive))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	for {
+		answer := ask(i18n.T("Which workstream do you want to join?"), "1")
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+			return choices[n-1].branch, nil
+		}
+		for _, c := range choices {
+			if c.branch == answer {
+				return c.branch, nil
+			}
+		}
+		i18n.Printf("Enter a number from 1 to %d or a branch name\n", len(choices))
+	}
+}
+
+// fetchPickChoices fetches every page of the team's workstreams, keeps the
+// active ones for this repository, and looks up who drove each last
+func fetchPickChoices(ctx context.Context, cfg *config.Config, mobWrapper *mob.Wrapper) ([]*pickChoice, error) {
+	client := newAPIClient(cfg)
+	workstreams, err := allWorkstreams(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	// Workstreams of other repositories can't be joined from here. Without
+	// a remote there's nothing to compare, so all of them are offered.
+	repoURL, _ := mobWrapper.GetRepoURL()
+
+	var choices []*pickChoice
+	for _, ws := range workstreams {
+		if !ws.IsActive || (repoURL != "" && ws.RepoURL != "" && mob.NormalizeRepoURL(ws.RepoURL) != repoURL) {
+			continue
+		}
+		choices = append(choices, &pickChoice{
+			branch:     ws.Branch,
+			title:      plans.PlanTitle(ws.PlanText),
+			lastActive: ws.UpdatedAt,
+		})
+	}
+
+	// Lookups that fail just leave the driver blank
+	var g errgroup.Group
+	g.SetLimit(pickConcurrency)
+	for _, c := range choices {
+		c := c
+		g.Go(func() error {
+			if rotation, err := client.GetLatestRotation(ctx, c.branch); err == nil && rotation != nil {
+				c.lastDriver = rotation.DriverName
+				if rotation.StartedAt.After(c.lastActive) {
+					c.lastActive = rotation.StartedAt
+				}
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	sort.SliceStable(choices, func(i, j int) bool {
+		return choices[i].lastActive.After(choices[j].lastActive)
+	})
+	return choices, nil
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	revertRotation string
+	revertYes      bool
+	aiUpdateYes    bool
+)
+
+func newPlanCmd() *cobra.Command {
+	planCmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Work with the session plan",
+	}
+
+	revertCmd := &cobra.Command{
+		Use:   "revert --to-rotation <id>",
+		Short: "Restore the plan as it was at the end of a rotation",
+		Long: `Replaces the current branch's plan with the snapshot stored with a
+dashboard rotation, both locally and on the dashboard. Use it when a rotation
+left the plan in a bad state.`,
+		Args: cobra.NoArgs,
+		RunE: runPlanRevert,
+	}
+	revertCmd.Flags().StringVar(&revertRotation, "to-rotation", "", "ID of the rotation whose plan snapshot to restore")
+	revertCmd.Flags().BoolVarP(&revertYes, "yes", "y", false, "Don't ask for confirmation")
+	_ = revertCmd.MarkFlagRequired("to-rotation")
+
+	aiUpdateCmd := &cobra.Command{
+		Use:   "ai-update",
+		Short: "Have Claude bring the plan up to date with the work done",
+		Long: `Sends the current branch's plan, its diff from the base branch, and the
+latest rotation summary to Claude, which checks off finished tasks, adds
+tasks the work turned up, and appends decisions. The proposed changes are
+shown as a diff to review before the plan is saved and synced.`,
+		Args: cobra.NoArgs,
+		RunE: runPlanAIUpdate,
+	}
+	aiUpdateCmd.Flags().BoolVarP(&aiUpdateYes, "yes", "y", false, "Save the proposed plan without asking")
+	addBaseFlag(aiUpdateCmd)
+
+	planCmd.AddCommand(revertCmd, aiUpdateCmd, newPlanLintCmd(), newPlanPushCmd(), newPlanPullCmd())
+	return planCmd
+}
+
+func runPlanRevert(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+
+	client := newAPIClient(cfg)
+	rotation, err := client.GetRotation(ctx, branch, revertRotation)
+	if err != nil {
+		return err
+	}
+	if rotation == nil {
+		return fmt.Errorf("rotation %s not found on %s", revertRotation, branch)
+	}
+	if rotation.PlanSnapshot == "" {
+		return fmt.Errorf("rotation %s has no plan snapshot", revertRotation)
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	current, _ := planMgr.LoadPlan(branch)
+	if current == rotation.PlanSnapshot {
+		i18n.Println("The plan already matches that rotation's snapshot")
+		return nil
+	}
+
+	i18n.Printf("Rotation %s was driven by %s, starting %s.\n", rotation.ID, rotation.DriverName, rotation.StartedAt.Local().Format("2006-01-02 15:04"))
+	if !revertYes && !confirm(i18n.Sprintf("Replace the plan for %s with its snapshot?", branch), false) {
+		i18n.Println("Plan left unchanged")
+		return nil
+	}
+
+	if err := planMgr.SavePlan(branch, rotation.PlanSnapshot); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+	if err := uploadPlan(ctx, client, planMgr, branch, rotation.PlanSnapshot); err != nil {
+		i18n.Printf("Warning: restored the local plan, but could not update the dashboard: %v\n", err)
+		queueFailedPlan(branch, rotation.PlanSnapshot, err)
+		return nil
+	}
+
+	i18n.Printf("Plan for %s restored from rotation %s\n", branch, rotation.ID)
+	return nil
+}
+
+func runPlanAIUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	current, err := planMgr.LoadPlan(branch)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(current) == "" {
+		return fmt.Errorf("no plan for %s to update", branch)
+	}
+
+	mobWrapper := mob.NewWrapper()
+	mobWrapper.SetRemote(cfg.GitRemote)
+	mobWrapper.SetDiffBase(diffBaseFor(cfg))
+	diff, err := mobWrapper.GetDiffFromBase()
+	if err != nil {
+		i18n.Printf("Warning: could not get diff: %v\n", err)
+	}
+	var latest *plans.Summary
+	if summaries, err := planMgr.LoadBranchSummaries(branch); err == nil && len(summaries) > 0 {
+		latest = summaries[len(summaries)-1]
+	}
+
+	i18n.Println("Asking Claude to update the plan...")
+	proposed, err := newGenerator(cfg).UpdatePlan(current, diff, latest)
+	if err != nil {
+		return fmt.Errorf("failed to update plan: %w", err)
+	}
+
+	changes := plans.DiffLines(current, proposed)
+	if changes == "" {
+		i18n.Println("Claude suggested no changes to the plan")
+		return nil
+	}
+	fmt.Println()
+	fmt.Print(changes)
+	fmt.Println()
+	if !aiUpdateYes && !confirm(i18n.Sprintf("Save the updated plan for %s?", branch), false) {
+		i18n.Println("Plan left unchanged")
+		return nil
+	}
+
+	if err := planMgr.SavePlan(branch, proposed); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+	i18n.Printf("Plan for %s updated\n", branch)
+
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return nil
+	}
+	if err := uploadPlan(ctx, newAPIClient(cfg), planMgr, branch, proposed); err != nil {
+		i18n.Printf("Warning: could not sync plan: %v\n", err)
+		queueFailedPlan(branch, proposed, err)
+	}
+	return nil
+}
+
+// uploadPlan sends a plan that was just saved locally to the dashboard and
+// records it as synced, in its front matter and the sync state, so
+// sync-plans doesn't treat it as a conflict
+func uploadPlan(ctx context.Context, client *api.Client, planMgr *plans.Manager, branch, plan string) error {
+	marked, err := plans.MarkSynced(plan)
+	if err != nil {
+		marked = plan
+	}
+	if err := client.UpdatePlan(ctx, branch, marked); err != nil {
+		return err
+	}
+	recordPlanSync(branch)
+	if marked != plan {
+		if err := planMgr.SavePlan(branch, marked); err != nil {
+			i18n.Printf("Warning: could not save plan locally: %v\n", err)
+		}
+	}
+
+	if state, err := planMgr.LoadSyncState(); err == nil {
+		state[branch] = plans.ContentHash(marked)
+		_ = planMgr.SaveSyncState(state)
+	}
+	return nil
+}
+
+// draftPlan has Claude draft a new plan for branch from its name, linked
+// ticket, and recent commits, in the shape of template (the default
+// template if empty), and lets the user edit the draft. If Claude can't
+// help, the plain template is used instead.
+func draftPlan(cfg *config.Config, planMgr *plans.Manager, mobWrapper *mob.Wrapper, branch, template string, claudeErr error) string {
+	if template == "" {
+		template = plans.DefaultTemplate
+	}
+	plan := planMgr.RenderTemplate(branch, template)
+	if claudeErr != nil {
+		i18n.Println("Claude is unavailable, so the plan starts from the template")
+		return plan
+	}
+
+	commits, err := mobWrapper.GetRecentCommits(20)
+	if err != nil {
+		i18n.Printf("Warning: could not get recent commits: %v\n", err)
+	}
+	ticket := plans.TicketFromBranch(branch)
+
+	i18n.Println("Asking Claude to draft the plan...")
+	draft, err := newGenerator(cfg).DraftPlan(branch, ticket, commits, plan)
+	if err != nil {
+		i18n.Printf("Warning: could not draft plan: %v\n", err)
+		return plan
+	}
+	if !isInteractive() {
+		return draft
+	}
+
+	fmt.Println()
+	fmt.Print(draft)
+	fmt.Println()
+	if !confirm(i18n.T("Edit the draft before saving?"), true) {
+		return draft
+	}
+	edited, err := editText(draft, "plan-*.md")
+	if err != nil {
+		i18n.Printf("Warning: could not edit the draft, saving it as is: %v\n", err)
+		return draft
+	}
+	if strings.TrimSpace(edited) == "" {
+		i18n.Println("The draft was emptied, so the plan starts from the template")
+		return plan
+	}
+	return edited
+}
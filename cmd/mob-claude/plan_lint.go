@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintAll    bool
+	lintStrict bool
+)
+
+// planLintResult is one plan's lint findings, as 'plan lint --output json'
+// reports them
+type planLintResult struct {
+	Branch   string              `json:"branch"`
+	Path     string              `json:"path"`
+	Problems []plans.LintProblem `json:"problems"`
+}
+
+func newPlanLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check that the plan is filled in and well formed",
+		Long: `Checks the current branch's plan for the required sections (Goal and
+Current Status), for placeholders left over from the template, and for a
+plan that is still the untouched template.
+
+Exits non-zero if an error is found, so CI can enforce that mobs keep their
+plans up to date. Placeholders outside the required sections are warnings,
+which fail only with --strict.
+
+Example: mob-claude plan lint --all --strict`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runPlanLint,
+	}
+	cmd.Flags().BoolVar(&lintAll, "all", false, "Lint every plan in the plans directory")
+	cmd.Flags().BoolVar(&lintStrict, "strict", false, "Fail on warnings too")
+	return cmd
+}
+
+func runPlanLint(cmd *cobra.Command, args []string) error {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	var targets []plans.LocalPlan
+	if lintAll {
+		if targets, err = planMgr.ListPlans(); err != nil {
+			return fmt.Errorf("failed to list plans: %w", err)
+		}
+	} else {
+		branch, err := currentPlanBranch()
+		if err != nil {
+			return err
+		}
+		if !planMgr.PlanExists(branch) {
+			return fmt.Errorf("no plan for %s", branch)
+		}
+		targets = []plans.LocalPlan{{Branch: branch, Path: planMgr.GetPlanPath(branch)}}
+	}
+
+	results := []planLintResult{}
+	failed := 0
+	for _, target := range targets {
+		data, err := os.ReadFile(target.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read plan: %w", err)
+		}
+		problems := plans.Lint(string(data))
+		results = append(results, planLintResult{Branch: target.Branch, Path: target.Path, Problems: problems})
+		if plans.HasLintErrors(problems) || (lintStrict && len(problems) > 0) {
+			failed++
+		}
+
+		if len(problems) == 0 {
+			i18n.Printf("%s: OK\n", target.Branch)
+			continue
+		}
+		fmt.Printf("%s:\n", target.Branch)
+		for _, p := range problems {
+			fmt.Printf("  - %s\n", p)
+		}
+	}
+	setResult(results)
+
+	if len(targets) == 0 {
+		i18n.Println("No plans to lint")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d plan(s) failed lint", failed)
+	}
+	return nil
+}
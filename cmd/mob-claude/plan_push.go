@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	planPushForce bool
+	planPullForce bool
+)
+
+func newPlanPushCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push",
+		Short: "Replace the dashboard's plan with the local one",
+		Long: `Uploads the current branch's local plan to the dashboard, after showing
+what it changes there. Use it instead of relying on start and next to pick
+the direction.
+
+If the dashboard's plan was edited since the last sync, push refuses to
+overwrite those edits unless --force is given; 'plan pull' fetches them.
+
+Example: mob-claude plan push`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runPlanPush,
+	}
+	cmd.Flags().BoolVar(&planPushForce, "force", false, "Overwrite dashboard edits made since the last sync")
+	return cmd
+}
+
+func newPlanPullCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Replace the local plan with the dashboard's",
+		Long: `Downloads the current branch's plan from the dashboard, after showing what
+it changes in the local plan.
+
+If the local plan has edits that weren't pushed, pull refuses to overwrite
+them unless --force is given; 'plan push' uploads them.
+
+Example: mob-claude plan pull --force`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runPlanPull,
+	}
+	cmd.Flags().BoolVar(&planPullForce, "force", false, "Overwrite local edits that weren't pushed")
+	return cmd
+}
+
+// planCopies are the two sides of a plan sync for the current branch
+type planCopies struct {
+	branch     string
+	local      string
+	remote     string
+	lastSynced string
+	registered bool
+}
+
+// loadPlanCopies fetches the current branch's local and dashboard plans
+func loadPlanCopies(cmd *cobra.Command, cfg *config.Config, planMgr *plans.Manager) (*planCopies, error) {
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return nil, err
+	}
+	local, err := planMgr.LoadPlan(branch)
+	if err != nil {
+		return nil, err
+	}
+	client := newAPIClient(cfg)
+	ws, err := client.GetWorkstream(cmd.Context(), branch)
+	if err != nil {
+		return nil, err
+	}
+	copies := &planCopies{branch: branch, local: local, registered: ws != nil}
+	if copies.registered {
+		if copies.remote, err = client.GetPlan(cmd.Context(), branch); err != nil {
+			return nil, fmt.Errorf("failed to fetch plan: %w", err)
+		}
+	}
+	recorded := ""
+	if state, err := planMgr.LoadSyncState(); err == nil {
+		recorded = state[branch]
+	}
+	copies.lastSynced = plans.LastSyncedHash(local, recorded)
+	return copies, nil
+}
+
+// changedSinceSync reports whether a copy of the plan was edited since the
+// last sync. A plan that was never synced counts as edited.
+func (c *planCopies) changedSinceSync(plan string) bool {
+	return plans.ContentHash(plan) != c.lastSynced
+}
+
+// printPlanDiff shows how the plan changes from one side to the other,
+// leaving out the front matter
+func printPlanDiff(fromName, from, toName, to string) {
+	fmt.Printf("\n--- %s\n+++ %s\n", fromName, toName)
+	fmt.Print(plans.DiffLines(planBody(from), planBody(to)))
+	fmt.Println()
+}
+
+func planBody(plan string) string {
+	if _, body, err := plans.ParseFrontMatter(plan); err == nil {
+		return body
+	}
+	return plan
+}
+
+func runPlanPush(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	copies, err := loadPlanCopies(cmd, cfg, planMgr)
+	if err != nil {
+		return err
+	}
+	branch := copies.branch
+	if copies.local == "" {
+		return fmt.Errorf("no local plan for %s to push", branch)
+	}
+
+	if plans.ContentHash(copies.local) == plans.ContentHash(copies.remote) {
+		markPlanSynced(planMgr, branch, copies.local)
+		setResult(planSyncResult{Branch: branch, Action: syncInSync})
+		i18n.Printf("The plan for %s is already in sync\n", branch)
+		return nil
+	}
+	if copies.remote != "" && copies.changedSinceSync(copies.remote) && !planPushForce {
+		printPlanDiff(i18n.T("local"), copies.local, i18n.T("dashboard"), copies.remote)
+		return fmt.Errorf("the dashboard's plan for %s was edited since the last sync. Run 'mob-claude plan pull' to get those edits, or push with --force to overwrite them", branch)
+	}
+
+	printPlanDiff(i18n.T("dashboard"), copies.remote, i18n.T("local"), copies.local)
+	if !confirm(i18n.Sprintf("Push the local plan for %s to the dashboard?", branch), true) {
+		setResult(planSyncResult{Branch: branch, Action: syncSkip})
+		i18n.Println("Plan left unchanged")
+		return nil
+	}
+
+	client := newAPIClient(cfg)
+	if !copies.registered {
+		mobWrapper := mob.NewWrapper()
+		mobWrapper.SetRemote(cfg.GitRemote)
+		repoURL, err := mobWrapper.GetRepoURL()
+		if err != nil {
+			repoURL = "unknown"
+		}
+		if _, err := client.CreateWorkstream(cmd.Context(), repoURL, branch); err != nil {
+			return fmt.Errorf("failed to register workstream: %w", err)
+		}
+	}
+	if err := uploadPlan(cmd.Context(), client, planMgr, branch, copies.local); err != nil {
+		return fmt.Errorf("failed to push plan: %w", err)
+	}
+	setResult(planSyncResult{Branch: branch, Action: syncPush})
+	i18n.Printf("Pushed the plan for %s\n", branch)
+	return nil
+}
+
+func runPlanPull(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	copies, err := loadPlanCopies(cmd, cfg, planMgr)
+	if err != nil {
+		return err
+	}
+	branch := copies.branch
+	if copies.remote == "" {
+		return fmt.Errorf("no plan for %s on the dashboard", branch)
+	}
+
+	remoteHash := plans.ContentHash(copies.remote)
+	if plans.ContentHash(copies.local) == remoteHash {
+		markPlanSynced(planMgr, branch, copies.local)
+		setResult(planSyncResult{Branch: branch, Action: syncInSync})
+		i18n.Printf("The plan for %s is already in sync\n", branch)
+		return nil
+	}
+	if copies.local != "" && copies.changedSinceSync(copies.local) && !planPullForce {
+		printPlanDiff(i18n.T("dashboard"), copies.remote, i18n.T("local"), copies.local)
+		return fmt.Errorf("the local plan for %s has edits that weren't pushed. Run 'mob-claude plan push' to upload them, or pull with --force to overwrite them", branch)
+	}
+
+	printPlanDiff(i18n.T("local"), copies.local, i18n.T("dashboard"), copies.remote)
+	if !confirm(i18n.Sprintf("Replace the local plan for %s with the dashboard's?", branch), true) {
+		setResult(planSyncResult{Branch: branch, Action: syncSkip})
+		i18n.Println("Plan left unchanged")
+		return nil
+	}
+
+	if !markPlanSynced(planMgr, branch, copies.remote) {
+		return fmt.Errorf("failed to save plan for %s", branch)
+	}
+	if state, err := planMgr.LoadSyncState(); err == nil {
+		state[branch] = remoteHash
+		_ = planMgr.SaveSyncState(state)
+	}
+	recordPlanSync(branch)
+	setResult(planSyncResult{Branch: branch, Action: syncPull})
+	i18n.Printf("Pulled the plan for %s\n", branch)
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// presenceCheckInterval is how often 'presence' checks whether the session
+// changed hands or ended
+const presenceCheckInterval = 15 * time.Second
+
+func newPresenceCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "presence",
+		Short: "Show teammates that you're driving, live",
+		Long: `Registers the current driver with the dashboard over a long-lived
+connection, so remote teammates see "Alice is driving, 7 min remaining" as it
+happens, and prints who else is connected to the workstream.
+
+Presence lasts as long as the command runs. It follows the session: after a
+handoff the new driver is announced, and it stops once the session ends.`,
+		Args: cobra.NoArgs,
+		RunE: runPresence,
+	}
+}
+
+// presenceResult is the result of presence: the last update seen before it
+// stopped
+type presenceResult struct {
+	Branch  string               `json:"branch,omitempty"`
+	Driver  string               `json:"driver,omitempty"`
+	Members []api.PresenceMember `json:"members"`
+}
+
+func runPresence(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	client := newAPIClient(cfg)
+	sessionID := idGen.NewID()
+	result := &presenceResult{Members: []api.PresenceMember{}}
+	setResult(result)
+	for {
+		session, err := config.LoadCurrentSession()
+		if err != nil {
+			return fmt.Errorf("failed to load session: %w", err)
+		}
+		if session == nil {
+			i18n.Println("No active mob session; presence cleared")
+			return nil
+		}
+
+		state := presenceState(session, sessionID)
+		i18n.Printf("Announcing %s as the driver of %s (Ctrl-C to stop)\n", state.DriverName, session.Branch)
+
+		connCtx, cancel := context.WithCancel(ctx)
+		conn := client.Presence(session.Branch, state)
+		result.Branch, result.Driver = session.Branch, state.DriverName
+		conn.OnUpdate = func(members []api.PresenceMember) {
+			result.Members = members
+			printPresence(members)
+		}
+		conn.OnDisconnect = func(err error) {
+			i18n.Printf("Presence connection lost (%v), reconnecting...\n", err)
+		}
+		go cancelOnSessionChange(connCtx, cancel, session)
+
+		err = conn.Run(connCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if connCtx.Err() == nil && err != nil {
+			return err
+		}
+	}
+}
+
+// presenceState describes the session's driver for the dashboard
+func presenceState(session *config.CurrentSession, sessionID string) api.PresenceState {
+	state := api.PresenceState{SessionID: sessionID, DriverName: session.DriverName}
+	if deadline, err := time.Parse(time.RFC3339, session.TimerDeadline); err == nil {
+		state.Deadline = &deadline
+	}
+	return state
+}
+
+// cancelOnSessionChange calls cancel once the session's driver, branch, or
+// timer changes, or the session ends, so presence can be re-announced
+func cancelOnSessionChange(ctx context.Context, cancel context.CancelFunc, session *config.CurrentSession) {
+	ticker := time.NewTicker(presenceCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current, err := config.LoadCurrentSession()
+		if err != nil {
+			continue
+		}
+		if current == nil || current.Branch != session.Branch || current.DriverName != session.DriverName ||
+			current.TimerDeadline != session.TimerDeadline || current.StartedAt != session.StartedAt {
+			cancel()
+			return
+		}
+	}
+}
+
+// printPresence lists who is connected to the workstream
+func printPresence(members []api.PresenceMember) {
+	fmt.Printf("\n[%s]\n", clk.Now().Format("15:04"))
+	if len(members) == 0 {
+		i18n.Println("  Nobody is connected")
+		return
+	}
+	for _, m := range members {
+		switch {
+		case m.Driving && m.Deadline != nil:
+			remaining := max(m.Deadline.Sub(clk.Now()).Round(time.Minute), 0)
+			i18n.Printf("  %s is driving, %d min remaining\n", m.DriverName, int(remaining.Minutes()))
+		case m.Driving:
+			i18n.Printf("  %s is driving\n", m.DriverName)
+		default:
+			i18n.Printf("  %s is watching\n", m.DriverName)
+		}
+	}
+}
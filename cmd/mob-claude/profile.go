@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newProfileCmd() *cobra.Command {
+	profileCmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Switch between config profiles",
+		Long: `Profiles are named dashboard setups in config, each with its own apiUrl,
+teamName, model, and apiToken, for working with several teams:
+
+  mob-claude config set profiles.oss.apiUrl https://mob.example.org
+  mob-claude config set profiles.oss.teamName oss
+  mob-claude profile use oss
+
+A profile can also be picked for a single command with --profile <name> or
+the MOB_CLAUDE_PROFILE environment variable.`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List profiles, marking the active one",
+		Args:  cobra.NoArgs,
+		RunE:  runProfileList,
+	}
+
+	useCmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a profile the default for this project",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runProfileUse,
+	}
+
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Go back to the settings outside any profile",
+		Args:  cobra.NoArgs,
+		RunE:  runProfileClear,
+	}
+
+	profileCmd.AddCommand(listCmd, useCmd, clearCmd)
+	return profileCmd
+}
+
+// profileResult is one profile in profile list's result
+type profileResult struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active,omitempty"`
+	config.Profile
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	active := config.ActiveProfile(cfg)
+	result := []profileResult{}
+	for _, name := range cfg.ProfileNames() {
+		result = append(result, profileResult{Name: name, Active: name == active, Profile: cfg.Profiles[name]})
+	}
+	setResult(result)
+	if len(cfg.Profiles) == 0 {
+		i18n.Println("No profiles configured")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tTEAM\tAPI URL\tMODEL")
+	for _, name := range cfg.ProfileNames() {
+		p := cfg.Profiles[name]
+		mark := " "
+		if name == active {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\n", mark, name, p.TeamName, p.APIURL, p.Model)
+	}
+	return w.Flush()
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	name := args[0]
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+
+	cfg.Profile = name
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	i18n.Printf("Using profile %s\n", name)
+	return nil
+}
+
+func runProfileClear(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.Profile = ""
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	i18n.Println("No profile in use")
+	return nil
+}
+
+// setProfileValue sets a field of a named profile, creating the profile if needed
+func setProfileValue(cfg *config.Config, name, field, value string) error {
+	profile := cfg.Profiles[name]
+	switch field {
+	case "apiUrl":
+		profile.APIURL = value
+	case "teamName":
+		profile.TeamName = value
+	case "model":
+		profile.Model = value
+	default:
+		return fmt.Errorf("unknown profile key: %s (use apiUrl, teamName, model, apiToken, or signingSecret)", field)
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]config.Profile)
+	}
+	cfg.Profiles[name] = profile
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// isInteractive reports whether stdin is a terminal we can prompt on
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm asks a yes/no question, returning defaultYes when the answer is
+// empty or stdin isn't interactive
+func confirm(question string, defaultYes bool) bool {
+	if !isInteractive() {
+		return defaultYes
+	}
+
+	hint := "[y/N]"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+	fmt.Printf("%s %s ", question, hint)
+
+	answer, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return defaultYes
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return defaultYes
+	}
+}
+
+// ask prompts for a line of input, returning def when empty or non-interactive
+func ask(question, def string) string {
+	if !isInteractive() {
+		return def
+	}
+
+	if def != "" {
+		fmt.Printf("%s [%s]: ", question, def)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+
+	answer, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return def
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return def
+}
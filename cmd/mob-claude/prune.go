@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneDays         int
+	pruneKeepArchives int
+	pruneDelete       bool
+	pruneDryRun       bool
+)
+
+// pruneResult is the JSON output of 'prune'
+type pruneResult struct {
+	*plans.PruneResult
+	CacheEntries int  `json:"cacheEntries,omitempty"`
+	DryRun       bool `json:"dryRun,omitempty"`
+}
+
+func newPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Archive or delete old summaries and archives",
+		Long: `Applies the retention policy to the local artifacts, so a long-lived repo
+doesn't pile up thousands of small files.
+
+Summaries older than keepSummariesDays are bundled into an archive in
+.claude/mob/archive/<branch>/ and removed, or just removed with --delete.
+A branch whose summaries and plan are all that old, and that isn't the
+current session's, is archived and cleaned whole, as 'done --archive' does,
+even with --delete. Then each branch keeps its newest keepArchives
+archives. Cached dashboard responses unused for keepSummariesDays are
+dropped as well.
+
+'done' prunes summaries, archives, and the cache on its own once either
+setting is configured; only this command prunes whole branches.
+
+Example: mob-claude config set keepSummariesDays 90
+Example: mob-claude prune --dry-run
+Example: mob-claude prune --days 30 --keep-archives 5 --delete`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runPrune,
+	}
+	cmd.Flags().IntVar(&pruneDays, "days", 0, "Keep this many days of summaries (overrides keepSummariesDays)")
+	cmd.Flags().IntVar(&pruneKeepArchives, "keep-archives", 0, "Keep this many archives per branch (overrides keepArchives)")
+	cmd.Flags().BoolVar(&pruneDelete, "delete", false, "Delete old summaries instead of archiving them (stale branches are still archived)")
+	cmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "List what would be pruned without removing anything")
+	return cmd
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	days, keepArchives := cfg.KeepSummariesDays, cfg.KeepArchives
+	if cmd.Flags().Changed("days") {
+		days = pruneDays
+	}
+	if cmd.Flags().Changed("keep-archives") {
+		keepArchives = pruneKeepArchives
+	}
+	if days < 0 || keepArchives < 0 {
+		return fmt.Errorf("--days and --keep-archives must be 0 or more")
+	}
+	if days == 0 && keepArchives == 0 {
+		return fmt.Errorf("nothing to prune: set keepSummariesDays or keepArchives, or pass --days or --keep-archives")
+	}
+
+	opts := plans.PruneOptions{KeepArchives: keepArchives, Branches: true, Delete: pruneDelete, DryRun: pruneDryRun}
+	if session, _ := config.LoadCurrentSession(); session != nil {
+		opts.Active = session.Branch
+	}
+	result, err := pruneArtifacts(days, opts)
+	if err != nil {
+		return err
+	}
+	setResult(result)
+
+	if result.Summaries == 0 && len(result.Archives) == 0 && result.CacheEntries == 0 {
+		i18n.Println("Nothing to prune")
+		return nil
+	}
+	if pruneDryRun {
+		i18n.Println("Would prune:")
+	} else {
+		i18n.Println("Pruned:")
+	}
+	printPruneResult(result)
+	return nil
+}
+
+// pruneArtifacts applies the retention policy to summaries older than
+// days, and drops cache entries unused for as many days
+func pruneArtifacts(days int, opts plans.PruneOptions) (*pruneResult, error) {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	opts.Before = plans.PruneBefore(clk.Now(), days)
+	pruned, err := planMgr.Prune(opts)
+	if pruned == nil {
+		pruned = &plans.PruneResult{}
+	}
+	result := &pruneResult{PruneResult: pruned, DryRun: opts.DryRun}
+	if err != nil {
+		return result, fmt.Errorf("failed to prune: %w", err)
+	}
+
+	if !opts.Before.IsZero() {
+		if dir, err := config.GetConfigDir(); err == nil {
+			if result.CacheEntries, err = api.PruneCache(filepath.Join(dir, api.CacheDir), opts.Before, opts.DryRun); err != nil {
+				i18n.Printf("Warning: could not prune the dashboard cache: %v\n", err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// printPruneResult lists what was pruned, with paths relative to the
+// working directory
+func printPruneResult(result *pruneResult) {
+	rel := func(path string) string {
+		if cwd, err := os.Getwd(); err == nil {
+			if r, err := filepath.Rel(cwd, path); err == nil {
+				return r
+			}
+		}
+		return path
+	}
+	if result.Summaries > 0 {
+		i18n.Printf("  %d summary file(s)\n", result.Summaries)
+	}
+	for _, branch := range result.Branches {
+		i18n.Printf("  Stale branch %s (plan, summaries, and handoff brief)\n", branch)
+	}
+	for _, path := range result.Archives {
+		i18n.Printf("  Archive %s\n", rel(path))
+	}
+	if result.CacheEntries > 0 {
+		i18n.Printf("  %d cached dashboard response(s)\n", result.CacheEntries)
+	}
+	for _, path := range result.Archived {
+		i18n.Printf("Archived to %s\n", rel(path))
+	}
+}
+
+// autoPrune runs the retention policy after 'done' on branch's session
+// when one is configured. It only archives old summaries and drops old
+// archives: whole branches are pruned by an explicit 'prune' alone, since
+// the session was already cleared and done shouldn't remove plans
+// unasked. Problems are warnings, so they never fail 'done'.
+func autoPrune(cfg *config.Config, branch string) {
+	if cfg.KeepSummariesDays == 0 && cfg.KeepArchives == 0 {
+		return
+	}
+	result, err := pruneArtifacts(cfg.KeepSummariesDays, plans.PruneOptions{KeepArchives: cfg.KeepArchives, Active: branch})
+	if err != nil {
+		i18n.Printf("Warning: %v\n", err)
+		return
+	}
+	if result.Summaries == 0 && len(result.Archives) == 0 && result.CacheEntries == 0 {
+		return
+	}
+	i18n.Println("\nPruned old local artifacts:")
+	printPruneResult(result)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var rebindFrom string
+
+// branchCheckExempt lists the commands that don't warn when the session's
+// branch no longer matches git: ones that set the branch themselves, and
+// ones that run inside other tools and must stay quiet
+var branchCheckExempt = map[string]bool{
+	"rebind":      true,
+	"start":       true,
+	"claude-hook": true,
+	"heartbeat":   true,
+	"config":      true,
+	"completion":  true,
+	"help":        true,
+}
+
+// rebindResult is what 'rebind --output json' reports
+type rebindResult struct {
+	From         string `json:"from"`
+	To           string `json:"to"`
+	WorkstreamID string `json:"workstreamId,omitempty"`
+}
+
+func newRebindCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebind [branch]",
+		Short: "Move the session to a renamed branch",
+		Long: `Moves the session, the plan, the summaries, the handoff brief, and the
+dashboard workstream over to a new branch name, after the WIP branch was
+renamed or rebased onto a new base. The new branch defaults to the one git
+is on now, and the old one to the session's branch.
+
+Example: mob-claude rebind
+Example: mob-claude rebind proj-124-login --from proj-123-login`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runRebind,
+	}
+	cmd.Flags().StringVar(&rebindFrom, "from", "", "Branch to move from (default: the session's branch)")
+	return cmd
+}
+
+func runRebind(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	from := rebindFrom
+	if from == "" {
+		if session == nil {
+			return fmt.Errorf("no active mob session. Use --from to name the old branch")
+		}
+		from = session.Branch
+	}
+	var to string
+	if len(args) > 0 {
+		to = args[0]
+	} else if to, err = mob.NewWrapper().GetBaseBranch(); err != nil {
+		return fmt.Errorf("failed to determine branch: %w", err)
+	}
+	if from == to {
+		i18n.Printf("The session is already on %s\n", to)
+		return nil
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	if err := planMgr.RenameBranch(from, to); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", from, to, err)
+	}
+	if err := config.UpdateFreshness(func(f *config.Freshness) {
+		if synced, ok := f.PlanSynced[from]; ok {
+			delete(f.PlanSynced, from)
+			f.PlanSynced[to] = synced
+		}
+	}); err != nil {
+		i18n.Printf("Warning: could not update sync times: %v\n", err)
+	}
+	i18n.Printf("Moved %s to %s\n", from, to)
+	if planMgr.PlanExists(to) {
+		i18n.Printf("Plan: %s\n", planMgr.GetPlanPath(to))
+	}
+
+	result := rebindResult{From: from, To: to}
+	if session != nil && session.Branch == from {
+		session.Branch = to
+		if cfg.TeamName != "" && cfg.APIURL != "" {
+			result.WorkstreamID = rebindWorkstream(cmd.Context(), cfg, planMgr, session, from)
+		}
+		if err := config.SaveCurrentSession(session); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+		i18n.Println("Session updated")
+	}
+	setResult(result)
+	return nil
+}
+
+// rebindWorkstream moves the dashboard workstream from the old branch to
+// the session's new one, registering the new branch if the dashboard had
+// none. If the dashboard can't be reached, registration is left for a later
+// command to retry. It returns the workstream's ID.
+func rebindWorkstream(ctx context.Context, cfg *config.Config, planMgr *plans.Manager, session *config.CurrentSession, from string) string {
+	client := newAPIClient(cfg)
+	workstream, err := client.RenameWorkstream(ctx, from, session.Branch)
+	if err == nil && workstream == nil {
+		if workstream, err = client.CreateWorkstream(ctx, session.RepoURL, session.Branch); err == nil {
+			if plan, _ := planMgr.LoadPlan(session.Branch); plan != "" {
+				if err := client.UpdatePlan(ctx, session.Branch, plan); err != nil {
+					i18n.Printf("Warning: could not upload plan: %v\n", err)
+				}
+			}
+		}
+	}
+	if err != nil {
+		i18n.Printf("Warning: could not update dashboard, will retry later: %v\n", err)
+		session.PendingRegistration = true
+		return ""
+	}
+
+	session.WorkstreamID = workstream.ID
+	session.PendingRegistration = false
+	recordPlanMetadata(planMgr, session.Branch, workstream.ID)
+	i18n.Printf("Dashboard workstream moved to %s\n", session.Branch)
+	return workstream.ID
+}
+
+// warnBranchMismatch warns when git is no longer on the branch the session
+// was started on, which happens when the WIP branch is renamed or rebased
+// onto a new base mid-session
+func warnBranchMismatch(cmd *cobra.Command) {
+	top := cmd
+	for top.HasParent() && top.Parent().HasParent() {
+		top = top.Parent()
+	}
+	if branchCheckExempt[top.Name()] {
+		return
+	}
+
+	session, _ := config.LoadCurrentSession()
+	if session == nil {
+		return
+	}
+	branch, err := mob.NewWrapper().GetBaseBranch()
+	if err != nil || branch == "" || branch == session.Branch {
+		return
+	}
+	i18n.Printf("Warning: the session is for branch %s, but git is on %s\n", session.Branch, branch)
+	i18n.Println("If the branch was renamed, run 'mob-claude rebind' to move the session over")
+}
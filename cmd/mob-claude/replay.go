@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+// Formats for 'replay --format'
+const (
+	replayText     = "text"
+	replayMarkdown = "markdown"
+)
+
+// replayMaxPause caps the pause between rotations with --speed, so a lunch
+// break or a night doesn't stall the replay
+const replayMaxPause = 10 * time.Second
+
+var (
+	replaySpeed  float64
+	replayFormat string
+	replayLocal  bool
+)
+
+func newReplayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <branch>",
+		Short: "Narrate a session rotation by rotation",
+		Long: `Walks through a workstream's rotations in order, printing each driver,
+how long they drove, the summary's TL;DR, and how the plan changed, so
+teammates who weren't there can catch up.
+
+Rotations come from the dashboard when one is configured, since it keeps a
+snapshot of the plan with each rotation. Otherwise, or with --local, they
+come from the local summaries, without plan changes.
+
+With --speed, rotations appear at that multiple of real time: at 600, an
+hour of mobbing plays in six seconds. Pauses are capped at 10s. --format
+markdown writes a document to share instead.
+
+Example: mob-claude replay feature-login --speed 600
+Example: mob-claude replay feature-login --format markdown > catch-up.md`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE:         runReplay,
+	}
+	cmd.Flags().Float64Var(&replaySpeed, "speed", 0, "Replay at this multiple of real time (0 prints everything at once)")
+	cmd.Flags().StringVar(&replayFormat, "format", replayText, "Output format: text or markdown")
+	cmd.Flags().BoolVar(&replayLocal, "local", false, "Replay from local summaries even with a dashboard")
+	return cmd
+}
+
+// replayStep is one rotation of a replayed session
+type replayStep struct {
+	Driver    string    `json:"driver"`
+	StartedAt time.Time `json:"startedAt"`
+	Duration  int       `json:"durationSeconds,omitempty"`
+	TLDR      string    `json:"tldr,omitempty"`
+
+	// PlanDiff is how the plan changed by the end of the rotation, as
+	// plans.DiffLines renders it
+	PlanDiff string `json:"planDiff,omitempty"`
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	if replayFormat != replayText && replayFormat != replayMarkdown {
+		return fmt.Errorf("unknown format %q (use text or markdown)", replayFormat)
+	}
+	if replaySpeed < 0 {
+		return fmt.Errorf("--speed must be 0 or more")
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	branch := args[0]
+	steps, withPlans, err := replaySteps(ctx, branch)
+	if err != nil {
+		return err
+	}
+	setResult(steps)
+	if len(steps) == 0 {
+		return fmt.Errorf("no rotations recorded for %s", branch)
+	}
+
+	if replayFormat == replayMarkdown {
+		fmt.Print(renderReplayMarkdown(branch, steps))
+		return nil
+	}
+
+	i18n.Printf("Replay of %s: %d rotations\n", branch, len(steps))
+	if !withPlans {
+		i18n.Println("Plan changes are only available from the dashboard")
+	}
+	for i, step := range steps {
+		if i > 0 && !replayPause(ctx, steps[i-1], step) {
+			return nil
+		}
+		fmt.Printf("\n[%d/%d] %s  %s", i+1, len(steps), step.StartedAt.Local().Format("2006-01-02 15:04"), step.Driver)
+		if step.Duration > 0 {
+			fmt.Printf(" (%s)", formatDuration(time.Duration(step.Duration)*time.Second))
+		}
+		fmt.Println()
+		if step.TLDR != "" {
+			fmt.Printf("  %s\n", step.TLDR)
+		}
+		if step.PlanDiff != "" {
+			i18n.Println("  Plan:")
+			for _, line := range strings.Split(strings.TrimRight(step.PlanDiff, "\n"), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+	return nil
+}
+
+// replaySteps gathers the branch's rotations, oldest first, from the
+// dashboard or else the local summaries. withPlans reports whether plan
+// changes could be included.
+func replaySteps(ctx context.Context, branch string) (steps []replayStep, withPlans bool, err error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load config: %w", err)
+	}
+	if !replayLocal && cfg.TeamName != "" && cfg.APIURL != "" {
+		rotations, err := allRotations(ctx, newAPIClient(cfg), branch)
+		if err == nil {
+			return rotationSteps(rotations), true, nil
+		}
+		if ctx.Err() != nil {
+			return nil, false, ctx.Err()
+		}
+		i18n.Printf("Warning: could not fetch rotations, replaying local summaries: %v\n", err)
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	summaries, err := planMgr.LoadBranchSummaries(branch)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load summaries: %w", err)
+	}
+	for _, s := range summaries {
+		started := s.StartedAt
+		if started.IsZero() {
+			started = s.Timestamp
+		}
+		steps = append(steps, replayStep{Driver: s.DriverName, StartedAt: started, Duration: s.Duration, TLDR: s.TLDR})
+	}
+	return steps, false, nil
+}
+
+// allRotations fetches every rotation of the branch from the dashboard,
+// oldest first
+func allRotations(ctx context.Context, client *api.Client, branch string) ([]api.Rotation, error) {
+	var rotations []api.Rotation
+	opts := api.ListOptions{}
+	for {
+		page, err := client.ListRotations(ctx, branch, opts)
+		if err != nil {
+			return nil, err
+		}
+		rotations = append(rotations, page.Rotations...)
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+	// Pages are newest first
+	for i, j := 0, len(rotations)-1; i < j; i, j = i+1, j-1 {
+		rotations[i], rotations[j] = rotations[j], rotations[i]
+	}
+	return rotations, nil
+}
+
+// rotationSteps turns dashboard rotations into replay steps, diffing each
+// plan snapshot against the one before it
+func rotationSteps(rotations []api.Rotation) []replayStep {
+	steps := make([]replayStep, 0, len(rotations))
+	plan := ""
+	for _, r := range rotations {
+		step := replayStep{Driver: r.DriverName, StartedAt: r.StartedAt, Duration: r.DurationSeconds, TLDR: r.SummaryTLDR}
+		if r.PlanSnapshot != "" {
+			step.PlanDiff = plans.DiffLines(plan, r.PlanSnapshot)
+			plan = r.PlanSnapshot
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// replayPause waits out the gap between two rotations at --speed. It
+// returns false if the replay was interrupted.
+func replayPause(ctx context.Context, prev, next replayStep) bool {
+	if replaySpeed == 0 {
+		return true
+	}
+	pause := time.Duration(float64(next.StartedAt.Sub(prev.StartedAt)) / replaySpeed)
+	if pause > replayMaxPause {
+		pause = replayMaxPause
+	}
+	if pause <= 0 {
+		return true
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(pause):
+		return true
+	}
+}
+
+// renderReplayMarkdown writes the replay as a markdown document
+func renderReplayMarkdown(branch string, steps []replayStep) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", i18n.Sprintf("Replay of %s", branch))
+	for i, step := range steps {
+		fmt.Fprintf(&b, "\n## %d. %s, %s", i+1, step.Driver, step.StartedAt.Local().Format("2006-01-02 15:04"))
+		if step.Duration > 0 {
+			fmt.Fprintf(&b, " (%s)", formatDuration(time.Duration(step.Duration)*time.Second))
+		}
+		b.WriteString("\n")
+		if step.TLDR != "" {
+			fmt.Fprintf(&b, "\n%s\n", step.TLDR)
+		}
+		if step.PlanDiff != "" {
+			fmt.Fprintf(&b, "\n%s\n\n```diff\n%s\n```\n", i18n.T("Plan changes:"), strings.TrimRight(step.PlanDiff, "\n"))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/schedule"
+	"github.com/spf13/cobra"
+)
+
+// Where a schedule came from
+const (
+	scheduleFromConfig    = "config"
+	scheduleFromDashboard = "dashboard"
+)
+
+func newScheduleCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schedule",
+		Short: "Show the team's mob schedule",
+		Long: `Lists the team's weekly mob blocks and which one is on now or next. The
+schedule comes from the 'schedule' list in config, or from the dashboard
+when config has none.
+
+'mob-claude start' warns when a session starts outside every block, and a
+session started during a block takes its rotation timer and roster from it
+(a --preset's settings win).`,
+		Args: cobra.NoArgs,
+		RunE: runSchedule,
+	}
+}
+
+// scheduleResult is the result of schedule
+type scheduleResult struct {
+	Source  string                 `json:"source,omitempty"`
+	Blocks  []config.ScheduleBlock `json:"blocks"`
+	Current *scheduleWindow        `json:"current,omitempty"`
+	Next    *scheduleWindow        `json:"next,omitempty"`
+}
+
+// scheduleWindow is one occurrence of a block in JSON output
+type scheduleWindow struct {
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func newScheduleWindow(w *schedule.Window) *scheduleWindow {
+	if w == nil {
+		return nil
+	}
+	return &scheduleWindow{Name: w.Name(), Start: w.Start.Format(time.RFC3339), End: w.End.Format(time.RFC3339)}
+}
+
+func runSchedule(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	dashboard := cfg.TeamName != "" && cfg.APIURL != ""
+	blocks, source, err := loadSchedule(cmd.Context(), cfg, dashboard)
+	if err != nil {
+		return err
+	}
+
+	now := clk.Now()
+	current, next := schedule.Current(blocks, now), schedule.Next(blocks, now)
+	setResult(scheduleResult{Source: source, Blocks: blocks, Current: newScheduleWindow(current), Next: newScheduleWindow(next)})
+	if len(blocks) == 0 {
+		i18n.Println("No mob schedule. Add blocks to 'schedule' in config, or set one up on the dashboard")
+		return nil
+	}
+
+	i18n.Printf("Mob schedule (from %s):\n\n", i18n.T(source))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tDAYS\tTIME\tTIMER\tROSTER")
+	for _, block := range blocks {
+		name := block.Name
+		if name == "" {
+			name = "-"
+		}
+		days := strings.Join(block.Days, ", ")
+		if days == "" {
+			days = i18n.T("every day")
+		}
+		timerLen := "-"
+		if block.RotationMinutes > 0 {
+			timerLen = fmt.Sprintf("%dm", block.RotationMinutes)
+		}
+		roster := strings.Join(block.Roster, ", ")
+		if roster == "" {
+			roster = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s-%s\t%s\t%s\n", name, days, block.Start, block.End, timerLen, roster)
+	}
+	w.Flush()
+
+	fmt.Println()
+	if current != nil {
+		i18n.Printf("Now: %s, until %s\n", current.Name(), current.End.Format("15:04"))
+	}
+	if next != nil {
+		i18n.Printf("Next: %s, %s\n", next.Name(), next.Start.Format("Mon 15:04"))
+	}
+	return nil
+}
+
+// loadSchedule returns the team's schedule from config, or else from the
+// dashboard if useDashboard is set, and where it came from
+func loadSchedule(ctx context.Context, cfg *config.Config, useDashboard bool) ([]config.ScheduleBlock, string, error) {
+	if len(cfg.Schedule) > 0 {
+		return cfg.Schedule, scheduleFromConfig, nil
+	}
+	if !useDashboard {
+		return nil, "", nil
+	}
+	remote, err := newAPIClient(cfg).GetSchedule(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	blocks := make([]config.ScheduleBlock, len(remote))
+	for i, b := range remote {
+		blocks[i] = config.ScheduleBlock(b)
+	}
+	return blocks, scheduleFromDashboard, nil
+}
+
+// scheduledBlock returns the schedule block a session starting now falls
+// in. It warns when the team has a schedule and now is outside it, and
+// returns nil then or when there's no schedule.
+func scheduledBlock(ctx context.Context, cfg *config.Config, dashboard bool) *schedule.Window {
+	blocks, _, err := loadSchedule(ctx, cfg, dashboard)
+	if err != nil {
+		i18n.Printf("Warning: could not fetch schedule: %v\n", err)
+		return nil
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	now := clk.Now()
+	if current := schedule.Current(blocks, now); current != nil {
+		return current
+	}
+	if next := schedule.Next(blocks, now); next != nil {
+		i18n.Printf("Warning: starting outside the team's mob schedule (next block: %s, %s)\n", next.Name(), next.Start.Format("Mon 15:04"))
+	} else {
+		i18n.Println("Warning: starting outside the team's mob schedule")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var searchBranch string
+
+// searchResult is a match along with the plan commit that added it, for
+// decisions made before any recorded rotation
+type searchResult struct {
+	plans.SearchMatch
+	Commit *mob.CommitInfo `json:"commit,omitempty"`
+}
+
+func newSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search decisions and rotation summaries",
+		Long: `Searches the Decisions Made section of every plan and every rotation summary
+(TL;DR, changes, next steps, and driver notes) for entries containing all
+words of the query, ignoring case.
+
+Each match shows the driver and rotation that introduced it. For a summary
+entry that's the rotation it was recorded in; for a decision, it's the
+rotation under way when the plan commit that added it was made.
+
+Example: mob-claude search "oauth"
+Example: mob-claude search "retry backoff" --branch feature-sync`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSearch,
+	}
+	cmd.Flags().StringVar(&searchBranch, "branch", "", "Only search this branch")
+	return cmd
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	query := args[0]
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("search query is empty")
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	files, err := planMgr.ListSummaries()
+	if err != nil {
+		return fmt.Errorf("failed to list summaries: %w", err)
+	}
+
+	// Summaries per branch, oldest first, for attributing decisions
+	byBranch := make(map[string][]*plans.Summary)
+	var matches []searchResult
+	for _, file := range files {
+		s, err := planMgr.LoadSummary(file)
+		if err != nil || (searchBranch != "" && s.Branch != searchBranch) {
+			continue
+		}
+		byBranch[s.Branch] = append(byBranch[s.Branch], s)
+		for _, m := range plans.SearchSummary(s, query) {
+			matches = append(matches, searchResult{SearchMatch: m})
+		}
+	}
+
+	localPlans, err := planMgr.ListPlans()
+	if err != nil {
+		return fmt.Errorf("failed to list plans: %w", err)
+	}
+	mobWrapper := mob.NewWrapper()
+	var decisions []searchResult
+	for _, p := range localPlans {
+		if searchBranch != "" && p.Branch != searchBranch {
+			continue
+		}
+		plan, err := planMgr.LoadPlan(p.Branch)
+		if err != nil {
+			continue
+		}
+		for _, decision := range plans.Decisions(plan) {
+			if !plans.MatchesQuery(decision, query) {
+				continue
+			}
+			match := searchResult{SearchMatch: plans.SearchMatch{Branch: p.Branch, Source: plans.SourceDecision, Text: decision}}
+			if commit, err := mobWrapper.FirstCommitAdding(p.Path, decision); err == nil && commit != nil {
+				if match.Summary = plans.RotationAt(byBranch[p.Branch], commit.Time); match.Summary == nil {
+					match.Commit = commit
+				}
+			}
+			decisions = append(decisions, match)
+		}
+	}
+	matches = append(decisions, matches...)
+	setResult(matches)
+
+	if len(matches) == 0 {
+		i18n.Printf("No decisions or summaries match %q\n", query)
+		return nil
+	}
+	for _, m := range matches {
+		fmt.Printf("[%s] %s: %s\n", i18n.T(m.Source), m.Branch, m.Text)
+		switch {
+		case m.Summary != nil:
+			started := m.Summary.Timestamp
+			if !m.Summary.StartedAt.IsZero() {
+				started = m.Summary.StartedAt
+			}
+			i18n.Printf("    introduced by %s in the rotation started %s\n", m.Summary.DriverName, started.Format("2006-01-02 15:04"))
+		case m.Commit != nil:
+			// Added before any recorded rotation, so credit the commit's author
+			i18n.Printf("    introduced by %s on %s (commit %s)\n", m.Commit.Author, m.Commit.Time.Format("2006-01-02 15:04"), m.Commit.Hash)
+		default:
+			i18n.Println("    introduced by an unknown rotation")
+		}
+	}
+	return nil
+}
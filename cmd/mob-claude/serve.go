@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/daemon"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	servePort         int
+	serveBind         string
+	serveAllowOrigins []string
+	serveAllowHosts   []string
+)
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the mob's status read-only over HTTP",
+		Long: `Serves the current mob state as JSON so editor extensions, stream overlays,
+and office displays can poll it without shelling out to the CLI.
+
+  GET /status      Current session, task progress, and timer
+  GET /plan        The plan, its front-matter fields, and its tasks
+  GET /summaries   Rotation summaries, newest first (?limit=N, default 20)
+  GET /timer       Current rotation timer
+
+/plan and /summaries default to the current branch; pass ?branch=<name> for
+another one. Nothing can be changed through the server, but the plan and
+summaries are served decrypted, so it's kept to this machine by default: it
+listens on 127.0.0.1 and needs no token there. Bound to any other address
+with --bind, such as 0.0.0.0 for a display on another machine, every request
+must carry the daemon's pairing token, as "Authorization: Bearer <token>" or
+a "token" query parameter ('mob-claude daemon --pair' replaces it).
+
+Requests must address the server by a loopback address, localhost, the
+bound address, or a name allowed with --allow-host, so a web page can't
+reach it through a DNS name pointed at 127.0.0.1. Web pages can't read the
+responses unless their origin is allowed with --allow-origin; browser-based
+overlays need their origin listed.
+
+Example: mob-claude serve --allow-origin http://localhost:3000
+Example: mob-claude serve --bind 0.0.0.0 --allow-host office-pc.local`,
+		Args: cobra.NoArgs,
+		RunE: runServe,
+	}
+	cmd.Flags().IntVar(&servePort, "port", daemon.DefaultStatusPort, "Port to listen on")
+	cmd.Flags().StringVar(&serveBind, "bind", "127.0.0.1", "Address to listen on")
+	cmd.Flags().StringSliceVar(&serveAllowOrigins, "allow-origin", nil, "Web origin allowed to read responses, e.g. http://localhost:3000 (repeatable)")
+	cmd.Flags().StringSliceVar(&serveAllowHosts, "allow-host", nil, "Host name requests may address the server by, besides its addresses (repeatable)")
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	server := &daemon.StatusServer{
+		Status:    serveSessionStatus,
+		Plan:      servePlan,
+		Summaries: serveSummaries,
+		Timer:     daemonTimerStatus,
+
+		AllowOrigins: serveAllowOrigins,
+		Hosts:        serveHosts(serveBind, serveAllowHosts),
+	}
+
+	if !isLoopback(serveBind) {
+		token, created, err := config.PairingToken(idGen.NewID, false)
+		if err != nil {
+			return fmt.Errorf("failed to load pairing token: %w", err)
+		}
+		server.Token = token
+		if created {
+			i18n.Printf("Pairing token: %s\n", token)
+		} else {
+			i18n.Println("Requests need the daemon's pairing token ('mob-claude daemon --pair' prints a new one)")
+		}
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(serveBind, fmt.Sprint(servePort)))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", servePort, err)
+	}
+	i18n.Printf("Serving status on http://%s\n", listener.Addr())
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	return server.Serve(ctx, listener)
+}
+
+// isLoopback reports whether a --bind address only accepts connections
+// from this machine
+func isLoopback(bind string) bool {
+	if strings.EqualFold(bind, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(bind)
+	return ip != nil && ip.IsLoopback()
+}
+
+// serveHosts returns the host names requests may use besides loopback ones:
+// the bound address, or every address of this machine and its host name
+// when bound to all of them, and the --allow-host names
+func serveHosts(bind string, allowed []string) []string {
+	hosts := append([]string{bind}, allowed...)
+	if ip := net.ParseIP(bind); ip == nil || !ip.IsUnspecified() {
+		return hosts
+	}
+	if name, err := os.Hostname(); err == nil {
+		hosts = append(hosts, name)
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok {
+				hosts = append(hosts, ipnet.IP.String())
+			}
+		}
+	}
+	return hosts
+}
+
+// serveBranch returns the branch to report on: the requested one, else the
+// session's, else the current git branch
+func serveBranch(requested string) string {
+	if requested != "" {
+		return requested
+	}
+	if session, _ := config.LoadCurrentSession(); session != nil {
+		return session.Branch
+	}
+	branch, _ := mob.NewWrapper().GetBaseBranch()
+	return branch
+}
+
+// serveSessionStatus reports the current session along with the plan's
+// task progress
+func serveSessionStatus() (*daemon.SessionStatus, error) {
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return &daemon.SessionStatus{}, nil
+	}
+
+	status := &daemon.SessionStatus{
+		Active:  true,
+		Branch:  session.Branch,
+		Driver:  session.DriverName,
+		Roster:  session.Roster,
+		Offline: session.Offline,
+	}
+	if startedAt, err := time.Parse(time.RFC3339, session.StartedAt); err == nil {
+		status.StartedAt = &startedAt
+	}
+	if status.Timer, err = daemonTimerStatus(); err != nil {
+		return nil, err
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	plan, err := planMgr.LoadPlan(session.Branch)
+	if err != nil {
+		return nil, err
+	}
+	tasks := plans.ParseTasks(plan)
+	status.TasksTotal = len(tasks)
+	for _, task := range tasks {
+		if task.Done {
+			status.TasksDone++
+		}
+	}
+	if next := plans.NextUnblockedTask(tasks); next != nil {
+		task := daemon.NewPlanTask(*next)
+		status.NextTask = &task
+	}
+	return status, nil
+}
+
+// servePlan reports a branch's plan
+func servePlan(branch string) (*daemon.PlanStatus, error) {
+	branch = serveBranch(branch)
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	plan, err := planMgr.LoadPlan(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	fm, body, _ := plans.ParseFrontMatter(plan)
+	status := &daemon.PlanStatus{
+		Branch:       branch,
+		WorkstreamID: fm.WorkstreamID,
+		Ticket:       fm.Ticket,
+		Owner:        fm.Owner,
+		Markdown:     body,
+		Fields:       fm.Fields,
+		Tasks:        []daemon.PlanTask{},
+	}
+	for _, task := range plans.ParseTasks(plan) {
+		status.Tasks = append(status.Tasks, daemon.NewPlanTask(task))
+	}
+	return status, nil
+}
+
+// serveSummaries reports a branch's latest summaries, newest first
+func serveSummaries(branch string, limit int) (*daemon.SummaryList, error) {
+	branch = serveBranch(branch)
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	summaries, err := planMgr.LoadBranchSummaries(branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load summaries: %w", err)
+	}
+
+	list := &daemon.SummaryList{Branch: branch, Summaries: []*plans.Summary{}}
+	for i := len(summaries) - 1; i >= 0 && len(list.Summaries) < limit; i-- {
+		list.Summaries = append(list.Summaries, summaries[i])
+	}
+	return list, nil
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/events"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sessionTemplatePreset   string
+	sessionTemplatePlan     string
+	sessionTemplateFromPlan bool
+	sessionTemplateRoster   []string
+)
+
+func newSessionCmd() *cobra.Command {
+	sessionCmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage reusable session setups",
+	}
+
+	templateCmd := &cobra.Command{
+		Use:   "template",
+		Short: "Save and apply session templates for recurring mob formats",
+		Long: `A session template bundles what a recurring mob format needs, such as a bug
+triage or onboarding mob: the rotation length, auto-next and timer alert
+settings, the roster, a plan template with its checklists, and the
+notification channels. Templates are saved as single files in
+.claude/mob/session-templates, so they can be committed and shared.
+
+'save' captures the project's current settings, overlaid with --preset if
+given, and the session's roster. The plan comes from --plan-template, or
+from the branch's plan with --from-plan, with its tasks unchecked.
+
+'apply' sets the template up in this project: it becomes a preset of the
+same name, its plan a plan template of the same name, and its notification
+channels the preset's, which replace the configured ones for its sessions.
+Templates never hold the channels' URLs: each one's is taken from the
+secret store (notify.<name>) if it's already there, or asked for. Start a
+session with it using 'mob-claude start --preset <name>'.
+
+Example: mob-claude session template save triage --plan-template bugfix
+Example: mob-claude session template apply triage`,
+	}
+
+	saveCmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save the current setup as a session template",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSessionTemplateSave,
+	}
+	saveCmd.Flags().StringVar(&sessionTemplatePreset, "preset", "", "Capture this preset's settings over config's")
+	saveCmd.Flags().StringVar(&sessionTemplatePlan, "plan-template", "", "Plan template to bundle")
+	saveCmd.Flags().BoolVar(&sessionTemplateFromPlan, "from-plan", false, "Bundle the branch's plan, with its tasks unchecked, as the plan template")
+	saveCmd.Flags().StringSliceVar(&sessionTemplateRoster, "roster", nil, "Comma-separated roster (default: the preset's or the session's)")
+
+	applyCmd := &cobra.Command{
+		Use:   "apply <name>",
+		Short: "Set up a session template as a preset in this project",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSessionTemplateApply,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List session templates",
+		Args:  cobra.NoArgs,
+		RunE:  runSessionTemplateList,
+	}
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Delete a session template",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSessionTemplateRemove,
+	}
+
+	templateCmd.AddCommand(saveCmd, applyCmd, listCmd, removeCmd)
+	sessionCmd.AddCommand(templateCmd)
+	return sessionCmd
+}
+
+func runSessionTemplateSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if sessionTemplatePlan != "" && sessionTemplateFromPlan {
+		return fmt.Errorf("use either --plan-template or --from-plan")
+	}
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	var preset config.Preset
+	if sessionTemplatePreset != "" {
+		var ok bool
+		if preset, ok = cfg.Presets[sessionTemplatePreset]; !ok {
+			return fmt.Errorf("unknown preset: %s", sessionTemplatePreset)
+		}
+		preset.Apply(cfg)
+	}
+	autoNext := cfg.AutoNext
+	t := &config.SessionTemplate{
+		RotationMinutes: cfg.RotationMinutes,
+		AutoNext:        &autoNext,
+		TimerAlert:      cfg.TimerAlert,
+		Roster:          sessionTemplateRoster,
+	}
+	// Only the channels' names and events: their URLs are secrets, and
+	// each project looks them up or asks for them on apply
+	for _, n := range cfg.Notifications {
+		t.Notifications = append(t.Notifications, config.Notification{Channel: n.Channel, Secret: n.Secret, Events: n.Events})
+	}
+	if len(t.Roster) == 0 {
+		t.Roster = preset.Roster
+	}
+	if len(t.Roster) == 0 {
+		if session, _ := config.LoadCurrentSession(); session != nil {
+			t.Roster = session.Roster
+		}
+	}
+
+	switch {
+	case sessionTemplateFromPlan:
+		branch, err := currentPlanBranch()
+		if err != nil {
+			return err
+		}
+		plan, err := planMgr.LoadPlan(branch)
+		if err != nil {
+			return err
+		}
+		if plan == "" {
+			return fmt.Errorf("no plan for %s", branch)
+		}
+		t.Plan = plans.TemplateFromPlan(plan, branch)
+	case sessionTemplatePlan != "" || preset.Template != "":
+		template := sessionTemplatePlan
+		if template == "" {
+			template = preset.Template
+		}
+		if t.Plan, err = planMgr.LoadTemplate(template); err != nil {
+			return err
+		}
+		if t.Plan == "" {
+			return fmt.Errorf("no plan template named %s in %s", template, plans.TemplatesDir)
+		}
+	}
+
+	if err := config.SaveSessionTemplate(name, t); err != nil {
+		return fmt.Errorf("failed to save session template: %w", err)
+	}
+	i18n.Printf("Session template %s saved\n", name)
+	printSessionTemplate(t)
+	return nil
+}
+
+func runSessionTemplateApply(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	t, err := config.LoadSessionTemplate(name)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("no session template named %s", name)
+	}
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// The template's channels route the preset's sessions, leaving the
+	// configured notifications as they are for other sessions
+	var routes []config.Notification
+	links := make(map[string]string)
+	for _, n := range t.Notifications {
+		name := notificationName(n)
+		i := slices.IndexFunc(cfg.Notifications, func(c config.Notification) bool { return notificationName(c) == name })
+		if i >= 0 && cfg.Notifications[i].Channel != n.Channel {
+			return fmt.Errorf("the template's %s notification channel %s is a %s channel here", n.Channel, name, cfg.Notifications[i].Channel)
+		}
+		added, link, err := templateNotification(n)
+		if err != nil {
+			return err
+		}
+		if added == nil {
+			i18n.Printf("Skipped the %s notification channel %s: no URL. Add it with 'mob-claude notify add %s --name %s --url <url>'\n", n.Channel, name, n.Channel, name)
+			continue
+		}
+		if link != "" {
+			links[added.Secret] = link
+		}
+		routes = append(routes, *added)
+	}
+	preset := t.Preset(name)
+	preset.Notifications = routes
+	if cfg.Presets == nil {
+		cfg.Presets = make(map[string]config.Preset)
+	}
+	cfg.Presets[name] = preset
+	for _, p := range config.Validate(cfg) {
+		if strings.HasPrefix(p.Key, "presets."+name+".") {
+			return fmt.Errorf("invalid session template: %s %s", p.Key, p.Message)
+		}
+	}
+
+	if t.Plan != "" {
+		planMgr, err := newPlanManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize plan manager: %w", err)
+		}
+		if err := planMgr.SaveTemplate(name, t.Plan); err != nil {
+			return err
+		}
+	}
+	for key, link := range links {
+		if err := config.SetSecret(key, link); err != nil {
+			return fmt.Errorf("failed to store the URL of %s: %w", key, err)
+		}
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	i18n.Printf("Session template %s applied\n", name)
+	printSessionTemplate(t)
+	i18n.Printf("\nStart a session with it: mob-claude start --preset %s\n", name)
+	return nil
+}
+
+// templateNotification returns a template's notification channel as it's
+// used in this project, and the URL to store for it, if any. A channel
+// that posts to a URL uses the one already in the secret store under its
+// name, or asks for it; nil means there's none to use. Templates saved
+// before URLs became secrets may still carry one, which is used and moved
+// to the secret store.
+func templateNotification(n config.Notification) (*config.Notification, string, error) {
+	link := n.URL
+	n.URL = ""
+	if n.Secret == "" && (link != "" || events.NeedsURL(n.Channel)) {
+		n.Secret = config.NotifySecretPrefix + n.Channel
+	}
+	if n.Secret == "" {
+		return &n, "", nil
+	}
+	if !config.IsNotifySecret(n.Secret) {
+		return nil, "", fmt.Errorf("invalid session template: notification secret %q is not a %s<name> key", n.Secret, config.NotifySecretPrefix)
+	}
+	if link == "" {
+		if stored, err := config.GetSecret(n.Secret); err == nil && stored != "" {
+			return &n, "", nil
+		}
+		link = ask(i18n.Sprintf("URL for the %s notification channel %s (empty to skip)", n.Channel, notificationName(n)), "")
+	}
+	if link == "" {
+		return nil, "", nil
+	}
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, "", fmt.Errorf("the URL for %s must be an absolute http(s) URL", notificationName(n))
+	}
+	return &n, link, nil
+}
+
+func runSessionTemplateList(cmd *cobra.Command, args []string) error {
+	names, err := config.SessionTemplateNames()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		i18n.Println("No session templates. Save one with 'mob-claude session template save <name>'")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTIMER\tROSTER\tPLAN\tNOTIFICATIONS")
+	for _, name := range names {
+		t, err := config.LoadSessionTemplate(name)
+		if err != nil {
+			i18n.Printf("Warning: %v\n", err)
+			continue
+		}
+		timerLen := "-"
+		if t.RotationMinutes > 0 {
+			timerLen = fmt.Sprintf("%dm", t.RotationMinutes)
+		}
+		roster := strings.Join(t.Roster, ", ")
+		if roster == "" {
+			roster = "-"
+		}
+		plan := "-"
+		if t.Plan != "" {
+			plan = i18n.Sprintf("%d task(s)", len(plans.ParseTasks(t.Plan)))
+		}
+		var channels []string
+		for _, n := range t.Notifications {
+			channels = append(channels, n.Channel)
+		}
+		notifications := strings.Join(channels, ", ")
+		if notifications == "" {
+			notifications = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, timerLen, roster, plan, notifications)
+	}
+	return w.Flush()
+}
+
+func runSessionTemplateRemove(cmd *cobra.Command, args []string) error {
+	if err := config.RemoveSessionTemplate(args[0]); err != nil {
+		return err
+	}
+	i18n.Printf("Session template %s removed\n", args[0])
+	return nil
+}
+
+// printSessionTemplate lists what a session template sets up
+func printSessionTemplate(t *config.SessionTemplate) {
+	if t.RotationMinutes > 0 {
+		i18n.Printf("  Timer: %d minutes\n", t.RotationMinutes)
+	}
+	if len(t.Roster) > 0 {
+		i18n.Printf("  Roster: %s\n", strings.Join(t.Roster, ", "))
+	}
+	if t.Plan != "" {
+		i18n.Printf("  Plan template: %d task(s)\n", len(plans.ParseTasks(t.Plan)))
+	}
+	if len(t.Notifications) > 0 {
+		i18n.Printf("  Notifications: %d channel(s)\n", len(t.Notifications))
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/summary"
+)
+
+// squashCommitMessage drafts the commit message for the squashed session
+// from every rotation summary recorded for branch. It returns "" if there
+// are none.
+func squashCommitMessage(cfg *config.Config, branch string) string {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return ""
+	}
+	summaries, err := planMgr.LoadBranchSummaries(branch)
+	if err != nil || len(summaries) == 0 {
+		return ""
+	}
+
+	if skipSummary || cfg.SkipSummary {
+		return summary.DefaultCommitMessage(branch, summaries)
+	}
+	i18n.Println("Drafting the squash commit message...")
+	return newGenerator(cfg).CommitMessage(branch, summaries)
+}
+
+// commitSquash commits the changes 'mob done' staged with the drafted
+// message, once the user agrees. Otherwise the message is left in
+// .git/SQUASH_MSG for their own 'git commit'.
+func commitSquash(mobWrapper *mob.Wrapper, message string) {
+	if message == "" {
+		return
+	}
+	if staged, err := mobWrapper.HasStagedChanges(); err != nil || !staged {
+		return
+	}
+
+	i18n.Println("\n=== Squash commit message ===")
+	fmt.Println(message)
+	fmt.Println()
+
+	if isInteractive() && confirm(i18n.T("Commit the squashed changes with this message?"), true) {
+		if err := mobWrapper.Commit(message); err != nil {
+			i18n.Printf("Warning: %v\n", err)
+		}
+		return
+	}
+
+	if err := mobWrapper.SetSquashMessage(message); err != nil {
+		i18n.Printf("Warning: could not save the commit message: %v\n", err)
+		return
+	}
+	i18n.Println("Saved to .git/SQUASH_MSG; 'git commit' will offer it as the message")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"golang.org/x/sync/errgroup"
+)
+
+// startRemote is what start learned from the dashboard: the branch's plan
+// ("" if there is none or it wasn't fetched) and its registered workstream
+type startRemote struct {
+	plan    string
+	planErr error
+	ws      *api.Workstream
+	wsErr   error
+}
+
+// fetchStartRemote fetches the branch's plan and registers its workstream
+// concurrently, while the outbox is flushed alongside them. A plan queued
+// in the outbox is newer than the dashboard's, so it isn't fetched at all
+// and the local copy stays in use.
+func fetchStartRemote(ctx context.Context, cfg *config.Config, repoURL, branch string) *startRemote {
+	remote := &startRemote{}
+	client := newAPIClient(cfg)
+	queued := false
+	if box, err := newOutbox(); err == nil {
+		queued, _ = box.HasPlan(branch)
+	}
+
+	var g errgroup.Group
+	g.Go(func() error {
+		flushOutbox(ctx, cfg)
+		return nil
+	})
+	if !queued {
+		g.Go(func() error {
+			remote.plan, remote.planErr = client.GetPlan(ctx, branch)
+			return nil
+		})
+	}
+	g.Go(func() error {
+		remote.ws, remote.wsErr = client.CreateWorkstream(ctx, repoURL, branch)
+		return nil
+	})
+	_ = g.Wait()
+	return remote
+}
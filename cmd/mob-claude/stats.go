@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsAll  bool
+	statsTags []string
+)
+
+func newStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show how mob time is spent, by rotation tag",
+		Long: `Totals the rotations in local summaries for the current branch, and their
+time at the keyboard, by the tags drivers gave them with 'next --tags'.
+Rotations without tags are counted as untagged. A rotation with several
+tags counts toward each of them.
+
+With --tag, only rotations with one of the given tags are counted, and the
+time is also broken down by driver.
+
+Example: mob-claude stats --all
+Example: mob-claude stats --tag refactor`,
+		Args: cobra.NoArgs,
+		RunE: runStats,
+	}
+	cmd.Flags().BoolVar(&statsAll, "all", false, "Include rotations from every branch")
+	cmd.Flags().StringSliceVar(&statsTags, "tag", nil, "Only count rotations with one of these tags (comma-separated or repeated)")
+	return cmd
+}
+
+// statsResult is the result of stats
+type statsResult struct {
+	Rotations int                `json:"rotations"`
+	Seconds   int                `json:"seconds"`
+	Tags      []plans.TagTime    `json:"tags"`
+	Drivers   []plans.DriverTime `json:"drivers,omitempty"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+
+	var files []string
+	if statsAll {
+		files, err = planMgr.ListSummaries()
+	} else {
+		var branch string
+		if branch, err = currentPlanBranch(); err != nil {
+			return err
+		}
+		files, err = planMgr.ListBranchSummaries(branch)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list summaries: %w", err)
+	}
+
+	var summaries []*plans.Summary
+	for _, file := range files {
+		s, err := planMgr.LoadSummary(file)
+		if err != nil {
+			i18n.Printf("Warning: skipping %v\n", err)
+			continue
+		}
+		if plans.HasAnyTag(s.Tags, statsTags) {
+			summaries = append(summaries, s)
+		}
+	}
+
+	report := plans.TagStats(summaries)
+	result := &statsResult{Rotations: report.Rotations, Seconds: int(report.Total.Seconds()), Tags: report.Tags}
+	if len(statsTags) > 0 {
+		result.Drivers = plans.Fairness(summaries, 0).Drivers
+	}
+	setResult(result)
+	if report.Rotations == 0 {
+		i18n.Println("No rotations recorded yet")
+		return nil
+	}
+
+	i18n.Printf("%d rotation(s), %s at the keyboard\n\n", report.Rotations, formatDuration(report.Total))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TAG\tROTATIONS\tTIME\tSHARE")
+	for _, t := range report.Tags {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%.0f%%\n", t.Name, t.Rotations, formatDuration(t.Time), t.Share*100)
+	}
+	w.Flush()
+
+	if len(result.Drivers) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DRIVER\tROTATIONS\tTIME\tSHARE")
+		for _, d := range result.Drivers {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%.0f%%\n", d.Name, d.Rotations, formatDuration(d.Time), d.Share*100)
+		}
+		w.Flush()
+	}
+
+	if report.Total == 0 {
+		i18n.Println("\nNo rotation durations were recorded, so only rotation counts are shown")
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/objectstore"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	storagePushDryRun bool
+	storagePullForce  bool
+)
+
+func newStorageCmd() *cobra.Command {
+	storageCmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Copy plans and summaries to a central bucket",
+		Long: `With 'storage' set to an s3://bucket/prefix or gs://bucket/prefix URL,
+every plan and summary mob-claude saves is also copied to the bucket, so a
+platform team can keep mob artifacts centrally without running the
+dashboard. Credentials come from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+(an HMAC key for GCS).
+
+Example: mob-claude config set storage s3://acme-mob/payments-api`,
+	}
+
+	pushCmd := &cobra.Command{
+		Use:   "push",
+		Short: "Copy local plans and summaries the bucket doesn't have",
+		Long: `Uploads every local plan that differs from the bucket's copy and every
+summary missing from it. Useful after setting 'storage' on a project with
+history, or after copies failed while offline.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runStoragePush,
+	}
+	pushCmd.Flags().BoolVar(&storagePushDryRun, "dry-run", false, "Show what would be copied without copying it")
+
+	pullCmd := &cobra.Command{
+		Use:   "pull [branch]",
+		Short: "Fetch a branch's plan from the bucket",
+		Long: `Writes the bucket's copy of the branch's plan (the current session's,
+by default) to .claude/plans. An existing local plan is kept unless --force
+is given.`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runStoragePull,
+	}
+	pullCmd.Flags().BoolVar(&storagePullForce, "force", false, "Replace the local plan")
+
+	storageCmd.AddCommand(pushCmd, pullCmd)
+	return storageCmd
+}
+
+// newRemoteStorage opens the bucket configured as 'storage'
+func newRemoteStorage(cfg *config.Config) (*objectstore.Store, error) {
+	region := cfg.StorageRegion
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	return objectstore.New(cfg.Storage, objectstore.Options{
+		Endpoint: cfg.StorageEndpoint,
+		Region:   region,
+		Credentials: objectstore.Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+	})
+}
+
+// openStorage opens the configured bucket once per run, so a bad setup is
+// reported once
+var openStorage = sync.OnceValue(func() *objectstore.Store {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	store, err := newRemoteStorage(cfg)
+	if err != nil {
+		i18n.Printf("Warning: could not open storage: %v\n", err)
+		return nil
+	}
+	return store
+})
+
+// setRemoteStorage has the plan manager copy what it saves to the
+// configured bucket. Failed copies only warn; 'storage push' catches up.
+func setRemoteStorage(planMgr *plans.Manager) {
+	store := openStorage()
+	if store == nil {
+		return
+	}
+	planMgr.SetRemote(store, func(err error) {
+		i18n.Printf("Warning: could not copy to storage: %v\n", err)
+	})
+}
+
+// remotePlanManager returns a plan manager with the configured bucket
+func remotePlanManager() (*plans.Manager, plans.Storage, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Storage == "" {
+		return nil, nil, fmt.Errorf("storage not configured. Set it with 'mob-claude config set storage s3://bucket/prefix'")
+	}
+	store, err := newRemoteStorage(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	planMgr, err := plans.NewManager()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	planMgr.SetClock(clk)
+	if err := setEncryptionKey(planMgr, cfg); err != nil {
+		return nil, nil, err
+	}
+	return planMgr, store, nil
+}
+
+func runStoragePush(cmd *cobra.Command, args []string) error {
+	planMgr, remote, err := remotePlanManager()
+	if err != nil {
+		return err
+	}
+
+	localPlans, err := planMgr.ListPlans()
+	if err != nil {
+		return fmt.Errorf("failed to list plans: %w", err)
+	}
+	plansCopied := 0
+	for _, local := range localPlans {
+		content, err := planMgr.LoadPlan(local.Branch)
+		if err != nil {
+			return err
+		}
+		stored, err := remote.LoadPlan(local.Branch)
+		if err != nil {
+			return err
+		}
+		if stored == content {
+			continue
+		}
+		i18n.Printf("Plan: %s\n", local.Branch)
+		if !storagePushDryRun {
+			if err := remote.SavePlan(local.Branch, content); err != nil {
+				return err
+			}
+		}
+		plansCopied++
+	}
+
+	entries, err := planMgr.SummaryIndex()
+	if err != nil {
+		return fmt.Errorf("failed to read summary index: %w", err)
+	}
+	stored := map[string]map[string]bool{}
+	summariesCopied := 0
+	for _, entry := range entries {
+		names, ok := stored[entry.Branch]
+		if !ok {
+			list, err := remote.ListSummaries(entry.Branch)
+			if err != nil {
+				return err
+			}
+			names = map[string]bool{}
+			for _, name := range list {
+				names[name] = true
+			}
+			stored[entry.Branch] = names
+		}
+		name := path.Base(entry.File)
+		if names[name] {
+			continue
+		}
+		i18n.Printf("Summary: %s\n", entry.File)
+		if !storagePushDryRun {
+			data, err := os.ReadFile(filepath.Join(planMgr.GetSummariesDir(), filepath.FromSlash(entry.File)))
+			if err != nil {
+				return fmt.Errorf("failed to read summary: %w", err)
+			}
+			if err := remote.AppendSummary(entry.Branch, name, data); err != nil {
+				return err
+			}
+		}
+		summariesCopied++
+	}
+
+	if storagePushDryRun {
+		i18n.Printf("Would copy %d plans and %d summaries\n", plansCopied, summariesCopied)
+	} else {
+		i18n.Printf("Copied %d plans and %d summaries\n", plansCopied, summariesCopied)
+	}
+	return nil
+}
+
+func runStoragePull(cmd *cobra.Command, args []string) error {
+	planMgr, remote, err := remotePlanManager()
+	if err != nil {
+		return err
+	}
+
+	var branch string
+	if len(args) > 0 {
+		branch = args[0]
+	} else if branch, err = currentPlanBranch(); err != nil {
+		return err
+	}
+
+	plan, err := remote.LoadPlan(branch)
+	if err != nil {
+		return err
+	}
+	if plan == "" {
+		return fmt.Errorf("no plan for %s in storage", branch)
+	}
+	if planMgr.PlanExists(branch) && !storagePullForce {
+		return fmt.Errorf("%s already has a local plan. Rerun with --force to replace it", branch)
+	}
+	if err := planMgr.SavePlan(branch, plan); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+	i18n.Printf("Wrote %s\n", planMgr.GetPlanPath(branch))
+	return nil
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/mob-claude/mob-claude/pkg/summary"
+	"github.com/spf13/cobra"
+)
+
+var (
+	summarizeRetry    bool
+	summarizeDiffFrom string
+	summarizeSave     bool
+	summarizeUpload   bool
+)
+
+func newSummarizeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summarize",
+		Short: "Generate a rotation summary",
+		Long: `Generates a summary of the changes on the current branch and prints it,
+outside the handoff flow. Useful mid-rotation, or after running 'mob next'
+without the wrapper.
+
+By default the diff is taken from the base branch; --diff-from picks another
+starting point. --save keeps the summary with the local summaries and
+--upload records it on the dashboard as a rotation.
+
+With --retry, regenerates the last rotation's saved summary instead, keeping
+its driver, note, and timestamps. The diff is the one the summary was made
+from, between the commits it recorded, however far the branch has moved
+since. Use it when a summary came out vague or fell back to the basic one.`,
+		Args: cobra.NoArgs,
+		RunE: runSummarize,
+	}
+	cmd.Flags().BoolVar(&summarizeRetry, "retry", false, "Regenerate the last rotation's saved summary")
+	cmd.Flags().StringVar(&summarizeDiffFrom, "diff-from", "", "Summarize changes since this git ref instead of the base branch")
+	cmd.Flags().BoolVar(&summarizeSave, "save", false, "Save the summary locally")
+	cmd.Flags().BoolVar(&summarizeUpload, "upload", false, "Upload the summary to the dashboard as a rotation")
+	addBaseFlag(cmd)
+	return cmd
+}
+
+func runSummarize(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	if summarizeUpload && (cfg.TeamName == "" || cfg.APIURL == "") {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+	// Fail up front rather than saving a basic summary in place of Claude's
+	if err := summary.CheckClaudeAvailable(); err != nil {
+		return err
+	}
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	mobWrapper := mob.NewWrapper()
+	mobWrapper.SetRemote(cfg.GitRemote)
+	mobWrapper.SetDiffBase(diffBaseFor(cfg))
+
+	// Start from the last saved summary when retrying, otherwise from the
+	// current session, if any
+	var previous *plans.Summary
+	var previousFile string
+	session, _ := config.LoadCurrentSession()
+	if summarizeRetry {
+		files, err := planMgr.ListSummaries()
+		if err != nil {
+			return fmt.Errorf("failed to list summaries: %w", err)
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no saved summary to regenerate")
+		}
+		previousFile = files[len(files)-1]
+		if previous, err = planMgr.LoadSummary(previousFile); err != nil {
+			return err
+		}
+		session = &config.CurrentSession{Branch: previous.Branch, DriverName: previous.DriverName}
+		if !previous.StartedAt.IsZero() {
+			session.StartedAt = previous.StartedAt.Format(time.RFC3339)
+		}
+	} else if session == nil {
+		branch, err := currentPlanBranch()
+		if err != nil {
+			return err
+		}
+		session = &config.CurrentSession{Branch: branch, DriverName: resolveDriverName("", cfg, nil)}
+	}
+
+	driverNote := driverNoteFor(session)
+	if previous != nil {
+		driverNote = previous.DriverNote
+	}
+
+	i18n.Println("Generating rotation summary...")
+	var diff, diffBase, diffHead string
+	switch {
+	case summarizeDiffFrom != "":
+		if diff, err = mobWrapper.GetDiffFrom(summarizeDiffFrom); err != nil {
+			return err
+		}
+	case previous != nil:
+		// The branch has moved on since, so only the rotation's own
+		// range gives the diff the summary was made from
+		if previous.DiffBase == "" || previous.DiffHead == "" {
+			return fmt.Errorf("the last summary doesn't record the commits it covered; pass --diff-from to choose the changes to summarize")
+		}
+		diffBase, diffHead = previous.DiffBase, previous.DiffHead
+		if diff, err = mobWrapper.GetDiffBetween(diffBase, diffHead); err != nil {
+			return fmt.Errorf("%w; pass --diff-from to choose the changes to summarize", err)
+		}
+	default:
+		if base, head, err := mobWrapper.GetDiffRange(); err == nil {
+			diffBase, diffHead = base, head
+		}
+		if diff, err = mobWrapper.GetDiffFromBase(); err != nil {
+			i18n.Printf("Warning: could not get diff: %v\n", err)
+		}
+	}
+	gen := newGenerator(cfg)
+	if previous == nil {
+		gen.SetActivity(rotationActivity(session, mobWrapper))
+		gen.SetNavigatorNotes(navigatorNotesFor(session))
+	} else {
+		gen.SetNavigatorNotes(previous.NavigatorNotes)
+	}
+	// A diff from a given ref is already incremental
+	if previous == nil && summarizeDiffFrom == "" {
+		setPreviousSummaries(gen, cfg, planMgr, session.Branch)
+	}
+	summaryObj, err := gen.Generate(diff, driverNote, session.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to generate summary: %w", err)
+	}
+	summaryObj.DriverName = session.DriverName
+	summaryObj.DiffBase, summaryObj.DiffHead = diffBase, diffHead
+
+	if previous != nil {
+		summaryObj.Timestamp = previous.Timestamp
+		summaryObj.StartedAt, summaryObj.EndedAt, summaryObj.Duration = previous.StartedAt, previous.EndedAt, previous.Duration
+		summaryObj.DiffStats = previous.DiffStats
+	} else if session.StartedAt != "" {
+		endRotation(session, summaryObj)
+	}
+	if previous == nil && summarizeDiffFrom == "" {
+		summaryObj.DiffStats = branchDiffStats(mobWrapper)
+	}
+
+	printSummary(summaryObj)
+
+	if previous != nil {
+		if err := planMgr.ReplaceSummary(previousFile, summaryObj); err != nil {
+			return fmt.Errorf("failed to save summary: %w", err)
+		}
+		i18n.Println("\nSaved over the last rotation's summary")
+	} else if summarizeSave {
+		if err := planMgr.SaveSummary(summaryObj); err != nil {
+			return fmt.Errorf("failed to save summary: %w", err)
+		}
+		i18n.Println("\nSummary saved")
+	}
+
+	if summarizeUpload {
+		if session.StartedAt == "" {
+			session.StartedAt = summaryObj.Timestamp.Format(time.RFC3339)
+		}
+		planText, _ := planMgr.LoadPlan(session.Branch)
+		rotation := newRotationRequest(session, summaryObj, planText, driverNote)
+		if _, err := newAPIClient(cfg).CreateRotation(cmd.Context(), session.Branch, rotation); err != nil {
+			return fmt.Errorf("failed to upload summary: %w", err)
+		}
+		i18n.Println("Rotation recorded in dashboard")
+	}
+	return nil
+}
+
+// printSummary shows a summary's TL;DR, changes, and next steps
+func printSummary(s *plans.Summary) {
+	i18n.Printf("Summary: %s\n", s.TLDR)
+	if len(s.Changes) > 0 {
+		i18n.Println("\nChanges:")
+		for _, change := range s.Changes {
+			fmt.Printf("  - %s\n", change)
+		}
+	}
+	if len(s.NextSteps) > 0 {
+		i18n.Println("\nNext steps:")
+		for _, step := range s.NextSteps {
+			fmt.Printf("  - %s\n", step)
+		}
+	}
+}
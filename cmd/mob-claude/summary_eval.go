@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/summary"
+	"github.com/spf13/cobra"
+)
+
+var (
+	evalModels       []string
+	evalPrompts      []string
+	recordDiffFrom   string
+	recordDriverNote string
+)
+
+func newSummaryCmd() *cobra.Command {
+	summaryCmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Evaluate summary prompts and print the summary schema",
+		Long: `Records rotations as fixtures and runs the summary generator over them, so
+prompt and model changes can be compared before rollout.
+
+A fixture directory holds <name>.diff files, each with an optional
+<name>.note containing the driver note.
+
+'summary schema' prints the JSON Schema Claude's summaries are checked
+against.`,
+	}
+
+	evalCmd := &cobra.Command{
+		Use:   "eval <fixtures-dir>",
+		Short: "Run the generator over fixtures and report quality stats",
+		Long: `Generates a summary for every fixture with each prompt and model
+combination, and reports how many passed the quality check along with TL;DR
+length stats.
+
+Prompt files may use {{driverNote}}, {{handoff}}, and {{diff}} placeholders.
+Without --prompt, the built-in prompt is used.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSummaryEval,
+	}
+	evalCmd.Flags().StringSliceVar(&evalModels, "models", nil, "Models to evaluate (default: the configured model)")
+	evalCmd.Flags().StringSliceVar(&evalPrompts, "prompt", nil, "Prompt template files to evaluate (repeatable)")
+
+	recordCmd := &cobra.Command{
+		Use:   "record <fixtures-dir> <name>",
+		Short: "Record the current diff as a fixture",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runSummaryRecord,
+	}
+	recordCmd.Flags().StringVar(&recordDiffFrom, "diff-from", "", "Record changes since this git ref instead of the base branch")
+	recordCmd.Flags().StringVarP(&recordDriverNote, "message", "m", "", "Driver note to store with the fixture")
+
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of generated summaries",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(summary.JSONSchema())
+			return nil
+		},
+	}
+
+	summaryCmd.AddCommand(evalCmd, recordCmd, schemaCmd)
+	return summaryCmd
+}
+
+// evalStats aggregates results for one prompt and model combination
+type evalStats struct {
+	prompt, model string
+	valid, errors int
+	tldrTotal     int
+	tldrMax       int
+	tldrCount     int
+}
+
+func runSummaryEval(cmd *cobra.Command, args []string) error {
+	fixtures, err := summary.LoadFixtures(args[0])
+	if err != nil {
+		return err
+	}
+	if len(fixtures) == 0 {
+		return fmt.Errorf("no fixtures (*.diff) found in %s", args[0])
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	models := evalModels
+	if len(models) == 0 {
+		models = []string{cfg.Model}
+	}
+
+	// An empty template stands for the built-in prompt
+	prompts := map[string]string{"default": ""}
+	promptNames := []string{"default"}
+	if len(evalPrompts) > 0 {
+		prompts, promptNames = map[string]string{}, nil
+		for _, path := range evalPrompts {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read prompt: %w", err)
+			}
+			name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			prompts[name] = string(data)
+			promptNames = append(promptNames, name)
+		}
+	}
+
+	var results []*evalStats
+	for _, promptName := range promptNames {
+		for _, model := range models {
+			stats := &evalStats{prompt: promptName, model: model}
+			gen := summary.NewGenerator(model, cfg.MaxTurns)
+			gen.SetPromptTemplate(prompts[promptName])
+
+			for _, f := range fixtures {
+				generated, problems, err := gen.Evaluate(f.Diff, f.DriverNote)
+				if err != nil {
+					stats.errors++
+					fmt.Printf("%s/%s %s: %v\n", promptName, model, f.Name, err)
+					continue
+				}
+				if len(problems) == 0 {
+					stats.valid++
+				} else {
+					fmt.Printf("%s/%s %s: %s\n", promptName, model, f.Name, strings.Join(problems, "; "))
+				}
+				if generated != nil && generated.TLDR != "" {
+					stats.tldrCount++
+					stats.tldrTotal += len(generated.TLDR)
+					if len(generated.TLDR) > stats.tldrMax {
+						stats.tldrMax = len(generated.TLDR)
+					}
+				}
+			}
+			results = append(results, stats)
+		}
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROMPT\tMODEL\tVALID\tERRORS\tAVG TLDR\tMAX TLDR")
+	for _, s := range results {
+		avg := 0
+		if s.tldrCount > 0 {
+			avg = s.tldrTotal / s.tldrCount
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d/%d\t%d\t%d\t%d\n", s.prompt, s.model, s.valid, len(fixtures), s.errors, avg, s.tldrMax)
+	}
+	return w.Flush()
+}
+
+func runSummaryRecord(cmd *cobra.Command, args []string) error {
+	mobWrapper := mob.NewWrapper()
+
+	var diff string
+	var err error
+	if recordDiffFrom != "" {
+		diff, err = mobWrapper.GetDiffFrom(recordDiffFrom)
+	} else {
+		diff, err = mobWrapper.GetDiffFromBase()
+	}
+	if err != nil {
+		return err
+	}
+
+	fixture := summary.Fixture{Name: args[1], Diff: diff, DriverNote: recordDriverNote}
+	if err := summary.SaveFixture(args[0], fixture); err != nil {
+		return err
+	}
+	i18n.Printf("Recorded fixture %s in %s\n", args[1], args[0])
+	return nil
+}
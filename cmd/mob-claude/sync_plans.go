@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+// Plan sync resolutions
+const (
+	syncPush = "push"
+	syncPull = "pull"
+	syncSkip = "skip"
+
+	// syncInSync is reported for plans that already match
+	syncInSync = "in-sync"
+)
+
+// planSyncResult is what sync-plans did with one branch's plan
+type planSyncResult struct {
+	Branch string `json:"branch"`
+	Action string `json:"action,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// syncPlansResult is the result of sync-plans
+type syncPlansResult struct {
+	DryRun bool             `json:"dryRun,omitempty"`
+	Pushed int              `json:"pushed"`
+	Pulled int              `json:"pulled"`
+	Failed int              `json:"failed"`
+	Plans  []planSyncResult `json:"plans"`
+}
+
+// add records the outcome for a branch
+func (r *syncPlansResult) add(branch, action string, err error) {
+	entry := planSyncResult{Branch: branch, Action: action}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	r.Plans = append(r.Plans, entry)
+}
+
+var syncPlansDryRun bool
+
+func newSyncPlansCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync-plans",
+		Short: "Sync every local plan with the dashboard",
+		Long: `Compares each local plan with the dashboard's copy and pushes or pulls
+whichever side changed. Useful after working offline for a while.
+
+When both sides changed since the last sync, you're asked which one to keep
+(the newer side is the default). Without a terminal, such conflicts are skipped.`,
+		Args: cobra.NoArgs,
+		RunE: runSyncPlans,
+	}
+	cmd.Flags().BoolVar(&syncPlansDryRun, "dry-run", false, "Show what would be synced without changing anything")
+	return cmd
+}
+
+func runSyncPlans(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	localPlans, err := planMgr.ListPlans()
+	if err != nil {
+		return fmt.Errorf("failed to list plans: %w", err)
+	}
+	state, err := planMgr.LoadSyncState()
+	if err != nil {
+		return err
+	}
+
+	client := newAPIClient(cfg)
+	team, err := client.GetTeam(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch workstreams: %w", err)
+	}
+
+	mobWrapper := mob.NewWrapper()
+	mobWrapper.SetRemote(cfg.GitRemote)
+	repoURL, err := mobWrapper.GetRepoURL()
+	if err != nil {
+		repoURL = "unknown"
+	}
+
+	local := make(map[string]plans.LocalPlan)
+	remote := make(map[string]api.Workstream)
+	var branches []string
+	for _, p := range localPlans {
+		local[p.Branch] = p
+		branches = append(branches, p.Branch)
+	}
+	if team != nil {
+		for _, ws := range team.Workstreams {
+			// Another repository's branch of the same name isn't this plan
+			if repoURL != "unknown" && ws.RepoURL != "" && mob.NormalizeRepoURL(ws.RepoURL) != repoURL {
+				continue
+			}
+			remote[ws.Branch] = ws
+			if _, ok := local[ws.Branch]; !ok {
+				branches = append(branches, ws.Branch)
+			}
+		}
+	}
+	sort.Strings(branches)
+
+	result := &syncPlansResult{DryRun: syncPlansDryRun, Plans: []planSyncResult{}}
+	setResult(result)
+
+	pushed, pulled, failed := 0, 0, 0
+	for _, branch := range branches {
+		localText, err := planMgr.LoadPlan(branch)
+		if err != nil {
+			i18n.Printf("Warning: skipping %v\n", err)
+			result.add(branch, "", err)
+			failed++
+			continue
+		}
+		remoteText := ""
+		ws, registered := remote[branch]
+		if registered {
+			if remoteText, err = client.GetPlan(ctx, branch); err != nil {
+				i18n.Printf("Warning: could not fetch plan for %s: %v\n", branch, err)
+				result.add(branch, "", err)
+				failed++
+				continue
+			}
+		}
+
+		// Front matter differs between the copies (each records when it was
+		// synced), so only the markdown is compared
+		localHash, remoteHash := plans.ContentHash(localText), plans.ContentHash(remoteText)
+		lastSynced := plans.LastSyncedHash(localText, state[branch])
+		action := syncSkip
+		switch {
+		case localText == "" && remoteText == "":
+			continue
+		case localHash == remoteHash:
+			fmt.Printf("%s: %s\n", branch, i18n.T("in sync"))
+			if !syncPlansDryRun {
+				markPlanSynced(planMgr, branch, localText)
+			}
+			state[branch] = localHash
+			recordPlanSync(branch)
+			result.add(branch, syncInSync, nil)
+			continue
+		case remoteText == "":
+			action = syncPush
+		case localText == "":
+			action = syncPull
+		case lastSynced == localHash:
+			action = syncPull
+		case lastSynced == remoteHash:
+			action = syncPush
+		default:
+			action = resolvePlanConflict(branch, local[branch].ModTime, ws.UpdatedAt, lastSynced != "")
+		}
+
+		switch action {
+		case syncPush:
+			fmt.Printf("%s: %s\n", branch, i18n.T("push local plan"))
+		case syncPull:
+			fmt.Printf("%s: %s\n", branch, i18n.T("pull dashboard plan"))
+		default:
+			fmt.Printf("%s: %s\n", branch, i18n.T("skipped"))
+			result.add(branch, syncSkip, nil)
+			continue
+		}
+		if syncPlansDryRun {
+			result.add(branch, action, nil)
+			continue
+		}
+
+		if action == syncPull {
+			if !markPlanSynced(planMgr, branch, remoteText) {
+				result.add(branch, action, fmt.Errorf("could not save plan locally"))
+				failed++
+				continue
+			}
+			state[branch] = remoteHash
+			recordPlanSync(branch)
+			result.add(branch, action, nil)
+			pulled++
+			continue
+		}
+
+		if !registered {
+			if _, err := client.CreateWorkstream(ctx, repoURL, branch); err != nil {
+				i18n.Printf("Warning: could not register workstream %s: %v\n", branch, err)
+				result.add(branch, action, err)
+				failed++
+				continue
+			}
+		}
+		if err := uploadPlan(ctx, client, planMgr, branch, localText); err != nil {
+			i18n.Printf("Warning: could not sync plan for %s: %v\n", branch, err)
+			result.add(branch, action, err)
+			failed++
+			continue
+		}
+		state[branch] = localHash
+		result.add(branch, action, nil)
+		pushed++
+	}
+	result.Pushed, result.Pulled, result.Failed = pushed, pulled, failed
+
+	if syncPlansDryRun {
+		i18n.Println("\nDry run: nothing was synced")
+		return nil
+	}
+	if err := planMgr.SaveSyncState(state); err != nil {
+		i18n.Printf("Warning: could not save plan sync state: %v\n", err)
+	}
+
+	i18n.Printf("\nPushed %d and pulled %d plan(s)\n", pushed, pulled)
+	if failed > 0 {
+		return fmt.Errorf("%d plan(s) failed to sync", failed)
+	}
+	return nil
+}
+
+// markPlanSynced saves plan as the branch's local plan with its syncedHash
+// up to date, unless it already is. It reports whether the plan was saved.
+func markPlanSynced(planMgr *plans.Manager, branch, plan string) bool {
+	marked, err := plans.MarkSynced(plan)
+	if err != nil {
+		marked = plan
+	}
+	if current, err := planMgr.LoadPlan(branch); err == nil && current == marked {
+		return true
+	}
+	if err := planMgr.SavePlan(branch, marked); err != nil {
+		i18n.Printf("Warning: could not save plan locally: %v\n", err)
+		return false
+	}
+	return true
+}
+
+// resolvePlanConflict asks which side of a diverged plan to keep. The newer
+// side is offered by default, unless both sides are known to have changed,
+// in which case a non-interactive run skips the plan.
+func resolvePlanConflict(branch string, localTime, remoteTime time.Time, bothChanged bool) string {
+	def := syncPush
+	if remoteTime.After(localTime) {
+		def = syncPull
+	}
+	if bothChanged && !isInteractive() {
+		def = syncSkip
+	}
+
+	i18n.Printf("%s: local plan (modified %s) and dashboard plan (updated %s) differ\n",
+		branch, localTime.Format("2006-01-02 15:04"), remoteTime.Format("2006-01-02 15:04"))
+	for {
+		answer := strings.ToLower(ask(i18n.T("Keep which side? push/pull/skip"), def))
+		switch answer {
+		case syncPush, syncPull, syncSkip:
+			return answer
+		}
+	}
+}
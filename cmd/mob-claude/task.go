@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/daemon"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var (
+	taskForce     bool
+	taskMine      bool
+	taskClaimAs   string
+	taskUnclaim   bool
+	taskClaimTake bool
+)
+
+func newTaskCmd() *cobra.Command {
+	taskCmd := &cobra.Command{
+		Use:   "task",
+		Short: "Work with plan tasks",
+		Long: `List and complete the checkbox tasks in the current plan.
+
+Tasks are numbered in the order they appear in the plan. A task can depend on
+others by ending with "(depends on 1, 2)" or "(after 1,2)", and be assigned
+to someone by ending with "(@name)" or "(owner: name)", as in
+"- [ ] Add tests (@alice)".`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List plan tasks and what they're waiting on",
+		Args:  cobra.NoArgs,
+		RunE:  runTaskList,
+	}
+	listCmd.Flags().BoolVar(&taskMine, "mine", false, "Only list the open tasks assigned to the driver")
+
+	doneCmd := &cobra.Command{
+		Use:   "done <n>",
+		Short: "Mark a task as done",
+		Long:  "Checks off task <n>, refusing if its dependencies aren't done yet (use --force to override).",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTaskDone,
+	}
+	doneCmd.Flags().BoolVar(&taskForce, "force", false, "Mark done even if dependencies are open")
+
+	claimCmd := &cobra.Command{
+		Use:   "claim <n>",
+		Short: "Assign a task to the driver",
+		Long: `Assigns task <n> to the driver, or to --as, by adding their name to it in
+the plan. A task someone else has claimed is only taken over with --force.
+--release removes the task's owner instead.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTaskClaim,
+	}
+	claimCmd.Flags().StringVar(&taskClaimAs, "as", "", "Assign the task to this name instead of the driver")
+	claimCmd.Flags().BoolVar(&taskUnclaim, "release", false, "Unassign the task")
+	claimCmd.Flags().BoolVar(&taskClaimTake, "force", false, "Take over a task someone else has claimed")
+
+	taskCmd.AddCommand(listCmd, doneCmd, claimCmd)
+	return taskCmd
+}
+
+// taskResult is the result of the task commands: the plan's tasks and
+// the next one that can be picked up
+type taskResult struct {
+	Branch string            `json:"branch"`
+	Tasks  []daemon.PlanTask `json:"tasks"`
+	Next   *daemon.PlanTask  `json:"next,omitempty"`
+}
+
+func newTaskResult(branch string, tasks []plans.Task) *taskResult {
+	result := &taskResult{Branch: branch, Tasks: []daemon.PlanTask{}}
+	for _, t := range tasks {
+		result.Tasks = append(result.Tasks, daemon.NewPlanTask(t))
+	}
+	if next := plans.NextUnblockedTask(tasks); next != nil {
+		task := daemon.NewPlanTask(*next)
+		result.Next = &task
+	}
+	return result
+}
+
+// currentPlanBranch returns the branch whose plan commands should operate on
+func currentPlanBranch() (string, error) {
+	session, _ := config.LoadCurrentSession()
+	if session != nil {
+		return session.Branch, nil
+	}
+	branch, err := mob.NewWrapper().GetBaseBranch()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine branch: %w", err)
+	}
+	return branch, nil
+}
+
+func runTaskList(cmd *cobra.Command, args []string) error {
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+
+	planText, err := planMgr.LoadPlan(branch)
+	if err != nil {
+		return err
+	}
+	tasks := plans.ParseTasks(planText)
+	if len(tasks) == 0 {
+		i18n.Println("No tasks in the plan")
+		setResult(newTaskResult(branch, nil))
+		return nil
+	}
+
+	driver := ""
+	if taskMine {
+		driver = currentDriver()
+	}
+	listed := 0
+	for _, t := range tasks {
+		if taskMine && (t.Done || !t.OwnedBy(driver)) {
+			continue
+		}
+		listed++
+		mark := " "
+		if t.Done {
+			mark = "x"
+		}
+		line := fmt.Sprintf("%2d. [%s] %s", t.Number, mark, t.Text)
+		if t.Owner != "" {
+			line += " (@" + t.Owner + ")"
+		}
+		if blocked := t.BlockedBy(tasks); !t.Done && len(blocked) > 0 {
+			line += i18n.Sprintf(" (blocked by %s)", joinInts(blocked))
+		}
+		fmt.Println(line)
+	}
+	if taskMine && listed == 0 {
+		i18n.Printf("No open tasks assigned to %s\n", driver)
+	}
+
+	if next := plans.NextUnblockedTask(tasks); next != nil {
+		i18n.Printf("\nNext up: %d. %s\n", next.Number, next.Text)
+	}
+	setResult(newTaskResult(branch, tasks))
+	return nil
+}
+
+func runTaskDone(cmd *cobra.Command, args []string) error {
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task number: %s", args[0])
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+
+	planText, err := planMgr.LoadPlan(branch)
+	if err != nil {
+		return err
+	}
+	tasks := plans.ParseTasks(planText)
+	if n < 1 || n > len(tasks) {
+		return fmt.Errorf("no task %d (plan has %d tasks)", n, len(tasks))
+	}
+	if blocked := tasks[n-1].BlockedBy(tasks); len(blocked) > 0 && !taskForce {
+		return fmt.Errorf("task %d depends on unfinished task(s) %s (use --force to override)", n, joinInts(blocked))
+	}
+
+	updated, err := plans.SetTaskDone(planText, n, true)
+	if err != nil {
+		return err
+	}
+	if err := planMgr.SavePlan(branch, updated); err != nil {
+		return err
+	}
+	i18n.Printf("Marked task %d done: %s\n", n, tasks[n-1].Text)
+
+	// Keep the dashboard copy in step
+	if cfg, err := config.Load(); err == nil && cfg.TeamName != "" && cfg.APIURL != "" {
+		if err := uploadPlan(cmd.Context(), newAPIClient(cfg), planMgr, branch, updated); err != nil {
+			i18n.Printf("Warning: could not sync plan: %v\n", err)
+			queueFailedPlan(branch, updated, err)
+		}
+	}
+
+	if next := plans.NextUnblockedTask(plans.ParseTasks(updated)); next != nil {
+		i18n.Printf("Next up: %d. %s\n", next.Number, next.Text)
+	}
+	setResult(newTaskResult(branch, plans.ParseTasks(updated)))
+	return nil
+}
+
+func runTaskClaim(cmd *cobra.Command, args []string) error {
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task number: %s", args[0])
+	}
+
+	planMgr, err := newPlanManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize plan manager: %w", err)
+	}
+	branch, err := currentPlanBranch()
+	if err != nil {
+		return err
+	}
+
+	planText, err := planMgr.LoadPlan(branch)
+	if err != nil {
+		return err
+	}
+	tasks := plans.ParseTasks(planText)
+	if n < 1 || n > len(tasks) {
+		return fmt.Errorf("no task %d (plan has %d tasks)", n, len(tasks))
+	}
+	task := tasks[n-1]
+
+	owner := ""
+	if !taskUnclaim {
+		owner = strings.TrimPrefix(strings.TrimSpace(taskClaimAs), "@")
+		if owner == "" {
+			owner = currentDriver()
+		}
+		if task.Owner != "" && !task.OwnedBy(owner) && !taskClaimTake {
+			return fmt.Errorf("task %d is claimed by %s (use --force to take it over)", n, task.Owner)
+		}
+	}
+
+	updated, err := plans.SetTaskOwner(planText, n, owner)
+	if err != nil {
+		return err
+	}
+	if err := planMgr.SavePlan(branch, updated); err != nil {
+		return err
+	}
+	if owner == "" {
+		i18n.Printf("Task %d is unassigned: %s\n", n, task.Text)
+	} else {
+		i18n.Printf("Task %d assigned to %s: %s\n", n, owner, task.Text)
+	}
+
+	// Keep the dashboard copy in step
+	if cfg, err := config.Load(); err == nil && cfg.TeamName != "" && cfg.APIURL != "" {
+		if err := uploadPlan(cmd.Context(), newAPIClient(cfg), planMgr, branch, updated); err != nil {
+			i18n.Printf("Warning: could not sync plan: %v\n", err)
+			queueFailedPlan(branch, updated, err)
+		}
+	}
+	setResult(newTaskResult(branch, plans.ParseTasks(updated)))
+	return nil
+}
+
+// currentDriver returns the session's driver, or who would drive if a
+// session started now
+func currentDriver() string {
+	if session, _ := config.LoadCurrentSession(); session != nil && session.DriverName != "" {
+		return session.DriverName
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	return resolveDriverName("", cfg, nil)
+}
+
+// printDriverTasks lists the open plan tasks assigned to the driver, so
+// they see them when their rotation starts
+func printDriverTasks(planMgr *plans.Manager, branch, driver string) {
+	plan, err := planMgr.LoadPlan(branch)
+	if err != nil {
+		return
+	}
+	var mine []string
+	for _, t := range plans.ParseTasks(plan) {
+		if !t.Done && t.OwnedBy(driver) {
+			mine = append(mine, fmt.Sprintf("%d. %s", t.Number, t.Text))
+		}
+	}
+	printPanelList(i18n.Sprintf("Your tasks, %s", driver), mine)
+}
+
+func joinInts(nums []int) string {
+	s := ""
+	for i, n := range nums {
+		if i > 0 {
+			s += ", "
+		}
+		s += strconv.Itoa(n)
+	}
+	return s
+}
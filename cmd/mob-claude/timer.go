@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/events"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/internal/notify"
+	"github.com/mob-claude/mob-claude/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+// autoNextGrace is how long after expiry auto-next waits before handing off
+const autoNextGrace = time.Minute
+
+var (
+	timerAutoNext     bool
+	timerHighContrast bool
+	timerLargeText    bool
+	timerAlert        string
+)
+
+func newTimerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timer",
+		Short: "Watch the rotation timer",
+		Long: `Counts down the current rotation and emits escalating warnings as the
+deadline approaches and passes.
+
+With autoNext enabled (config or --auto-next), the summary and handoff flow
+runs automatically shortly after the timer expires.`,
+		Args: cobra.NoArgs,
+		RunE: runTimer,
+	}
+	cmd.Flags().BoolVar(&timerAutoNext, "auto-next", false, "Hand off automatically when the timer expires")
+	cmd.Flags().BoolVar(&timerHighContrast, "high-contrast", false, "Render warnings in high-contrast (bold, inverted) text")
+	cmd.Flags().BoolVar(&timerLargeText, "large", false, "Render the remaining time in large block digits")
+	cmd.Flags().StringVar(&timerAlert, "alert", "", "Alert style for urgent warnings: bell, flash, or none")
+	return cmd
+}
+
+func runTimer(cmd *cobra.Command, args []string) error {
+	session, err := config.LoadCurrentSession()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return fmt.Errorf("no active mob session. Run 'mob-claude start' first")
+	}
+	if session.TimerDeadline == "" {
+		return fmt.Errorf("no timer for this session. Set one with 'mob-claude config set rotationMinutes <n>' before starting")
+	}
+
+	deadline, err := time.Parse(time.RFC3339, session.TimerDeadline)
+	if err != nil {
+		return fmt.Errorf("invalid timer deadline in session: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	applySessionPreset(cfg, session)
+	autoNext := timerAutoNext || cfg.AutoNext
+
+	display := timer.Display{
+		HighContrast: timerHighContrast || cfg.TimerHighContrast,
+		LargeText:    timerLargeText || cfg.TimerLargeText,
+		Alert:        cfg.TimerAlert,
+	}
+	if timerAlert != "" {
+		display.Alert = timerAlert
+	}
+	switch display.Alert {
+	case "", timer.AlertBell, timer.AlertFlash, timer.AlertNone:
+	default:
+		return fmt.Errorf("invalid alert style %q: use bell, flash, or none", display.Alert)
+	}
+
+	i18n.Printf("Rotation ends at %s (%s left)\n", deadline.Format("15:04"), timer.FormatRemaining(deadline.Sub(clk.Now())))
+	if url := mob.LoadSettings().TimerRoomURL(); url != "" {
+		i18n.Printf("Timer room: %s\n", url)
+	}
+	if autoNext {
+		i18n.Printf("Auto-next is on: handing off %s after the timer expires\n", autoNextGrace)
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	lastLevel := timer.LevelAt(deadline.Sub(clk.Now()))
+	lastOverdueMinute := 0
+	for range ticker.C {
+		remaining := deadline.Sub(clk.Now())
+		level := timer.LevelAt(remaining)
+
+		if level != lastLevel {
+			display.Announce(os.Stdout, remaining)
+			switch {
+			case level == timer.LevelExpired:
+				emitNotification(cmd.Context(), cfg, events.TimerExpired,
+					i18n.Sprintf("Time's up for %s on %s", session.DriverName, session.Branch),
+					timer.Message(remaining), session.Branch, session.DriverName, nil)
+			case level >= timer.LevelUrgent:
+				_ = notify.Desktop("mob-claude", timer.Message(remaining))
+			}
+			lastLevel = level
+			// The level change was this minute's announcement
+			lastOverdueMinute = int(-remaining.Minutes())
+		} else if level == timer.LevelOverdue {
+			// Keep nagging once a minute while overdue
+			if minute := int(-remaining.Minutes()); minute > lastOverdueMinute {
+				display.Announce(os.Stdout, remaining)
+				lastOverdueMinute = minute
+			}
+		}
+
+		if autoNext && remaining <= -autoNextGrace {
+			i18n.Println("\nAuto-next: starting handoff...")
+			return runNext(cmd, nil)
+		}
+	}
+	return nil
+}
+
+// timerRoom returns the mob.sh timer room the mob shares (MOB_TIMER_ROOM),
+// or nil if there's none
+func timerRoom() *timer.Room {
+	if url := mob.LoadSettings().TimerRoomURL(); url != "" {
+		return timer.NewRoom(url)
+	}
+	return nil
+}
+
+// timerRoomUser returns the name timers started from here are shown under:
+// MOB_TIMER_USER, or else the driver
+func timerRoomUser(driver string) string {
+	if user := mob.LoadSettings().TimerUser; user != "" {
+		return user
+	}
+	return driver
+}
+
+// startTimerRoom starts the rotation timer in the shared timer room, if the
+// mob uses one
+func startTimerRoom(ctx context.Context, minutes int, driver string) {
+	room := timerRoom()
+	if room == nil {
+		return
+	}
+	if err := room.Start(ctx, minutes, timerRoomUser(driver)); err != nil {
+		i18n.Printf("Warning: could not start timer room: %v\n", err)
+		return
+	}
+	i18n.Printf("Timer room: %s\n", room.URL())
+}
+
+// stopTimerRoom clears the shared timer room's timer at the end of a
+// rotation, if the mob uses one
+func stopTimerRoom(ctx context.Context, driver string) {
+	room := timerRoom()
+	if room == nil {
+		return
+	}
+	if err := room.Stop(ctx, timerRoomUser(driver)); err != nil {
+		i18n.Printf("Warning: could not stop timer room: %v\n", err)
+	}
+}
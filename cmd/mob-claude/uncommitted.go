@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/mob"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// checkUncommitted warns about working tree changes that mob next would
+// sweep into the WIP commit and asks whether to include them. It returns
+// nil if there are none. Without a terminal they're included, as mob next
+// would do anyway.
+func checkUncommitted(mobWrapper *mob.Wrapper) *plans.UncommittedChanges {
+	files, err := mobWrapper.UncommittedFiles()
+	if err != nil {
+		i18n.Printf("Warning: could not check for uncommitted changes: %v\n", err)
+		return nil
+	}
+
+	// The plan, summaries, and handoff brief are mob-claude's own and
+	// always go along
+	var changed []string
+	for _, f := range files {
+		if !strings.HasPrefix(f, config.ConfigDir+"/") && !strings.HasPrefix(f, plans.PlansDir+"/") {
+			changed = append(changed, f)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	i18n.Printf("Warning: %d uncommitted files:\n", len(changed))
+	for _, f := range changed {
+		fmt.Printf("  %s\n", f)
+	}
+	included := confirm(i18n.T("Include them in the handoff?"), true)
+	return &plans.UncommittedChanges{Files: changed, Included: included}
+}
+
+// stashUncommitted keeps changes the driver left out of the handoff in a
+// git stash on this machine
+func stashUncommitted(mobWrapper *mob.Wrapper, branch string, files []string) {
+	if err := mobWrapper.StashFiles("mob-claude: left out of handoff on "+branch, files); err != nil {
+		i18n.Printf("Warning: could not stash uncommitted changes, they will be handed off: %v\n", err)
+		return
+	}
+	i18n.Printf("Stashed %d files left out of the handoff; run 'git stash pop' to get them back\n", len(files))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var watchTransport string
+
+func newWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [branch]",
+		Short: "Stream live updates for a workstream",
+		Long: `Prints live updates from the dashboard for the current workstream (or the
+given branch) as they happen.
+
+Updates stream over server-sent events. If a proxy keeps cutting the stream,
+mob-claude falls back to long-polling and periodically tries streaming again.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runWatch,
+	}
+	cmd.Flags().StringVar(&watchTransport, "transport", api.TransportAuto, "Transport: auto, sse, or poll")
+	return cmd
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	switch watchTransport {
+	case api.TransportAuto, api.TransportSSE, api.TransportLongPoll:
+	default:
+		return fmt.Errorf("invalid transport %q: use auto, sse, or poll", watchTransport)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+
+	branch := ""
+	if len(args) > 0 {
+		branch = args[0]
+	} else if branch, err = currentPlanBranch(); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	sub := newAPIClient(cfg).Subscribe(branch, watchTransport)
+	sub.OnTransportChange = func(transport string) {
+		if transport == api.TransportLongPoll {
+			i18n.Println("(streaming blocked, falling back to long-polling)")
+		}
+	}
+
+	i18n.Printf("Watching %s (Ctrl-C to stop)\n", branch)
+	err = sub.Run(ctx, func(event api.Event) error {
+		fmt.Println(formatEvent(event))
+		return nil
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// formatEvent renders a live event as a single line
+func formatEvent(event api.Event) string {
+	var fields struct {
+		DriverName  string `json:"driverName"`
+		SummaryTLDR string `json:"summaryTldr"`
+	}
+	_ = json.Unmarshal(event.Data, &fields)
+
+	switch {
+	case fields.DriverName != "" && fields.SummaryTLDR != "":
+		return fmt.Sprintf("[%s] %s: %s", event.Type, fields.DriverName, fields.SummaryTLDR)
+	case fields.DriverName != "":
+		return fmt.Sprintf("[%s] %s", event.Type, fields.DriverName)
+	default:
+		return fmt.Sprintf("[%s] %s", event.Type, string(event.Data))
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/pkg/api"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+	"github.com/spf13/cobra"
+)
+
+var workstreamListAll bool
+
+func newWorkstreamCmd() *cobra.Command {
+	workstreamCmd := &cobra.Command{
+		Use:   "workstream",
+		Short: "List, archive, and reopen the team's workstreams",
+		Long: `Workstreams are the dashboard's record of each mobbed branch. Archiving a
+finished one takes it out of the dashboard's picker and 'start --pick'
+while keeping its plan and rotations; reopening it brings it back when work
+resumes.`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the team's workstreams",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runWorkstreamList,
+	}
+	listCmd.Flags().BoolVar(&workstreamListAll, "all", false, "Include archived workstreams")
+
+	archiveCmd := &cobra.Command{
+		Use:   "archive [branch]",
+		Short: "Archive a finished workstream",
+		Long: `Marks the workstream inactive on the dashboard (the current session's, by
+default). Nothing is deleted; 'workstream reopen' revives it.
+
+Example: mob-claude workstream archive feature-login`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setWorkstreamActive(cmd.Context(), args, false)
+		},
+	}
+
+	reopenCmd := &cobra.Command{
+		Use:   "reopen <branch>",
+		Short: "Reopen an archived workstream",
+		Long: `Marks an archived workstream active again, so it's offered by the
+dashboard's picker and 'start --pick'.
+
+Example: mob-claude workstream reopen feature-login`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setWorkstreamActive(cmd.Context(), args, true)
+		},
+	}
+
+	workstreamCmd.AddCommand(listCmd, archiveCmd, reopenCmd)
+	return workstreamCmd
+}
+
+// allWorkstreams fetches every page of the team's workstreams
+func allWorkstreams(ctx context.Context, client *api.Client) ([]api.Workstream, error) {
+	var workstreams []api.Workstream
+	opts := api.ListOptions{}
+	for {
+		page, err := client.ListWorkstreams(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch workstreams: %w", err)
+		}
+		workstreams = append(workstreams, page.Workstreams...)
+		if page.NextCursor == "" {
+			return workstreams, nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+func runWorkstreamList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+	workstreams, err := allWorkstreams(cmd.Context(), newAPIClient(cfg))
+	if err != nil {
+		return err
+	}
+
+	shown := make([]api.Workstream, 0, len(workstreams))
+	for _, ws := range workstreams {
+		if ws.IsActive || workstreamListAll {
+			shown = append(shown, ws)
+		}
+	}
+	sort.SliceStable(shown, func(i, j int) bool {
+		return shown[i].UpdatedAt.After(shown[j].UpdatedAt)
+	})
+	setResult(shown)
+
+	if len(shown) == 0 {
+		if workstreamListAll {
+			i18n.Println("No workstreams")
+		} else {
+			i18n.Println("No active workstreams. Use --all to include archived ones")
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, ws := range shown {
+		state := ""
+		if !ws.IsActive {
+			state = i18n.T("archived")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ws.Branch, ws.UpdatedAt.Local().Format("2006-01-02"), state, plans.PlanTitle(ws.PlanText))
+	}
+	return w.Flush()
+}
+
+// setWorkstreamActive archives or reopens the workstream of the branch in
+// args, or of the current session
+func setWorkstreamActive(ctx context.Context, args []string, active bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.TeamName == "" || cfg.APIURL == "" {
+		return fmt.Errorf("dashboard not configured. Set apiUrl and teamName first")
+	}
+	var branch string
+	if len(args) > 0 {
+		branch = args[0]
+	} else if branch, err = currentPlanBranch(); err != nil {
+		return err
+	}
+
+	client := newAPIClient(cfg)
+	current, err := client.GetWorkstream(ctx, branch)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("no workstream for %s on the dashboard", branch)
+	}
+	if current.IsActive == active {
+		setResult(current)
+		if active {
+			i18n.Printf("%s is already active\n", branch)
+		} else {
+			i18n.Printf("%s is already archived\n", branch)
+		}
+		return nil
+	}
+
+	ws, err := client.SetWorkstreamActive(ctx, branch, active)
+	if err != nil {
+		return err
+	}
+	if ws == nil {
+		return fmt.Errorf("no workstream for %s on the dashboard", branch)
+	}
+	setResult(ws)
+	if active {
+		i18n.Printf("Reopened %s\n", branch)
+	} else {
+		i18n.Printf("Archived %s. Reopen it with 'mob-claude workstream reopen %s'\n", branch, branch)
+	}
+	return nil
+}
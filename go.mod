@@ -2,9 +2,20 @@ module github.com/mob-claude/mob-claude
 
 go 1.21
 
-require github.com/spf13/cobra v1.8.0
+require (
+	filippo.io/age v1.1.1
+	github.com/spf13/cobra v1.8.0
+	github.com/zalando/go-keyring v0.2.6
+	golang.org/x/sync v0.8.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
+	al.essio.dev/pkg/shellescape v1.5.1 // indirect
+	github.com/danieljoos/wincred v1.2.2 // indirect
+	github.com/godbus/dbus/v5 v5.1.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/crypto v0.4.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 )
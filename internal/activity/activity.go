@@ -0,0 +1,120 @@
+package activity
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// FileName is the activity log inside the config directory
+const FileName = "activity.jsonl"
+
+// Event kinds
+const (
+	KindCommit = "commit"
+	KindSave   = "save"
+	KindTest   = "test"
+)
+
+// Event is one thing that happened during a rotation
+type Event struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// Log is a rotation's activity, stored one JSON event per line so the
+// watcher and hooks can append to it from separate processes
+type Log struct {
+	path string
+}
+
+// New returns the activity log stored in dir
+func New(dir string) *Log {
+	return &Log{path: filepath.Join(dir, FileName)}
+}
+
+// Record appends an event to the log
+func (l *Log) Record(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), platform.DirPerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, platform.FilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to open activity log: %w", err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// Load returns the events recorded at or after since, oldest first.
+// Lines that don't parse, such as one cut short by a crash, are skipped.
+func (l *Log) Load(since time.Time) ([]Event, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read activity log: %w", err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Time.Before(since) {
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events, scanner.Err()
+}
+
+// Clear empties the log, once a rotation's activity has been summarized
+func (l *Log) Clear() error {
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Format renders events for the summary prompt: commits and test runs in
+// order, then saved files collapsed into one line each
+func Format(events []Event) string {
+	var b strings.Builder
+	saves := make(map[string]int)
+	var saved []string
+	for _, e := range events {
+		switch e.Kind {
+		case KindSave:
+			if saves[e.Detail] == 0 {
+				saved = append(saved, e.Detail)
+			}
+			saves[e.Detail]++
+		default:
+			fmt.Fprintf(&b, "- %s %s: %s\n", e.Time.Local().Format("15:04"), e.Kind, e.Detail)
+		}
+	}
+	for _, file := range saved {
+		if saves[file] > 1 {
+			fmt.Fprintf(&b, "- saved %s (%d saves)\n", file, saves[file])
+		} else {
+			fmt.Fprintf(&b, "- saved %s\n", file)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package activity
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// skipDirs are never watched: VCS data, mob-claude's own files, and
+// dependency trees that change without anyone editing them
+var skipDirs = map[string]bool{
+	".git":         true,
+	".claude":      true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// Watch polls the files under root every interval and calls onSave with
+// the slash-separated relative path of each file whose modification time
+// changes, until ctx is done. Polling keeps it dependency-free and works
+// the same on every platform; the first scan only records a baseline.
+func Watch(ctx context.Context, root string, interval time.Duration, onSave func(path string)) error {
+	seen, err := scan(root)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := scan(root)
+		if err != nil {
+			continue
+		}
+		for path, modTime := range current {
+			if previous, ok := seen[path]; !ok || !previous.Equal(modTime) {
+				onSave(path)
+			}
+		}
+		seen = current
+	}
+}
+
+// scan returns the modification time of every watched file under root
+func scan(root string) (map[string]time.Time, error) {
+	files := make(map[string]time.Time)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		files[filepath.ToSlash(rel)] = info.ModTime()
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,165 @@
+package claudecode
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// InternalEnv is set in the environment of the Claude calls mob-claude
+// makes itself, so its hook can tell them from the driver's conversations
+const InternalEnv = "MOB_CLAUDE_INTERNAL"
+
+// HookInput is the JSON Claude Code passes on stdin to hook commands
+type HookInput struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	Cwd            string `json:"cwd"`
+	HookEventName  string `json:"hook_event_name"`
+}
+
+// ParseHookInput reads a hook's stdin
+func ParseHookInput(r io.Reader) (*HookInput, error) {
+	var input HookInput
+	if err := json.NewDecoder(r).Decode(&input); err != nil {
+		return nil, fmt.Errorf("failed to parse hook input: %w", err)
+	}
+	if input.SessionID == "" {
+		return nil, fmt.Errorf("hook input has no session_id")
+	}
+	return &input, nil
+}
+
+// nonAlphanumeric matches the characters Claude Code replaces when naming
+// a project's transcript directory after its path
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// TranscriptDir returns where Claude Code keeps the conversation
+// transcripts for a project directory: ~/.claude/projects/<path with
+// every non-alphanumeric character replaced by "-">
+func TranscriptDir(projectDir string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(projectDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude", "projects", nonAlphanumeric.ReplaceAllString(abs, "-")), nil
+}
+
+// SessionExists reports whether a conversation's transcript is on this
+// machine, which 'claude --resume' needs
+func SessionExists(projectDir, sessionID string) bool {
+	dir, err := TranscriptDir(projectDir)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, sessionID+".jsonl"))
+	return err == nil
+}
+
+// ownSessionsFile lists the conversation IDs of the Claude calls mob-claude
+// makes itself, one per line, in mob-claude's directory of the user cache
+const ownSessionsFile = "claude-sessions"
+
+func ownSessionsPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mob-claude", ownSessionsFile), nil
+}
+
+// NewOwnSession returns a conversation ID for a Claude call mob-claude makes
+// itself, to pass with --session-id. The ID is recorded, so LatestSession
+// never mistakes the call's transcript for the driver's conversation.
+func NewOwnSession() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// Claude Code takes a version 4 UUID
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	id := fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+
+	path, err := ownSessionsPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), platform.DirPerm); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, platform.FilePerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to record Claude session: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, id); err != nil {
+		return "", fmt.Errorf("failed to record Claude session: %w", err)
+	}
+	return id, nil
+}
+
+// ownSessions returns the recorded IDs of mob-claude's own Claude calls
+func ownSessions() map[string]bool {
+	own := make(map[string]bool)
+	path, err := ownSessionsPath()
+	if err != nil {
+		return own
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return own
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		own[strings.TrimSpace(scanner.Text())] = true
+	}
+	return own
+}
+
+// LatestSession guesses the driver's Claude Code conversation for a
+// project: the most recently updated transcript modified since the given
+// time, skipping mob-claude's own summary calls. It returns "" if there's
+// none. Capturing the ID with the SessionStart hook is more reliable.
+func LatestSession(projectDir string, since time.Time) (string, error) {
+	dir, err := TranscriptDir(projectDir)
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	own := ownSessions()
+	latest, latestTime := "", since
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if entry.IsDir() || !ok || own[id] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().Before(latestTime) {
+			continue
+		}
+		latest, latestTime = id, info.ModTime()
+	}
+	return latest, nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// AnnouncementsFile records which team announcements the user has already
+// seen. It lives in the user config directory, so each person sees an
+// announcement once no matter how many projects or clones they use.
+const AnnouncementsFile = "announcements.json"
+
+// userConfigDir returns mob-claude's per-user config directory
+func userConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mob-claude"), nil
+}
+
+// loadAnnouncementState reads the seen announcement IDs for every dashboard
+func loadAnnouncementState() (map[string][]string, error) {
+	state := make(map[string][]string)
+	dir, err := userConfigDir()
+	if err != nil {
+		return state, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, AnnouncementsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return make(map[string][]string), err
+	}
+	return state, nil
+}
+
+// SeenAnnouncements returns the IDs of announcements already shown for a
+// team on a dashboard
+func SeenAnnouncements(apiURL, team string) map[string]bool {
+	state, _ := loadAnnouncementState()
+	seen := make(map[string]bool)
+	for _, id := range state[apiURL+"#"+team] {
+		seen[id] = true
+	}
+	return seen
+}
+
+// MarkAnnouncementsSeen records ids as the team's seen announcements.
+// Passing the currently pinned IDs drops ones that were unpinned.
+func MarkAnnouncementsSeen(apiURL, team string, ids []string) error {
+	state, _ := loadAnnouncementState()
+	state[apiURL+"#"+team] = ids
+
+	dir, err := userConfigDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, AnnouncementsFile), data, platform.FilePerm)
+}
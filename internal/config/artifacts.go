@@ -0,0 +1,26 @@
+package config
+
+// Artifacts are the files in the config directory that a team may want to
+// commit: config.json, rotation summaries, --archive bundles, the handoff
+// brief, plan templates, and session templates
+var Artifacts = []string{"config", "summaries", "archive", "handoff", "templates", "session-templates"}
+
+// Tracks reports whether git should track the named artifact
+func (c *Config) Tracks(artifact string) bool {
+	for _, a := range c.UntrackedArtifacts {
+		if a == artifact {
+			return false
+		}
+	}
+	return true
+}
+
+// IsArtifact reports whether name is one of the Artifacts
+func IsArtifact(name string) bool {
+	for _, a := range Artifacts {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
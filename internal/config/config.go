@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
 )
 
 const (
@@ -14,25 +16,276 @@ const (
 
 // Config holds the mob-claude configuration
 type Config struct {
+	// Version is the config.json schema version, see ConfigVersion
+	Version int `json:"version"`
+
 	APIURL      string `json:"apiUrl"`
 	TeamName    string `json:"teamName"`
 	Model       string `json:"model"`
 	MaxTurns    int    `json:"maxTurns"`
 	SkipSummary bool   `json:"skipSummary"`
+
+	// RotationMinutes is the rotation length; 0 disables the timer
+	RotationMinutes int  `json:"rotationMinutes,omitempty"`
+	AutoNext        bool `json:"autoNext,omitempty"`
+
+	// PlainWipCommits keeps mob.sh's WIP commit message on next instead of
+	// adding the rotation's TL;DR to it
+	PlainWipCommits bool `json:"plainWipCommits,omitempty"`
+
+	// Timer display accessibility options
+	TimerHighContrast bool   `json:"timerHighContrast,omitempty"`
+	TimerLargeText    bool   `json:"timerLargeText,omitempty"`
+	TimerAlert        string `json:"timerAlert,omitempty"`
+
+	// SummaryRetries is how many times a summary that fails validation is
+	// regenerated; nil uses the default
+	SummaryRetries *int `json:"summaryRetries,omitempty"`
+
+	// SummaryContext is how many of the branch's previous summaries are
+	// given to Claude so a new one doesn't repeat them; nil uses the
+	// default and 0 turns it off
+	SummaryContext *int `json:"summaryContext,omitempty"`
+
+	// RedactSecrets scrubs API keys, cloud credentials, private keys, and
+	// anything matching RedactPatterns from diffs before they're sent to
+	// Claude
+	RedactSecrets  bool     `json:"redactSecrets,omitempty"`
+	RedactPatterns []string `json:"redactPatterns,omitempty"`
+
+	// SummaryIncludePaths and SummaryExcludePaths are globs choosing whose
+	// changes are sent to Claude, e.g. to leave out generated files,
+	// snapshots, and vendored directories
+	SummaryIncludePaths []string `json:"summaryIncludePaths,omitempty"`
+	SummaryExcludePaths []string `json:"summaryExcludePaths,omitempty"`
+
+	// Claude holds extra generation controls passed to the claude CLI
+	Claude *ClaudeOptions `json:"claude,omitempty"`
+
+	// StatusPreview controls the plan preview in 'status'
+	StatusPreview *StatusPreview `json:"statusPreview,omitempty"`
+
+	// DriverName overrides the driver name taken from git or the OS user,
+	// for shared machines
+	DriverName string `json:"driverName,omitempty"`
+
+	// Language overrides the locale detected from the environment
+	Language string `json:"language,omitempty"`
+
+	// SummaryLanguage is the language Claude writes summaries and the
+	// handoff brief in, as a code ("ja") or name ("Japanese"); "" is English
+	SummaryLanguage string `json:"summaryLanguage,omitempty"`
+
+	// BaseBranch is the branch summaries diff against, such as "develop";
+	// "" detects it from origin/HEAD, falling back to main or master
+	BaseBranch string `json:"baseBranch,omitempty"`
+
+	// GitRemote is the remote whose URL identifies the repository and whose
+	// branches diffs are taken from; "" uses mob.sh's MOB_REMOTE_NAME or origin
+	GitRemote string `json:"gitRemote,omitempty"`
+
+	// MobPath is the mob binary to run, for mob.sh installed outside PATH or
+	// another implementation of its command line; "" uses mob from PATH
+	MobPath string `json:"mobPath,omitempty"`
+
+	// Engine runs mob sessions: "mob.sh" (the default) or "internal" for
+	// plain git without mob.sh
+	Engine string `json:"engine,omitempty"`
+
+	// HTTPTimeout is the dashboard request timeout in seconds; 0 uses the default
+	HTTPTimeout int `json:"httpTimeout,omitempty"`
+
+	// HTTPProxy is the proxy dashboard requests go through, as an http,
+	// https, or socks5 URL; "" honors HTTPS_PROXY and friends
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// CABundle is a PEM file of extra certificate authorities to trust for
+	// the dashboard, such as a corporate proxy's
+	CABundle string `json:"caBundle,omitempty"`
+
+	// Storage is a bucket that keeps a copy of every plan and summary, as
+	// an s3://bucket/prefix or gs://bucket/prefix URL; "" keeps them local only
+	Storage string `json:"storage,omitempty"`
+
+	// StorageEndpoint and StorageRegion override the bucket's service URL,
+	// for S3-compatible stores, and its region
+	StorageEndpoint string `json:"storageEndpoint,omitempty"`
+	StorageRegion   string `json:"storageRegion,omitempty"`
+
+	// KeepSummariesDays is how many days of summaries 'prune' keeps;
+	// older ones are archived or deleted. 0 keeps them all.
+	KeepSummariesDays int `json:"keepSummariesDays,omitempty"`
+
+	// KeepArchives is how many archives 'prune' keeps for each branch; 0
+	// keeps them all
+	KeepArchives int `json:"keepArchives,omitempty"`
+
+	// UntrackedArtifacts are the Artifacts the managed .gitignore in the
+	// config directory keeps out of git; the rest can be committed. Runtime
+	// files such as the session are always kept out.
+	UntrackedArtifacts []string `json:"untrackedArtifacts,omitempty"`
+
+	// Presets are named session setups selectable with 'start --preset'
+	Presets map[string]Preset `json:"presets,omitempty"`
+
+	// Schedule is the team's weekly mob blocks. When it's empty, the
+	// dashboard's schedule is used.
+	Schedule []ScheduleBlock `json:"schedule,omitempty"`
+
+	// Profiles are named dashboard setups; Profile is the one selected with
+	// 'profile use'
+	Profile  string             `json:"profile,omitempty"`
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+
+	// Notifications route session events to the desktop, chat tools, and
+	// webhooks
+	Notifications []Notification `json:"notifications,omitempty"`
+
+	// Secrets live in the OS keychain (or an encrypted file), never in config.json
+	APIToken     string `json:"-"`
+	SlackWebhook string `json:"-"`
+
+	// SigningSecret is the team's shared secret for signing dashboard requests
+	SigningSecret string `json:"-"`
+
+	// EncryptionKey is the team's age key ("AGE-SECRET-KEY-1...") that
+	// summaries and archives are encrypted with at rest
+	EncryptionKey string `json:"-"`
+}
+
+// StatusPreview selects which plan sections 'status' shows and how many
+// lines. With no sections, the Goal section and unchecked tasks are shown.
+type StatusPreview struct {
+	Sections []string `json:"sections,omitempty"`
+	MaxLines int      `json:"maxLines,omitempty"`
+}
+
+// ClaudeOptions tune how the claude CLI generates summaries and plans,
+// beyond model and maxTurns. The CLI has no temperature setting, so there
+// is none here; ExtraArgs passes any other flag through as is.
+type ClaudeOptions struct {
+	// SystemPrompt is appended to Claude Code's system prompt
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+
+	// AllowedTools are the tools Claude may use without asking, such as
+	// "Read" or "Bash(git log:*)"
+	AllowedTools []string `json:"allowedTools,omitempty"`
+
+	// MaxOutputTokens caps each response; 0 uses the CLI's default
+	MaxOutputTokens int `json:"maxOutputTokens,omitempty"`
+
+	// ExtraArgs are added to every claude invocation
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+}
+
+// Notification sends events to a channel: desktop, slack, discord, teams,
+// or webhook. Secret is the secret store key ("notify.<name>") holding the
+// channel's URL, so it stays out of config.json; a Slack channel without
+// one uses the slackWebhook secret. URL is only read from configs written
+// before, and LoadFile moves it to the secret store. Events lists the
+// events it receives; empty means all of them.
+type Notification struct {
+	Channel string   `json:"channel"`
+	Secret  string   `json:"secret,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	Events  []string `json:"events,omitempty"`
+}
+
+// Preset bundles session settings for a recurring mob format
+type Preset struct {
+	RotationMinutes int      `json:"rotationMinutes,omitempty"`
+	Template        string   `json:"template,omitempty"`
+	AutoNext        *bool    `json:"autoNext,omitempty"`
+	TimerAlert      string   `json:"timerAlert,omitempty"`
+	Roster          []string `json:"roster,omitempty"`
+
+	// Notifications replace the configured notifications for sessions
+	// started with the preset. Each names its channel and, for channels
+	// that post to a URL, the notify.<name> secret holding it; presets
+	// never hold URLs themselves.
+	Notifications []Notification `json:"notifications,omitempty"`
+}
+
+// ScheduleBlock is a recurring block of mob time. Days are three-letter
+// weekday names (empty means every day); Start and End are local "15:04"
+// times on the same day. RotationMinutes and Roster set up sessions
+// started during the block.
+type ScheduleBlock struct {
+	Name            string   `json:"name,omitempty"`
+	Days            []string `json:"days,omitempty"`
+	Start           string   `json:"start"`
+	End             string   `json:"end"`
+	RotationMinutes int      `json:"rotationMinutes,omitempty"`
+	Roster          []string `json:"roster,omitempty"`
+}
+
+// Apply overrides the config's session settings with those set in the preset
+func (p Preset) Apply(cfg *Config) {
+	if p.RotationMinutes > 0 {
+		cfg.RotationMinutes = p.RotationMinutes
+	}
+	if p.AutoNext != nil {
+		cfg.AutoNext = *p.AutoNext
+	}
+	if p.TimerAlert != "" {
+		cfg.TimerAlert = p.TimerAlert
+	}
+	if len(p.Notifications) > 0 {
+		cfg.Notifications = p.Notifications
+	}
 }
 
 // CurrentSession holds the current mob session metadata
 type CurrentSession struct {
-	Branch      string `json:"branch"`
-	RepoURL     string `json:"repoUrl"`
-	StartedAt   string `json:"startedAt"`
-	DriverName  string `json:"driverName"`
+	// Version is the current.json schema version, see SessionVersion
+	Version int `json:"version"`
+
+	Branch       string `json:"branch"`
+	RepoURL      string `json:"repoUrl"`
+	StartedAt    string `json:"startedAt"`
+	DriverName   string `json:"driverName"`
 	WorkstreamID string `json:"workstreamId,omitempty"`
+
+	// TimerDeadline is when the current rotation ends (RFC3339), if a timer is set
+	TimerDeadline string `json:"timerDeadline,omitempty"`
+
+	// PendingRegistration is set when the dashboard was unreachable at start
+	// and the workstream still needs to be registered
+	PendingRegistration bool `json:"pendingRegistration,omitempty"`
+
+	// Offline and ClaudeUnavailable record that the dashboard or the Claude
+	// CLI was down at start, so later commands queue uploads and build
+	// summaries from notes instead of retrying them
+	Offline           bool `json:"offline,omitempty"`
+	ClaudeUnavailable bool `json:"claudeUnavailable,omitempty"`
+
+	// Notes are journal entries the driver recorded during the rotation
+	Notes []SessionNote `json:"notes,omitempty"`
+
+	// NavNotes are observations navigators recorded with 'mob-claude nav'
+	NavNotes []SessionNote `json:"navNotes,omitempty"`
+
+	// Preset and Roster record the preset the session was started with
+	Preset string   `json:"preset,omitempty"`
+	Roster []string `json:"roster,omitempty"`
+
+	// ClaudeSessionID is the driver's Claude Code conversation, captured by
+	// 'mob-claude claude-hook' when Claude Code starts a session
+	ClaudeSessionID string `json:"claudeSessionId,omitempty"`
+}
+
+// SessionNote is a timestamped note recorded with 'mob-claude note' or
+// 'mob-claude nav'. Author is the navigator who made an observation, if given.
+type SessionNote struct {
+	Time   string `json:"time"`
+	Text   string `json:"text"`
+	Author string `json:"author,omitempty"`
 }
 
 // DefaultConfig returns a config with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
+		Version:     ConfigVersion,
 		APIURL:      "http://localhost:3000",
 		TeamName:    "",
 		Model:       "haiku",
@@ -56,14 +309,40 @@ func EnsureConfigDir() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
 		return "", err
 	}
 	return dir, nil
 }
 
-// Load reads the config from disk, or returns defaults if not found
+// FileExists reports whether the project has a config.json
+func FileExists() bool {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, ConfigFileName))
+	return err == nil
+}
+
+// Load reads the config from disk, or returns defaults if not found, with
+// the active profile applied and secrets filled in
 func Load() (*Config, error) {
+	cfg, err := LoadFile()
+	if err != nil {
+		return nil, err
+	}
+	if err := applyProfile(cfg); err != nil {
+		return nil, err
+	}
+	loadSecrets(cfg)
+	return cfg, nil
+}
+
+// LoadFile reads config.json as saved, without applying a profile or
+// loading secrets. Use it when the config will be saved back. A config.json
+// from an older version is upgraded in place.
+func LoadFile() (*Config, error) {
 	dir, err := GetConfigDir()
 	if err != nil {
 		return DefaultConfig(), nil
@@ -78,6 +357,30 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	data, migrated, err := MigrateConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if moved := moveNotificationURLs(cfg); moved || migrated {
+		// Upgrade the file for next time; if it can't be written, the
+		// migrated settings still apply to this run
+		_ = Save(cfg)
+	}
+	return cfg, nil
+}
+
+// Parse reads config.json content, migrating it to ConfigVersion and
+// filling in defaults for missing fields
+func Parse(data []byte) (*Config, error) {
+	data, _, err := MigrateConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{}
 	if err := json.Unmarshal(data, cfg); err != nil {
 		return nil, err
@@ -92,7 +395,14 @@ func Load() (*Config, error) {
 		cfg.MaxTurns = defaults.MaxTurns
 	}
 	if cfg.APIURL == "" {
-		cfg.APIURL = defaults.APIURL
+		// An apiUrl saved empty turns the dashboard off; only a missing
+		// one gets the default
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err == nil {
+			if _, set := fields["apiUrl"]; !set {
+				cfg.APIURL = defaults.APIURL
+			}
+		}
 	}
 
 	return cfg, nil
@@ -105,16 +415,18 @@ func Save(cfg *Config) error {
 		return err
 	}
 
+	cfg.Version = ConfigVersion
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return err
 	}
 
 	configPath := filepath.Join(dir, ConfigFileName)
-	return os.WriteFile(configPath, data, 0644)
+	return os.WriteFile(configPath, data, platform.FilePerm)
 }
 
-// LoadCurrentSession reads the current session metadata
+// LoadCurrentSession reads the current session metadata, upgrading a
+// session file from an older version in place
 func LoadCurrentSession() (*CurrentSession, error) {
 	dir, err := GetConfigDir()
 	if err != nil {
@@ -130,10 +442,18 @@ func LoadCurrentSession() (*CurrentSession, error) {
 		return nil, err
 	}
 
+	data, migrated, err := migrateSession(data)
+	if err != nil {
+		return nil, err
+	}
+
 	session := &CurrentSession{}
 	if err := json.Unmarshal(data, session); err != nil {
 		return nil, err
 	}
+	if migrated {
+		_ = SaveCurrentSession(session)
+	}
 
 	return session, nil
 }
@@ -145,13 +465,14 @@ func SaveCurrentSession(session *CurrentSession) error {
 		return err
 	}
 
+	session.Version = SessionVersion
 	data, err := json.MarshalIndent(session, "", "  ")
 	if err != nil {
 		return err
 	}
 
 	sessionPath := filepath.Join(dir, CurrentFile)
-	return os.WriteFile(sessionPath, data, 0644)
+	return os.WriteFile(sessionPath, data, platform.FilePerm)
 }
 
 // ClearCurrentSession removes the current session file
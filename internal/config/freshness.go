@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// FreshnessFile records when local data was last synced with the dashboard
+const FreshnessFile = "freshness.json"
+
+// Freshness tracks how current the locally displayed data is
+type Freshness struct {
+	// PlanSynced is when each branch's plan was last fetched from or pushed
+	// to the dashboard
+	PlanSynced map[string]time.Time `json:"planSynced,omitempty"`
+
+	// DashboardContact is the last time the dashboard answered a request
+	DashboardContact time.Time `json:"dashboardContact"`
+
+	// Heartbeat is the last time a heartbeat reached the dashboard
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+// LoadFreshness reads the freshness metadata, returning an empty record if
+// none has been written yet
+func LoadFreshness() (*Freshness, error) {
+	f := &Freshness{}
+	dir, err := GetConfigDir()
+	if err != nil {
+		return f, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, FreshnessFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return f, nil
+		}
+		return f, err
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return &Freshness{}, err
+	}
+	return f, nil
+}
+
+// freshnessMu serializes updates, which start makes from concurrent requests
+var freshnessMu sync.Mutex
+
+// UpdateFreshness applies update to the stored freshness metadata. The file
+// is replaced with a rename, so a reader never sees it half written.
+func UpdateFreshness(update func(*Freshness)) error {
+	freshnessMu.Lock()
+	defer freshnessMu.Unlock()
+
+	f, _ := LoadFreshness()
+	update(f)
+
+	dir, err := EnsureConfigDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, FreshnessFile+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), platform.FilePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, FreshnessFile))
+}
+
+// RecordPlanSync notes that branch's plan was just synced with the dashboard
+func RecordPlanSync(branch string, at time.Time) error {
+	return UpdateFreshness(func(f *Freshness) {
+		if f.PlanSynced == nil {
+			f.PlanSynced = make(map[string]time.Time)
+		}
+		f.PlanSynced[branch] = at
+		f.DashboardContact = at
+	})
+}
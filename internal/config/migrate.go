@@ -0,0 +1,131 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Schema versions this build reads and writes. A file without a version
+// predates versioning and counts as version 0.
+const (
+	ConfigVersion  = 2
+	SessionVersion = 1
+)
+
+// ErrNewerVersion is returned for a file written by a newer mob-claude,
+// which this build can't read without losing settings
+var ErrNewerVersion = errors.New("written by a newer version of mob-claude")
+
+// migration upgrades a file's JSON object from one schema version to the
+// next, editing its top-level keys in place
+type migration func(doc map[string]json.RawMessage) error
+
+// configMigrations[i] upgrades config.json from version i to i+1, so there
+// are always ConfigVersion of them
+var configMigrations = []migration{
+	// 0 → 1: the first versioned schema only adds the version itself
+	func(map[string]json.RawMessage) error { return nil },
+	// 1 → 2: webhooks become notifications on the webhook channel
+	mergeWebhooks,
+}
+
+// sessionMigrations[i] upgrades current.json from version i to i+1
+var sessionMigrations = []migration{
+	// 0 → 1: the first versioned schema only adds the version itself
+	func(map[string]json.RawMessage) error { return nil },
+}
+
+// webhookEvents maps the events webhooks subscribed to before they became
+// a notification channel to the notification events
+var webhookEvents = map[string]string{
+	"session-started":    "session-started",
+	"rotation-completed": "handoff",
+	"session-done":       "done",
+}
+
+// mergeWebhooks moves each "webhooks" entry into "notifications" as a
+// webhook channel with the same URL and events
+func mergeWebhooks(doc map[string]json.RawMessage) error {
+	raw, ok := doc["webhooks"]
+	if !ok {
+		return nil
+	}
+	var hooks []struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events,omitempty"`
+	}
+	if err := json.Unmarshal(raw, &hooks); err != nil {
+		return fmt.Errorf("invalid webhooks: %w", err)
+	}
+	var notifications []map[string]any
+	if raw, ok := doc["notifications"]; ok {
+		if err := json.Unmarshal(raw, &notifications); err != nil {
+			return fmt.Errorf("invalid notifications: %w", err)
+		}
+	}
+	for _, hook := range hooks {
+		n := map[string]any{"channel": "webhook", "url": hook.URL}
+		if len(hook.Events) > 0 {
+			var events []string
+			for _, event := range hook.Events {
+				if mapped, ok := webhookEvents[event]; ok {
+					event = mapped
+				}
+				events = append(events, event)
+			}
+			n["events"] = events
+		}
+		notifications = append(notifications, n)
+	}
+	delete(doc, "webhooks")
+	if len(notifications) > 0 {
+		doc["notifications"], _ = json.Marshal(notifications)
+	}
+	return nil
+}
+
+// MigrateConfig upgrades config.json content to ConfigVersion, reporting
+// whether anything changed
+func MigrateConfig(data []byte) ([]byte, bool, error) {
+	return migrate(data, "config.json", ConfigVersion, configMigrations)
+}
+
+// migrateSession upgrades current.json content to SessionVersion
+func migrateSession(data []byte) ([]byte, bool, error) {
+	return migrate(data, CurrentFile, SessionVersion, sessionMigrations)
+}
+
+// migrate runs the migrations from the file's version up to target and
+// stamps it with target. Content already at target is returned unchanged.
+func migrate(data []byte, name string, target int, migrations []migration) ([]byte, bool, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, false, err
+	}
+
+	version := 0
+	if raw, ok := doc["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, false, fmt.Errorf("%s has an invalid version: %s", name, raw)
+		}
+	}
+	switch {
+	case version > target:
+		return nil, false, fmt.Errorf("%s is version %d, %w. Upgrade to read it (this one reads version %d)", name, version, ErrNewerVersion, target)
+	case version == target:
+		return data, false, nil
+	}
+
+	for v := version; v < target; v++ {
+		if err := migrations[v](doc); err != nil {
+			return nil, false, fmt.Errorf("failed to migrate %s from version %d: %w", name, v, err)
+		}
+	}
+	doc["version"], _ = json.Marshal(target)
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, false, err
+	}
+	return migrated, true, nil
+}
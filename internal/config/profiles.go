@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// ProfileEnv selects a config profile for a single invocation
+const ProfileEnv = "MOB_CLAUDE_PROFILE"
+
+// Profile holds the dashboard and model settings for one team, so the same
+// project can report to several dashboards
+type Profile struct {
+	APIURL   string `json:"apiUrl,omitempty"`
+	TeamName string `json:"teamName,omitempty"`
+	Model    string `json:"model,omitempty"`
+}
+
+// Apply overrides the config's dashboard settings with those set in the profile
+func (p Profile) Apply(cfg *Config) {
+	if p.APIURL != "" {
+		cfg.APIURL = p.APIURL
+	}
+	if p.TeamName != "" {
+		cfg.TeamName = p.TeamName
+	}
+	if p.Model != "" {
+		cfg.Model = p.Model
+	}
+}
+
+// profileOverride is the profile chosen with --profile, if any
+var profileOverride string
+
+// SetProfileOverride selects a profile for this invocation, taking
+// precedence over MOB_CLAUDE_PROFILE and the profile saved in config
+func SetProfileOverride(name string) {
+	profileOverride = name
+}
+
+// ActiveProfile returns the name of the profile in effect: --profile, then
+// MOB_CLAUDE_PROFILE, then the profile saved with 'profile use'
+func ActiveProfile(cfg *Config) string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	if name := os.Getenv(ProfileEnv); name != "" {
+		return name
+	}
+	return cfg.Profile
+}
+
+// ProfileNames returns the configured profile names in sorted order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckProfile returns an error if the active profile isn't one of cfg's
+func CheckProfile(cfg *Config) error {
+	name := ActiveProfile(cfg)
+	if _, ok := cfg.Profiles[name]; name != "" && !ok {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+	return nil
+}
+
+// applyProfile overlays the active profile onto cfg
+func applyProfile(cfg *Config) error {
+	if err := CheckProfile(cfg); err != nil {
+		return err
+	}
+	if name := ActiveProfile(cfg); name != "" {
+		cfg.Profiles[name].Apply(cfg)
+	}
+	return nil
+}
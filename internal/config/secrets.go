@@ -0,0 +1,184 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/secrets"
+)
+
+// SecretKeys are config keys stored in the secret store instead of config.json
+var SecretKeys = []string{"apiToken", "slackWebhook", "signingSecret", "encryptionKey"}
+
+// NotifySecretPrefix starts the secret store keys of notification URLs,
+// "notify.<name>"
+const NotifySecretPrefix = "notify."
+
+// IsSecretKey reports whether a config key holds a secret. Profiles have
+// their own apiToken and signingSecret, set as profiles.<name>.apiToken and
+// profiles.<name>.signingSecret, and each notification channel's URL is
+// set as notify.<name>.
+func IsSecretKey(key string) bool {
+	if _, field, ok := SplitProfileKey(key); ok {
+		return field == "apiToken" || field == "signingSecret"
+	}
+	if IsNotifySecret(key) {
+		return true
+	}
+	for _, k := range SecretKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// secretStore returns the secret store scoped to the current project
+func secretStore() (secrets.Store, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.Default(dir), nil
+}
+
+// loadSecrets fills the secret fields of cfg, leaving them empty if unavailable
+func loadSecrets(cfg *Config) {
+	store, err := secretStore()
+	if err != nil {
+		return
+	}
+	cfg.APIToken, _ = store.Get("apiToken")
+	cfg.SlackWebhook, _ = store.Get("slackWebhook")
+	cfg.SigningSecret, _ = store.Get("signingSecret")
+	cfg.EncryptionKey, _ = store.Get("encryptionKey")
+
+	// A profile's own token and signing secret win over the project-wide ones
+	if name := ActiveProfile(cfg); name != "" {
+		if token, err := store.Get(profileSecretKey(name, "apiToken")); err == nil && token != "" {
+			cfg.APIToken = token
+		}
+		if secret, err := store.Get(profileSecretKey(name, "signingSecret")); err == nil && secret != "" {
+			cfg.SigningSecret = secret
+		}
+	}
+}
+
+// SplitProfileKey splits a "profiles.<name>.<field>" config key
+func SplitProfileKey(key string) (name, field string, ok bool) {
+	rest, found := strings.CutPrefix(key, "profiles.")
+	if !found {
+		return "", "", false
+	}
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 || dot == len(rest)-1 {
+		return "", "", false
+	}
+	return rest[:dot], rest[dot+1:], true
+}
+
+// profileSecretKey is the secret store key for a profile's secret field
+func profileSecretKey(profile, field string) string {
+	return "profiles." + profile + "." + field
+}
+
+// IsNotifySecret reports whether key is a notification URL's secret key
+func IsNotifySecret(key string) bool {
+	name, ok := strings.CutPrefix(key, NotifySecretPrefix)
+	return ok && name != ""
+}
+
+// GetSecret returns a secret config value, or "" if it isn't set
+func GetSecret(key string) (string, error) {
+	if !IsSecretKey(key) {
+		return "", errors.New("not a secret key: " + key)
+	}
+	store, err := secretStore()
+	if err != nil {
+		return "", err
+	}
+	value, err := store.Get(key)
+	if errors.Is(err, secrets.ErrNotFound) {
+		return "", nil
+	}
+	return value, err
+}
+
+// moveNotificationURLs moves the URLs of notifications configured before
+// they were secrets into the secret store, as notify.<channel>, and
+// reports whether any moved. A URL that can't be stored stays where it is.
+func moveNotificationURLs(cfg *Config) bool {
+	var store secrets.Store
+	moved := false
+	for i, n := range cfg.Notifications {
+		if n.URL == "" || n.Secret != "" {
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = secretStore(); err != nil {
+				return false
+			}
+		}
+		key := unusedNotifySecret(cfg.Notifications, n.Channel)
+		if err := store.Set(key, n.URL); err != nil {
+			return moved
+		}
+		cfg.Notifications[i].Secret, cfg.Notifications[i].URL = key, ""
+		moved = true
+	}
+	return moved
+}
+
+// unusedNotifySecret returns notify.<name>, or notify.<name>-2 and so on
+// if a notification already uses it
+func unusedNotifySecret(notifications []Notification, name string) string {
+	key := NotifySecretPrefix + name
+	for i := 2; slices.ContainsFunc(notifications, func(n Notification) bool { return n.Secret == key }); i++ {
+		key = fmt.Sprintf("%s%s-%d", NotifySecretPrefix, name, i)
+	}
+	return key
+}
+
+// SetSecret stores a secret config value; an empty value removes it
+func SetSecret(key, value string) error {
+	if !IsSecretKey(key) {
+		return errors.New("not a secret key: " + key)
+	}
+	store, err := secretStore()
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return store.Delete(key)
+	}
+	return store.Set(key, value)
+}
+
+// pairingTokenKey is the secret store key for the daemon's pairing token
+const pairingTokenKey = "pairingToken"
+
+// PairingToken returns the daemon's pairing token. If none is stored, or
+// rotate is set, a new token from newToken is stored and created is true.
+func PairingToken(newToken func() string, rotate bool) (token string, created bool, err error) {
+	store, err := secretStore()
+	if err != nil {
+		return "", false, err
+	}
+	if !rotate {
+		token, err = store.Get(pairingTokenKey)
+		if err == nil && token != "" {
+			return token, false, nil
+		}
+		if err != nil && !errors.Is(err, secrets.ErrNotFound) {
+			return "", false, err
+		}
+	}
+	token = newToken()
+	if err := store.Set(pairingTokenKey, token); err != nil {
+		return "", false, err
+	}
+	return token, true, nil
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// SessionTemplatesDir holds the session templates, one <name>.json each,
+// inside the config directory
+const SessionTemplatesDir = "session-templates"
+
+// SessionTemplate is a reusable setup for a recurring mob format, such as
+// a bug triage or onboarding mob. Unlike a preset it carries its plan
+// template's text and its notification channels, so it can be shared as a
+// single file.
+type SessionTemplate struct {
+	RotationMinutes int      `json:"rotationMinutes,omitempty"`
+	AutoNext        *bool    `json:"autoNext,omitempty"`
+	TimerAlert      string   `json:"timerAlert,omitempty"`
+	Roster          []string `json:"roster,omitempty"`
+
+	// Plan is the plan template, checklists included, that sessions
+	// started with the template begin from
+	Plan string `json:"plan,omitempty"`
+
+	// Notifications are the channels' names (their Secret keys) and
+	// events, never their URLs, since the file is meant to be shared
+	Notifications []Notification `json:"notifications,omitempty"`
+}
+
+// Preset returns the session settings of the template as a preset whose
+// plan template is named template
+func (t *SessionTemplate) Preset(template string) Preset {
+	p := Preset{
+		RotationMinutes: t.RotationMinutes,
+		AutoNext:        t.AutoNext,
+		TimerAlert:      t.TimerAlert,
+		Roster:          t.Roster,
+	}
+	if t.Plan != "" {
+		p.Template = template
+	}
+	return p
+}
+
+// sessionTemplatePath returns the file of the named session template
+func sessionTemplatePath(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid session template name: %q", name)
+	}
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SessionTemplatesDir, name+".json"), nil
+}
+
+// LoadSessionTemplate reads the named session template. It returns nil if
+// there's no such template.
+func LoadSessionTemplate(name string) (*SessionTemplate, error) {
+	path, err := sessionTemplatePath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read session template: %w", err)
+	}
+	var t SessionTemplate
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse session template %s: %w", name, err)
+	}
+	return &t, nil
+}
+
+// SaveSessionTemplate writes the named session template, replacing any
+// template of that name
+func SaveSessionTemplate(name string, t *SessionTemplate) error {
+	path, err := sessionTemplatePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), platform.DirPerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), platform.FilePerm)
+}
+
+// RemoveSessionTemplate deletes the named session template
+func RemoveSessionTemplate(name string) error {
+	path, err := sessionTemplatePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no session template named %s", name)
+		}
+		return err
+	}
+	return nil
+}
+
+// SessionTemplateNames returns the names of the saved session templates in
+// sorted order
+func SessionTemplateNames() ([]string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, SessionTemplatesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Unset removes a setting from config.json so it falls back to its default.
+// The key is a dotted path, such as "statusPreview.maxLines" or
+// "profiles.<name>.model". It reports whether config.json had the key;
+// secrets are removed from the secret store instead, and always report true.
+func Unset(key string) (bool, error) {
+	if IsSecretKey(key) {
+		return true, SetSecret(key, "")
+	}
+
+	data, err := LoadRaw()
+	if err != nil || data == nil {
+		return false, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return false, err
+	}
+
+	path := strings.Split(key, ".")
+	if name, field, ok := SplitProfileKey(key); ok {
+		path = []string{"profiles", name, field}
+	}
+	if !deletePath(raw, path) {
+		return false, nil
+	}
+
+	// Round-trip through Config so the file keeps its usual layout and defaults
+	if data, err = json.Marshal(raw); err != nil {
+		return false, err
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return false, err
+	}
+	return true, Save(cfg)
+}
+
+// deletePath removes the value at path from nested JSON objects, reporting
+// whether it was there
+func deletePath(m map[string]interface{}, path []string) bool {
+	if len(path) == 1 {
+		_, ok := m[path[0]]
+		delete(m, path[0])
+		return ok
+	}
+	child, ok := m[path[0]].(map[string]interface{})
+	return ok && deletePath(child, path[1:])
+}
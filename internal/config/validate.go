@@ -0,0 +1,367 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/events"
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// KnownModels are the model aliases accepted by the Claude CLI.
+// Full model IDs starting with "claude-" are accepted as well.
+var KnownModels = []string{"haiku", "sonnet", "opus"}
+
+// Allowed ranges for numeric settings
+const (
+	MinMaxTurns        = 1
+	MaxMaxTurns        = 20
+	MaxRotationMinutes = 240
+	MaxHTTPTimeout     = 600
+	MaxSummaryRetries  = 5
+	MaxSummaryContext  = 10
+)
+
+// Problem is a single config validation failure
+type Problem struct {
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Key, p.Message)
+}
+
+// Validate checks config values for syntax and range errors
+func Validate(cfg *Config) []Problem {
+	var problems []Problem
+	add := func(key, format string, args ...interface{}) {
+		problems = append(problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if cfg.APIURL != "" {
+		u, err := url.Parse(cfg.APIURL)
+		if err != nil {
+			add("apiUrl", "invalid URL: %v", err)
+		} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("apiUrl", "must be an absolute http(s) URL, got %q", cfg.APIURL)
+		}
+	}
+
+	if !IsKnownModel(cfg.Model) {
+		add("model", "unknown model %q (expected one of %s, or a claude-* model ID)", cfg.Model, strings.Join(KnownModels, ", "))
+	}
+
+	if cfg.MaxTurns < MinMaxTurns || cfg.MaxTurns > MaxMaxTurns {
+		add("maxTurns", "must be between %d and %d, got %d", MinMaxTurns, MaxMaxTurns, cfg.MaxTurns)
+	}
+
+	if cfg.RotationMinutes < 0 || cfg.RotationMinutes > MaxRotationMinutes {
+		add("rotationMinutes", "must be between 0 and %d, got %d", MaxRotationMinutes, cfg.RotationMinutes)
+	}
+
+	if !isTimerAlert(cfg.TimerAlert) {
+		add("timerAlert", "must be bell, flash, or none, got %q", cfg.TimerAlert)
+	}
+
+	if cfg.HTTPTimeout < 0 || cfg.HTTPTimeout > MaxHTTPTimeout {
+		add("httpTimeout", "must be between 0 and %d seconds, got %d", MaxHTTPTimeout, cfg.HTTPTimeout)
+	}
+
+	if cfg.HTTPProxy != "" {
+		if u, err := url.Parse(cfg.HTTPProxy); err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+			add("httpProxy", "must be an absolute http, https, or socks5 URL, got %q", cfg.HTTPProxy)
+		}
+	}
+
+	if cfg.CABundle != "" {
+		if _, err := os.Stat(cfg.CABundle); err != nil {
+			add("caBundle", "cannot read %s: %v", cfg.CABundle, err)
+		}
+	}
+
+	if cfg.MobPath != "" {
+		if _, err := platform.FindExecutable(cfg.MobPath); err != nil {
+			add("mobPath", "%s is not an executable: %v", cfg.MobPath, err)
+		}
+	}
+	if cfg.Engine != "" && cfg.Engine != "mob.sh" && cfg.Engine != "internal" {
+		add("engine", "must be mob.sh or internal, got %q", cfg.Engine)
+	}
+
+	if cfg.Storage != "" {
+		if u, err := url.Parse(cfg.Storage); err != nil || (u.Scheme != "s3" && u.Scheme != "gs") || u.Host == "" {
+			add("storage", "must be an s3://bucket/prefix or gs://bucket/prefix URL, got %q", cfg.Storage)
+		}
+	}
+	if cfg.StorageEndpoint != "" {
+		if u, err := url.Parse(cfg.StorageEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("storageEndpoint", "must be an absolute http(s) URL, got %q", cfg.StorageEndpoint)
+		}
+	}
+
+	for _, artifact := range cfg.UntrackedArtifacts {
+		if !IsArtifact(artifact) {
+			add("untrackedArtifacts", "unknown artifact %q (expected %s)", artifact, strings.Join(Artifacts, ", "))
+		}
+	}
+
+	if cfg.KeepSummariesDays < 0 {
+		add("keepSummariesDays", "must be 0 or more, got %d", cfg.KeepSummariesDays)
+	}
+	if cfg.KeepArchives < 0 {
+		add("keepArchives", "must be 0 or more, got %d", cfg.KeepArchives)
+	}
+
+	if r := cfg.SummaryRetries; r != nil && (*r < 0 || *r > MaxSummaryRetries) {
+		add("summaryRetries", "must be between 0 and %d, got %d", MaxSummaryRetries, *r)
+	}
+
+	if n := cfg.SummaryContext; n != nil && (*n < 0 || *n > MaxSummaryContext) {
+		add("summaryContext", "must be between 0 and %d, got %d", MaxSummaryContext, *n)
+	}
+
+	for _, pattern := range cfg.RedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			add("redactPatterns", "invalid regexp %q: %v", pattern, err)
+		}
+	}
+
+	if c := cfg.Claude; c != nil && c.MaxOutputTokens < 0 {
+		add("claude.maxOutputTokens", "must be 0 or more, got %d", c.MaxOutputTokens)
+	}
+
+	if p := cfg.StatusPreview; p != nil && p.MaxLines < 0 {
+		add("statusPreview.maxLines", "must be 0 or more, got %d", p.MaxLines)
+	}
+
+	checkNotification := func(key string, n Notification) {
+		if !events.IsChannel(n.Channel) {
+			add(key+".channel", "unknown channel %q (expected one of %s)", n.Channel, strings.Join(events.Channels, ", "))
+		}
+		if n.Secret != "" && !IsNotifySecret(n.Secret) {
+			add(key+".secret", "must be a %s<name> key, got %q", NotifySecretPrefix, n.Secret)
+		}
+		if n.URL != "" {
+			add(key+".url", "is stored in plaintext in config.json; move it to the secret store with 'mob-claude notify add %s --url <url>'", n.Channel)
+		} else if n.Secret == "" && events.NeedsURL(n.Channel) {
+			add(key+".secret", "is required for %s notifications; add the URL with 'mob-claude notify add %s --url <url>'", n.Channel, n.Channel)
+		}
+		for _, event := range n.Events {
+			if !events.IsEvent(event) {
+				add(key+".events", "unknown event %q (expected one of %s)", event, strings.Join(events.Events, ", "))
+			}
+		}
+	}
+
+	for name, preset := range cfg.Presets {
+		key := "presets." + name
+		if preset.RotationMinutes < 0 || preset.RotationMinutes > MaxRotationMinutes {
+			add(key+".rotationMinutes", "must be between 0 and %d, got %d", MaxRotationMinutes, preset.RotationMinutes)
+		}
+		if !isTimerAlert(preset.TimerAlert) {
+			add(key+".timerAlert", "must be bell, flash, or none, got %q", preset.TimerAlert)
+		}
+		for i, n := range preset.Notifications {
+			checkNotification(fmt.Sprintf("%s.notifications[%d]", key, i), n)
+		}
+	}
+
+	if cfg.Profile != "" {
+		if _, ok := cfg.Profiles[cfg.Profile]; !ok {
+			add("profile", "unknown profile %q", cfg.Profile)
+		}
+	}
+	for name, profile := range cfg.Profiles {
+		key := "profiles." + name
+		if profile.APIURL != "" {
+			if u, err := url.Parse(profile.APIURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				add(key+".apiUrl", "must be an absolute http(s) URL, got %q", profile.APIURL)
+			}
+		}
+		if profile.Model != "" && !IsKnownModel(profile.Model) {
+			add(key+".model", "unknown model %q (expected one of %s, or a claude-* model ID)", profile.Model, strings.Join(KnownModels, ", "))
+		}
+	}
+
+	for i, block := range cfg.Schedule {
+		key := fmt.Sprintf("schedule[%d]", i)
+		for _, day := range block.Days {
+			if !isWeekday(day) {
+				add(key+".days", "unknown day %q (expected mon, tue, wed, thu, fri, sat, or sun)", day)
+			}
+		}
+		start, startErr := time.Parse("15:04", block.Start)
+		if startErr != nil {
+			add(key+".start", "must be a time like 09:30, got %q", block.Start)
+		}
+		end, endErr := time.Parse("15:04", block.End)
+		if endErr != nil {
+			add(key+".end", "must be a time like 12:00, got %q", block.End)
+		}
+		if startErr == nil && endErr == nil && !end.After(start) {
+			add(key+".end", "must be after start (%s), got %q", block.Start, block.End)
+		}
+		if block.RotationMinutes < 0 {
+			add(key+".rotationMinutes", "must not be negative, got %d", block.RotationMinutes)
+		}
+	}
+
+	for i, n := range cfg.Notifications {
+		checkNotification(fmt.Sprintf("notifications[%d]", i), n)
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems
+}
+
+// IsKnownModel reports whether the model name is one the Claude CLI accepts
+func IsKnownModel(model string) bool {
+	if strings.HasPrefix(model, "claude-") {
+		return true
+	}
+	for _, known := range KnownModels {
+		if model == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isTimerAlert(alert string) bool {
+	switch alert {
+	case "", "bell", "flash", "none":
+		return true
+	}
+	return false
+}
+
+func isWeekday(day string) bool {
+	switch day {
+	case "mon", "tue", "wed", "thu", "fri", "sat", "sun":
+		return true
+	}
+	return false
+}
+
+// LoadRaw returns the raw contents of config.json, or nil if it doesn't exist
+func LoadRaw() ([]byte, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ConfigFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// UnknownKeys returns keys in raw config JSON that don't map to any
+// setting, once it's migrated to ConfigVersion
+func UnknownKeys(data []byte) ([]string, error) {
+	if migrated, _, err := MigrateConfig(data); err == nil {
+		data = migrated
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	known := jsonKeys(reflect.TypeOf(Config{}))
+	presetKeys := jsonKeys(reflect.TypeOf(Preset{}))
+	previewKeys := jsonKeys(reflect.TypeOf(StatusPreview{}))
+	claudeKeys := jsonKeys(reflect.TypeOf(ClaudeOptions{}))
+	profileKeys := jsonKeys(reflect.TypeOf(Profile{}))
+	notificationKeys := jsonKeys(reflect.TypeOf(Notification{}))
+	scheduleKeys := jsonKeys(reflect.TypeOf(ScheduleBlock{}))
+
+	var unknown []string
+	for key, value := range raw {
+		if !known[key] {
+			unknown = append(unknown, key)
+			continue
+		}
+		if key == "statusPreview" || key == "claude" {
+			nestedKeys := previewKeys
+			if key == "claude" {
+				nestedKeys = claudeKeys
+			}
+			var nested map[string]json.RawMessage
+			if err := json.Unmarshal(value, &nested); err != nil {
+				continue
+			}
+			for nestedKey := range nested {
+				if !nestedKeys[nestedKey] {
+					unknown = append(unknown, key+"."+nestedKey)
+				}
+			}
+			continue
+		}
+		if key == "notifications" || key == "schedule" {
+			entryKeys := notificationKeys
+			if key == "schedule" {
+				entryKeys = scheduleKeys
+			}
+			var entries []map[string]json.RawMessage
+			if err := json.Unmarshal(value, &entries); err != nil {
+				continue
+			}
+			for i, entry := range entries {
+				for entryKey := range entry {
+					if !entryKeys[entryKey] {
+						unknown = append(unknown, fmt.Sprintf("%s[%d].%s", key, i, entryKey))
+					}
+				}
+			}
+			continue
+		}
+		if key != "presets" && key != "profiles" {
+			continue
+		}
+
+		nestedKeys := presetKeys
+		if key == "profiles" {
+			nestedKeys = profileKeys
+		}
+		var entries map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(value, &entries); err != nil {
+			continue
+		}
+		for name, entry := range entries {
+			for entryKey := range entry {
+				if !nestedKeys[entryKey] {
+					unknown = append(unknown, key+"."+name+"."+entryKey)
+				}
+			}
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// jsonKeys returns the JSON field names of a struct type
+func jsonKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
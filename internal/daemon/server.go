@@ -0,0 +1,221 @@
+package daemon
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultPort is the localhost port the daemon listens on
+const DefaultPort = 7778
+
+// PortFile is the file, relative to the config directory, that holds the
+// port of the running daemon so clients can find it
+const PortFile = "daemon.port"
+
+// Deck states, from idle through an overdue rotation
+const (
+	StateIdle    = "idle"
+	StateRunning = "running"
+	StateNotice  = "notice"
+	StateUrgent  = "urgent"
+	StateExpired = "expired"
+	StateOverdue = "overdue"
+)
+
+// stateColors are the background colors deck buttons use for each state
+var stateColors = map[string]string{
+	StateIdle:    "#6b7280",
+	StateRunning: "#16a34a",
+	StateNotice:  "#ca8a04",
+	StateUrgent:  "#ea580c",
+	StateExpired: "#dc2626",
+	StateOverdue: "#7f1d1d",
+}
+
+// DeckStatus is the compact payload of GET /deck, sized for hardware button
+// displays such as a Stream Deck key
+type DeckStatus struct {
+	State    string `json:"state"`
+	Seconds  int    `json:"seconds"`
+	Initials string `json:"initials"`
+	Color    string `json:"color"`
+}
+
+// NewDeckStatus builds a deck payload, picking the color for state
+func NewDeckStatus(state string, seconds int, driver string) *DeckStatus {
+	return &DeckStatus{
+		State:    state,
+		Seconds:  seconds,
+		Initials: Initials(driver),
+		Color:    stateColors[state],
+	}
+}
+
+// Initials returns up to two uppercase initials for a name
+func Initials(name string) string {
+	var initials []rune
+	for _, word := range strings.Fields(name) {
+		for _, r := range word {
+			initials = append(initials, unicode.ToUpper(r))
+			break
+		}
+		if len(initials) == 2 {
+			break
+		}
+	}
+	return string(initials)
+}
+
+// TimerStatus is the payload of GET /timer
+type TimerStatus struct {
+	Active           bool       `json:"active"`
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	RemainingSeconds int        `json:"remainingSeconds"`
+	Message          string     `json:"message,omitempty"`
+
+	// Room is the mob.sh shared timer room's address, if the mob uses one
+	Room string `json:"room,omitempty"`
+}
+
+// Server exposes session controls over a localhost HTTP interface so stream
+// decks, Shortcuts, and desktop widgets can drive the rotation
+type Server struct {
+	// Token must be presented by clients as "Authorization: Bearer <token>"
+	// or a "token" query parameter
+	Token string
+
+	// Timer reports the current rotation timer
+	Timer func() (*TimerStatus, error)
+
+	// Deck reports the compact status for button displays
+	Deck func() (*DeckStatus, error)
+
+	// Next triggers the summary and handoff flow
+	Next func(message string) error
+}
+
+// Handler returns the HTTP handler serving the daemon endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/timer", s.handleTimer)
+	mux.HandleFunc("/deck", s.handleDeck)
+	mux.HandleFunc("/next", s.handleNext)
+	return s.requireToken(mux)
+}
+
+// Listen opens the daemon's listener on 127.0.0.1:port. Port 0 picks a
+// free port.
+func Listen(port int) (net.Listener, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	return listener, nil
+}
+
+// Serve handles requests on listener until ctx is cancelled
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	return serve(ctx, listener, s.Handler())
+}
+
+// serve runs handler on listener until ctx is cancelled
+func serve(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasToken(r, s.Token) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid or missing pairing token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hasToken reports whether r carries token, as "Authorization: Bearer
+// <token>" or a "token" query parameter. An empty token matches nothing.
+func hasToken(r *http.Request, token string) bool {
+	got := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		got = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return token != "" && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+func (s *Server) handleTimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use GET"})
+		return
+	}
+	status, err := s.Timer()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleDeck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use GET"})
+		return
+	}
+	status, err := s.Deck()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleNext(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use POST"})
+		return
+	}
+
+	var body struct {
+		Message string `json:"message"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+	}
+
+	if err := s.Next(body.Message); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "handed off"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
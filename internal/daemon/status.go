@@ -0,0 +1,193 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// DefaultStatusPort is the port 'mob-claude serve' listens on
+const DefaultStatusPort = 7777
+
+// DefaultSummaryLimit is how many summaries GET /summaries returns when no
+// limit is given
+const DefaultSummaryLimit = 20
+
+// SessionStatus is the payload of GET /status
+type SessionStatus struct {
+	Active    bool       `json:"active"`
+	Branch    string     `json:"branch,omitempty"`
+	Driver    string     `json:"driver,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	Roster    []string   `json:"roster,omitempty"`
+	Offline   bool       `json:"offline,omitempty"`
+
+	// TasksDone and TasksTotal count the plan's checkbox tasks; NextTask is
+	// the first open task that isn't waiting on another
+	TasksDone  int          `json:"tasksDone"`
+	TasksTotal int          `json:"tasksTotal"`
+	NextTask   *PlanTask    `json:"nextTask,omitempty"`
+	Timer      *TimerStatus `json:"timer,omitempty"`
+}
+
+// PlanTask is a checkbox task from the plan
+type PlanTask struct {
+	Number    int    `json:"number"`
+	Text      string `json:"text"`
+	Done      bool   `json:"done"`
+	DependsOn []int  `json:"dependsOn,omitempty"`
+	Owner     string `json:"owner,omitempty"`
+}
+
+// NewPlanTask converts a parsed plan task to its JSON form
+func NewPlanTask(task plans.Task) PlanTask {
+	return PlanTask{Number: task.Number, Text: task.Text, Done: task.Done, DependsOn: task.DependsOn, Owner: task.Owner}
+}
+
+// PlanStatus is the payload of GET /plan
+type PlanStatus struct {
+	Branch       string            `json:"branch"`
+	WorkstreamID string            `json:"workstreamId,omitempty"`
+	Ticket       string            `json:"ticket,omitempty"`
+	Owner        string            `json:"owner,omitempty"`
+	Markdown     string            `json:"markdown"`
+	Fields       map[string]string `json:"fields,omitempty"`
+	Tasks        []PlanTask        `json:"tasks"`
+}
+
+// SummaryList is the payload of GET /summaries, newest first
+type SummaryList struct {
+	Branch    string           `json:"branch"`
+	Summaries []*plans.Summary `json:"summaries"`
+}
+
+// StatusServer exposes the mob's state read-only over HTTP so editor
+// extensions, stream overlays, and office displays can poll it
+type StatusServer struct {
+	// Status reports the current session
+	Status func() (*SessionStatus, error)
+
+	// Plan reports the plan for branch, or the current branch's if empty
+	Plan func(branch string) (*PlanStatus, error)
+
+	// Summaries reports up to limit of branch's summaries, or the current
+	// branch's if empty
+	Summaries func(branch string, limit int) (*SummaryList, error)
+
+	// Timer reports the current rotation timer
+	Timer func() (*TimerStatus, error)
+
+	// AllowOrigins are the web origins, e.g. "http://localhost:3000", whose
+	// pages may read the server's responses; "*" allows any. Browsers keep
+	// other pages from reading them.
+	AllowOrigins []string
+
+	// Hosts are the host names and addresses, besides loopback ones, that
+	// requests may name in their Host header. Checking it keeps a web page
+	// from reaching the server through a DNS name rebound to 127.0.0.1.
+	Hosts []string
+
+	// Token, when set, must be presented as "Authorization: Bearer <token>"
+	// or a "token" query parameter
+	Token string
+}
+
+// Handler returns the HTTP handler serving the status endpoints
+func (s *StatusServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.readOnly(func(r *http.Request) (interface{}, error) {
+		return s.Status()
+	}))
+	mux.HandleFunc("/plan", s.readOnly(func(r *http.Request) (interface{}, error) {
+		return s.Plan(r.URL.Query().Get("branch"))
+	}))
+	mux.HandleFunc("/summaries", s.readOnly(func(r *http.Request) (interface{}, error) {
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = DefaultSummaryLimit
+		}
+		return s.Summaries(r.URL.Query().Get("branch"), limit)
+	}))
+	mux.HandleFunc("/timer", s.readOnly(func(r *http.Request) (interface{}, error) {
+		return s.Timer()
+	}))
+	return mux
+}
+
+// Serve handles requests on listener until ctx is cancelled
+func (s *StatusServer) Serve(ctx context.Context, listener net.Listener) error {
+	return serve(ctx, listener, s.Handler())
+}
+
+// readOnly serves GET requests with the JSON from fetch, letting pages from
+// AllowOrigins read it
+func (s *StatusServer) readOnly(fetch func(r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowsHost(r.Host) {
+			writeJSON(w, http.StatusMisdirectedRequest, map[string]string{"error": "unknown host"})
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); origin != "" && s.allowsOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if r.Method == http.MethodOptions {
+				// Preflight for a page sending the token in a header
+				w.Header().Set("Access-Control-Allow-Methods", "GET")
+				w.Header().Set("Access-Control-Allow-Headers", "Authorization")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		if s.Token != "" && !hasToken(r, s.Token) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid or missing token"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use GET"})
+			return
+		}
+		v, err := fetch(r)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, v)
+	}
+}
+
+// allowsHost reports whether a request's Host header names a loopback
+// address, localhost, or one of Hosts
+func (s *StatusServer) allowsHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	for _, allowed := range s.Hosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsOrigin reports whether origin is one of AllowOrigins
+func (s *StatusServer) allowsOrigin(origin string) bool {
+	for _, allowed := range s.AllowOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+// Package events names the session events notifications are routed for
+// and the channels they go to. It imports nothing, so config can validate
+// against the same lists notify delivers with.
+package events
+
+import "slices"
+
+// Session events a notification can be routed for
+const (
+	SessionStarted = "session-started"
+	TimerExpired   = "timer-expired"
+	Handoff        = "handoff"
+	Done           = "done"
+)
+
+// Events lists every session event, in the order they happen
+var Events = []string{SessionStarted, TimerExpired, Handoff, Done}
+
+// Notification channels
+const (
+	ChannelDesktop = "desktop"
+	ChannelSlack   = "slack"
+	ChannelDiscord = "discord"
+	ChannelTeams   = "teams"
+	ChannelWebhook = "webhook"
+)
+
+// Channels lists every notification channel
+var Channels = []string{ChannelDesktop, ChannelSlack, ChannelDiscord, ChannelTeams, ChannelWebhook}
+
+// IsEvent reports whether name is a known session event
+func IsEvent(name string) bool {
+	return slices.Contains(Events, name)
+}
+
+// IsChannel reports whether name is a known notification channel
+func IsChannel(name string) bool {
+	return slices.Contains(Channels, name)
+}
+
+// NeedsURL reports whether a channel posts to a URL that has to be
+// configured: every channel but the desktop, and Slack, which falls back
+// to the slackWebhook secret
+func NeedsURL(channel string) bool {
+	return channel != ChannelDesktop && channel != ChannelSlack
+}
@@ -0,0 +1,128 @@
+package handoff
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+)
+
+// PassphraseEnv holds the passphrase for encrypted handoff bundles
+const PassphraseEnv = "MOB_CLAUDE_HANDOFF_PASSPHRASE"
+
+// ageHeader starts every age-encrypted file
+const ageHeader = "age-encryption.org/"
+
+// ErrEncrypted is returned by Decode for an encrypted bundle when no
+// passphrase was given
+var ErrEncrypted = errors.New("handoff is encrypted")
+
+// ErrNotEncrypted is returned by Decode for a plain bundle when a
+// passphrase was given: a mob that encrypts its handoffs doesn't accept
+// one that anyone with dashboard access could have left
+var ErrNotEncrypted = errors.New("handoff is not encrypted")
+
+// Bundle is the context one driver passes to the next when they don't
+// share a machine
+type Bundle struct {
+	Branch string    `json:"branch"`
+	From   string    `json:"from"`
+	SentAt time.Time `json:"sentAt"`
+
+	// To is the driver the bundle is meant for; "" means whoever starts next
+	To string `json:"to,omitempty"`
+
+	// Brief is the handoff brief (.claude/mob/handoff.md)
+	Brief string `json:"brief,omitempty"`
+
+	// RotationMinutes is the sender's rotation length, and TimerDeadline
+	// the end of a rotation still under way when the bundle was sent
+	RotationMinutes int        `json:"rotationMinutes,omitempty"`
+	TimerDeadline   *time.Time `json:"timerDeadline,omitempty"`
+
+	// ClaudeSessionID and Transcript are the sender's Claude Code
+	// conversation and its JSONL transcript, so it can be resumed
+	ClaudeSessionID string `json:"claudeSessionId,omitempty"`
+	Transcript      []byte `json:"transcript,omitempty"`
+}
+
+// IsFor reports whether the bundle is meant for driver
+func (b *Bundle) IsFor(driver string) bool {
+	return b.To == "" || strings.EqualFold(strings.TrimSpace(b.To), strings.TrimSpace(driver))
+}
+
+// Encode serializes and compresses a bundle, encrypting it with passphrase
+// if one is given
+func Encode(b *Bundle, passphrase string) ([]byte, error) {
+	var buf bytes.Buffer
+	var dst io.Writer = &buf
+	var encrypted io.WriteCloser
+	if passphrase != "" {
+		recipient, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		if encrypted, err = age.Encrypt(&buf, recipient); err != nil {
+			return nil, fmt.Errorf("failed to encrypt handoff: %w", err)
+		}
+		dst = encrypted
+	}
+
+	zw := gzip.NewWriter(dst)
+	if err := json.NewEncoder(zw).Encode(b); err != nil {
+		return nil, fmt.Errorf("failed to encode handoff: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	if encrypted != nil {
+		if err := encrypted.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encrypt handoff: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// IsEncrypted reports whether an encoded bundle needs a passphrase
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageHeader))
+}
+
+// Decode reverses Encode. An encrypted bundle needs its passphrase; without
+// one, Decode returns ErrEncrypted. Given a passphrase, Decode returns
+// ErrNotEncrypted for a plain bundle.
+func Decode(data []byte, passphrase string) (*Bundle, error) {
+	var src io.Reader = bytes.NewReader(data)
+	if !IsEncrypted(data) && passphrase != "" {
+		return nil, ErrNotEncrypted
+	}
+	if IsEncrypted(data) {
+		if passphrase == "" {
+			return nil, ErrEncrypted
+		}
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		if src, err = age.Decrypt(src, identity); err != nil {
+			return nil, fmt.Errorf("failed to decrypt handoff (wrong passphrase?): %w", err)
+		}
+	}
+
+	zr, err := gzip.NewReader(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read handoff: %w", err)
+	}
+	defer zr.Close()
+	var b Bundle
+	if err := json.NewDecoder(zr).Decode(&b); err != nil {
+		return nil, fmt.Errorf("failed to decode handoff: %w", err)
+	}
+	return &b, nil
+}
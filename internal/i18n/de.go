@@ -0,0 +1,512 @@
+package i18n
+
+// german is the built-in German catalog, keyed by the English message
+var german = map[string]string{
+	// internal mob engine
+	"Joined the mob session on %s\n":                                  "Der Mob-Session auf %s beigetreten\n",
+	"Started a mob session on %s from %s\n":                           "Mob-Session auf %s von %s aus gestartet\n",
+	"Handed off, staying on %s\n":                                     "Übergeben, bleibe auf %s\n",
+	"Handed off, back on %s\n":                                        "Übergeben, zurück auf %s\n",
+	"Merged %s into %s\n":                                             "%s in %s gemergt\n",
+	"The session's changes are staged on %s; commit them to finish\n": "Die Änderungen der Session sind auf %s gestaged; committe sie zum Abschluss\n",
+	"No mob session on %s\n":                                          "Keine Mob-Session auf %s\n",
+	"Mob session on %s (WIP branch %s)\n":                             "Mob-Session auf %s (WIP-Branch %s)\n",
+
+	// start
+	"Starting mob session...":                    "Starte Mob-Session...",
+	"Fetched plan from dashboard":                "Plan vom Dashboard geladen",
+	"Synced plan from dashboard":                 "Plan mit dem Dashboard synchronisiert",
+	"Creating new plan for branch: %s\n":         "Erstelle neuen Plan für Branch: %s\n",
+	"Plan created at: %s\n":                      "Plan erstellt unter: %s\n",
+	"Using existing plan: %s\n":                  "Verwende vorhandenen Plan: %s\n",
+	"Registered with dashboard: %s/team/%s\n":    "Beim Dashboard registriert: %s/team/%s\n",
+	"\nMob session started!\n":                   "\nMob-Session gestartet!\n",
+	"Driver: %s\n":                               "Fahrer: %s\n",
+	"Branch: %s\n":                               "Branch: %s\n",
+	"Started: %s\n":                              "Gestartet: %s\n",
+	"Elapsed: %s\n":                              "Verstrichen: %s\n",
+	"Timer: %s\n":                                "Timer: %s\n",
+	"\n=== Handoff from the previous driver ===": "\n=== Übergabe vom vorherigen Fahrer ===",
+	"Who is driving? (number or name)":           "Wer fährt? (Nummer oder Name)",
+	"Timer: %d minutes (run 'mob-claude timer' to get reminders)\n": "Timer: %d Minuten ('mob-claude timer' für Erinnerungen ausführen)\n",
+
+	// next / done
+	"Generating rotation summary...":                      "Erstelle Zusammenfassung der Rotation...",
+	"Generating final summary...":                         "Erstelle abschließende Zusammenfassung...",
+	"Recovered summary from an interrupted handoff: %s\n": "Zusammenfassung aus einer abgebrochenen Übergabe wiederhergestellt: %s\n",
+	"Summary: %s\n":                                       "Zusammenfassung: %s\n",
+	"Final summary: %s\n":                                 "Abschließende Zusammenfassung: %s\n",
+	"Rotation recorded in dashboard":                      "Rotation im Dashboard gespeichert",
+	"\nHanding off to next driver...":                     "\nÜbergabe an den nächsten Fahrer...",
+	"\nCompleting mob session...":                         "\nSchließe Mob-Session ab...",
+	"\nAuto-next: starting handoff...":                    "\nAuto-Next: starte Übergabe...",
+
+	// summarize
+	"\nChanges:":      "\nÄnderungen:",
+	"\nNext steps:":   "\nNächste Schritte:",
+	"\nSummary saved": "\nZusammenfassung gespeichert",
+	"\nSaved over the last rotation's summary": "\nZusammenfassung der letzten Rotation überschrieben",
+
+	"Recorded fixture %s in %s\n": "Fixture %s in %s aufgezeichnet\n",
+
+	// status
+	"=== Mob Status ===":        "=== Mob-Status ===",
+	"\n=== Current Session ===": "\n=== Aktuelle Session ===",
+	"\n=== Plan ===":            "\n=== Plan ===",
+	"\n=== Latest Summary ===":  "\n=== Letzte Zusammenfassung ===",
+	"... (%d more lines)\n":     "... (%d weitere Zeilen)\n",
+	"mob status: %v\n":          "mob status: %v\n",
+	"Dashboard: not registered yet (dashboard unreachable)": "Dashboard: noch nicht registriert (Dashboard nicht erreichbar)",
+
+	"\n=== Freshness ===":     "\n=== Aktualität ===",
+	"Plan synced: %s\n":       "Plan synchronisiert: %s\n",
+	"Dashboard contact: %s\n": "Dashboard-Kontakt: %s\n",
+	"Heartbeat: %s\n":         "Heartbeat: %s\n",
+	"never":                   "nie",
+	"just now":                "gerade eben",
+	"%s ago":                  "vor %s",
+
+	// config
+	"Current configuration:": "Aktuelle Konfiguration:",
+	"\nConfig file: %s\n":    "\nKonfigurationsdatei: %s\n",
+	"Set %s = %s\n":          "%s = %s gesetzt\n",
+	"Unset %s\n":             "%s entfernt\n",
+	"%s is not set\n":        "%s ist nicht gesetzt\n",
+	"No changes made":        "Keine Änderungen vorgenommen",
+	"Config saved":           "Konfiguration gespeichert",
+	"Edit again?":            "Erneut bearbeiten?",
+	"Changes discarded":      "Änderungen verworfen",
+
+	// conflicts
+	"\n=== Merge conflicts ===":                                  "\n=== Merge-Konflikte ===",
+	"\nAsking Claude to explain the conflicts...":                "\nClaude erklärt die Konflikte...",
+	"Warning: could not explain conflicts: %v\n":                 "Warnung: Konflikte konnten nicht erklärt werden: %v\n",
+	"\nResolve the conflicts and commit them before continuing.": "\nKonflikte auflösen und committen, bevor es weitergeht.",
+
+	// announcements
+	"No announcements": "Keine Ankündigungen",
+	"Warning: could not fetch announcements: %v\n":     "Warnung: Ankündigungen konnten nicht abgerufen werden: %v\n",
+	"=== Team announcements ===":                       "=== Team-Ankündigungen ===",
+	"Warning: could not save seen announcements: %v\n": "Warnung: Gesehene Ankündigungen konnten nicht gespeichert werden: %v\n",
+
+	// squash commit
+	"Drafting the squash commit message...":          "Commit-Nachricht für den Squash wird entworfen...",
+	"\n=== Squash commit message ===":                "\n=== Squash-Commit-Nachricht ===",
+	"Commit the squashed changes with this message?": "Die zusammengefassten Änderungen mit dieser Nachricht committen?",
+	"Warning: %v\n": "Warnung: %v\n",
+	"Warning: could not save the commit message: %v\n":                    "Warnung: Commit-Nachricht konnte nicht gespeichert werden: %v\n",
+	"Saved to .git/SQUASH_MSG; 'git commit' will offer it as the message": "In .git/SQUASH_MSG gespeichert; 'git commit' schlägt sie als Nachricht vor",
+
+	// degraded mode
+	"=== Running in offline/degraded mode ===":                                        "=== Offline-/Eingeschränkter Modus ===",
+	"Dashboard unreachable (%v).\n":                                                   "Dashboard nicht erreichbar (%v).\n",
+	"  Rotations and plan updates are queued and uploaded once it's back.":            "  Rotationen und Plan-Updates werden gesammelt und hochgeladen, sobald es wieder da ist.",
+	"Claude unavailable (%v).\n":                                                      "Claude nicht verfügbar (%v).\n",
+	"  Summaries are built from your notes and -m messages.":                          "  Zusammenfassungen werden aus deinen Notizen und -m-Nachrichten erstellt.",
+	"Uploaded %d queued update(s) to the dashboard\n":                                 "%d gesammelte Aktualisierung(en) ans Dashboard übertragen\n",
+	"Warning: could not upload queued updates: %v\n":                                  "Warnung: Gesammelte Aktualisierungen konnten nicht übertragen werden: %v\n",
+	"Warning: could not queue upload: %v\n":                                           "Warnung: Upload konnte nicht vorgemerkt werden: %v\n",
+	"Queued the rotation for upload once the dashboard is reachable":                  "Rotation wird hochgeladen, sobald das Dashboard erreichbar ist",
+	"Queued the plan for upload once the dashboard is reachable":                      "Plan wird hochgeladen, sobald das Dashboard erreichbar ist",
+	"Warning: the dashboard refused %d queued update(s); they were set aside in %s\n": "Warnung: Das Dashboard hat %d gesammelte Aktualisierung(en) abgelehnt; sie wurden in %s zurückgelegt\n",
+	"Dashboard is reachable again":                                                    "Dashboard ist wieder erreichbar",
+	"Mode: offline (uploads are queued)":                                              "Modus: offline (Uploads werden gesammelt)",
+	"Mode: Claude unavailable (summaries come from notes)":                            "Modus: Claude nicht verfügbar (Zusammenfassungen aus Notizen)",
+	"Queued uploads: %d\n":                                                            "Ausstehende Uploads: %d\n",
+
+	// handoff brief
+	"previous driver":   "vorheriger Fahrer",
+	"_From %s at %s_\n": "_Von %s am %s_\n",
+	"Driver note":       "Notiz des Fahrers",
+	"Navigator notes":   "Beobachtungen der Navigatoren",
+	"Next steps":        "Nächste Schritte",
+	"Next task":         "Nächste Aufgabe",
+	" (blocked by %s)":  " (blockiert durch %s)",
+	"Outstanding tasks": "Offene Aufgaben",
+	"Open questions":    "Offene Fragen",
+	"Files touched":     "Geänderte Dateien",
+
+	// plan
+	"Warning: could not update plan fields: %v\n":                                "Warnung: Plan-Felder konnten nicht aktualisiert werden: %v\n",
+	"The plan already matches that rotation's snapshot":                          "Der Plan entspricht bereits dem Stand dieser Rotation",
+	"Rotation %s was driven by %s, starting %s.\n":                               "Rotation %s wurde von %s gefahren, Beginn %s.\n",
+	"Replace the plan for %s with its snapshot?":                                 "Plan für %s durch diesen Stand ersetzen?",
+	"Plan left unchanged":                                                        "Plan unverändert gelassen",
+	"Warning: restored the local plan, but could not update the dashboard: %v\n": "Warnung: Lokaler Plan wiederhergestellt, aber das Dashboard konnte nicht aktualisiert werden: %v\n",
+	"Plan for %s restored from rotation %s\n":                                    "Plan für %s aus Rotation %s wiederhergestellt\n",
+
+	// plan ai-update
+	"Asking Claude to update the plan...":     "Claude aktualisiert den Plan...",
+	"Claude suggested no changes to the plan": "Claude schlägt keine Änderungen am Plan vor",
+	"Save the updated plan for %s?":           "Aktualisierten Plan für %s speichern?",
+	"Plan for %s updated\n":                   "Plan für %s aktualisiert\n",
+
+	// profile
+	"No profiles configured": "Keine Profile konfiguriert",
+	"Using profile %s\n":     "Verwende Profil %s\n",
+	"No profile in use":      "Kein Profil aktiv",
+
+	// notification lists
+	"all": "alle",
+
+	// activity
+	"No activity recorded for this rotation yet":                        "Für diese Rotation wurde noch keine Aktivität erfasst",
+	"Recording file saves for the rotation summary (Ctrl-C to stop)...": "Erfasse gespeicherte Dateien für die Zusammenfassung (Strg-C zum Beenden)...",
+	"Warning: could not record activity: %v\n":                          "Warnung: Aktivität konnte nicht erfasst werden: %v\n",
+	"Warning: could not clear activity log: %v\n":                       "Warnung: Aktivitätsprotokoll konnte nicht geleert werden: %v\n",
+
+	// presence
+	"No active mob session; presence cleared":              "Keine aktive Mob-Sitzung; Anwesenheit beendet",
+	"Announcing %s as the driver of %s (Ctrl-C to stop)\n": "Melde %s als Fahrer von %s an (Strg-C zum Beenden)\n",
+	"Presence connection lost (%v), reconnecting...\n":     "Anwesenheitsverbindung verloren (%v), verbinde neu...\n",
+	"  Nobody is connected":                                "  Niemand ist verbunden",
+	"  %s is driving, %d min remaining\n":                  "  %s fährt, noch %d Min.\n",
+	"  %s is driving\n":                                    "  %s fährt\n",
+	"  %s is watching\n":                                   "  %s schaut zu\n",
+
+	// timer
+	"Rotation ends at %s (%s left)\n":                                   "Rotation endet um %s (noch %s)\n",
+	"Auto-next is on: handing off %s after the timer expires\n":         "Auto-Next ist aktiv: Übergabe %s nach Ablauf des Timers\n",
+	"%s left in this rotation":                                          "Noch %s in dieser Rotation",
+	"%d minutes left in this rotation":                                  "Noch %d Minuten in dieser Rotation",
+	"Less than a minute left (%ds) - wrap up and get ready to hand off": "Weniger als eine Minute übrig (%ds) - zum Abschluss kommen und Übergabe vorbereiten",
+	"Time's up! Run 'mob-claude next' to hand off":                      "Die Zeit ist um! Mit 'mob-claude next' übergeben",
+	"OVERDUE by %d minutes - hand off now with 'mob-claude next'":       "%d Minuten ÜBERZOGEN - jetzt mit 'mob-claude next' übergeben",
+
+	// daemon
+	"Listening on http://127.0.0.1:%d\n":                                                     "Lausche auf http://127.0.0.1:%d\n",
+	"Requests need the daemon's pairing token ('mob-claude daemon --pair' prints a new one)": "Anfragen brauchen das Kopplungs-Token des Daemons ('mob-claude daemon --pair' gibt ein neues aus)",
+	"Pairing token: %s\n": "Kopplungs-Token: %s\n",
+	"Using the existing pairing token (run with --pair to create a new one)": "Verwende vorhandenes Kopplungs-Token (mit --pair ein neues erstellen)",
+
+	// history
+	"No rotations recorded yet": "Noch keine Rotationen aufgezeichnet",
+
+	// fairness
+	"Keyboard time over the last %d session(s): %s\n\n": "Zeit an der Tastatur in den letzten %d Sitzung(en): %s\n\n",
+	"above an even share":                               "über dem gleichmäßigen Anteil",
+	"below an even share":                               "unter dem gleichmäßigen Anteil",
+	"\nNo rotation durations were recorded, so only rotation counts are shown": "\nEs wurden keine Rotationsdauern erfasst, daher werden nur Rotationen gezählt",
+	"\nEven share with %d drivers: %.0f%%\n":                                   "\nGleichmäßiger Anteil bei %d Fahrern: %.0f%%\n",
+
+	// claude-resume
+	"Resuming Claude Code conversation %s...\n":                 "Claude-Code-Unterhaltung %s wird fortgesetzt...\n",
+	"Warning: could not fetch latest rotation: %v\n":            "Warnung: Letzte Rotation konnte nicht geladen werden: %v\n",
+	"Warning: could not look up Claude Code conversation: %v\n": "Warnung: Claude-Code-Unterhaltung konnte nicht ermittelt werden: %v\n",
+
+	// metrics
+	"Serving metrics on http://%s/metrics\n": "Metriken unter http://%s/metrics\n",
+
+	// done --archive
+	"Archived session to %s\n":                                     "Sitzung archiviert unter %s\n",
+	"Archive uploaded to the dashboard":                            "Archiv ins Dashboard hochgeladen",
+	"Warning: could not archive session: %v\n":                     "Warnung: Sitzung konnte nicht archiviert werden: %v\n",
+	"Warning: could not get diff stats: %v\n":                      "Warnung: Diff-Statistik konnte nicht ermittelt werden: %v\n",
+	"Warning: could not clean up session files: %v\n":              "Warnung: Sitzungsdateien konnten nicht aufgeräumt werden: %v\n",
+	"Warning: could not upload archive: %v\n":                      "Warnung: Archiv konnte nicht hochgeladen werden: %v\n",
+	"Warning: dashboard not configured, archive kept locally only": "Warnung: Dashboard nicht konfiguriert, Archiv nur lokal gespeichert",
+
+	// serve
+	"Serving status on http://%s\n": "Status unter http://%s\n",
+
+	// start --ai-plan
+	"Claude is unavailable, so the plan starts from the template": "Claude ist nicht verfügbar, der Plan beginnt mit der Vorlage",
+	"Warning: could not get recent commits: %v\n":                 "Warnung: Letzte Commits konnten nicht gelesen werden: %v\n",
+	"Asking Claude to draft the plan...":                          "Claude entwirft den Plan...",
+	"Warning: could not draft plan: %v\n":                         "Warnung: Plan konnte nicht entworfen werden: %v\n",
+	"Edit the draft before saving?":                               "Entwurf vor dem Speichern bearbeiten?",
+	"Warning: could not edit the draft, saving it as is: %v\n":    "Warnung: Entwurf konnte nicht bearbeitet werden, er wird unverändert gespeichert: %v\n",
+	"The draft was emptied, so the plan starts from the template": "Der Entwurf wurde geleert, der Plan beginnt mit der Vorlage",
+
+	// search
+	"decision":                             "Entscheidung",
+	"tldr":                                 "TL;DR",
+	"change":                               "Änderung",
+	"next step":                            "Nächster Schritt",
+	"note":                                 "Notiz",
+	"No decisions or summaries match %q\n": "Keine Entscheidungen oder Zusammenfassungen passen zu %q\n",
+	"    introduced by %s in the rotation started %s\n": "    eingeführt von %s in der Rotation ab %s\n",
+	"    introduced by %s on %s (commit %s)\n":          "    eingeführt von %s am %s (Commit %s)\n",
+	"    introduced by an unknown rotation":             "    eingeführt in einer unbekannten Rotation",
+
+	// plan metadata
+	"Warning: could not update plan metadata: %v\n": "Warnung: Plan-Metadaten konnten nicht aktualisiert werden: %v\n",
+
+	// handoff
+	"Handoff written to %s\n":                                  "Übergabe nach %s geschrieben\n",
+	"Handoff sent to the dashboard":                            "Übergabe an das Dashboard gesendet",
+	"For: %s\n":                                                "Für: %s\n",
+	"Includes Claude Code conversation %s\n":                   "Enthält Claude-Code-Unterhaltung %s\n",
+	"Encrypted with %s\n":                                      "Verschlüsselt mit %s\n",
+	"No handoff waiting for %s\n":                              "Keine Übergabe für %s vorhanden\n",
+	"A handoff is waiting for %s\n":                            "Eine Übergabe wartet auf %s\n",
+	"Received handoff from %s (sent %s)\n":                     "Übergabe von %s erhalten (gesendet %s)\n",
+	"The previous rotation's timer runs until %s\n":            "Der Timer der vorigen Rotation läuft bis %s\n",
+	"Warning: could not import Claude Code conversation: %v\n": "Warnung: Claude-Code-Unterhaltung konnte nicht importiert werden: %v\n",
+	"Run 'mob-claude claude-resume %s' to continue the previous driver's conversation\n": "Mit 'mob-claude claude-resume %s' die Unterhaltung des vorigen Fahrers fortsetzen\n",
+	"Warning: could not remove handoff from dashboard: %v\n":                             "Warnung: Übergabe konnte nicht vom Dashboard entfernt werden: %v\n",
+	"Warning: could not receive handoff: %v\n":                                           "Warnung: Übergabe konnte nicht empfangen werden: %v\n",
+
+	// uncommitted changes
+	"Warning: could not check for uncommitted changes: %v\n":                           "Warnung: Nicht committete Änderungen konnten nicht geprüft werden: %v\n",
+	"Warning: %d uncommitted files:\n":                                                 "Warnung: %d nicht committete Dateien:\n",
+	"Include them in the handoff?":                                                     "In die Übergabe aufnehmen?",
+	"Warning: could not stash uncommitted changes, they will be handed off: %v\n":      "Warnung: Nicht committete Änderungen konnten nicht gestasht werden und werden übergeben: %v\n",
+	"Stashed %d files left out of the handoff; run 'git stash pop' to get them back\n": "%d nicht übergebene Dateien gestasht; mit 'git stash pop' zurückholen\n",
+	"Left out of the handoff":                                                          "Nicht übergeben",
+
+	// summary context
+	"Warning: could not load previous summaries: %v\n": "Warnung: Frühere Zusammenfassungen konnten nicht geladen werden: %v\n",
+
+	// timer room
+	"Timer room: %s\n":                          "Timer-Raum: %s\n",
+	"Warning: could not start timer room: %v\n": "Warnung: Timer im Timer-Raum konnte nicht gestartet werden: %v\n",
+	"Warning: could not stop timer room: %v\n":  "Warnung: Timer im Timer-Raum konnte nicht gestoppt werden: %v\n",
+
+	// rebind
+	"Warning: the session is for branch %s, but git is on %s\n":                   "Warnung: Die Sitzung gehört zu Branch %s, Git ist aber auf %s\n",
+	"If the branch was renamed, run 'mob-claude rebind' to move the session over": "Falls der Branch umbenannt wurde, mit 'mob-claude rebind' die Sitzung übertragen",
+	"The session is already on %s\n":                                              "Die Sitzung ist bereits auf %s\n",
+	"Moved %s to %s\n":                                                            "%s nach %s verschoben\n",
+	"Plan: %s\n":                                                                  "Plan: %s\n",
+	"Session updated":                                                             "Sitzung aktualisiert",
+	"Warning: could not update sync times: %v\n":                                  "Warnung: Synchronisationszeiten konnten nicht aktualisiert werden: %v\n",
+	"Warning: could not upload plan: %v\n":                                        "Warnung: Plan konnte nicht hochgeladen werden: %v\n",
+	"Warning: could not update dashboard, will retry later: %v\n":                 "Warnung: Dashboard konnte nicht aktualisiert werden, wird später erneut versucht: %v\n",
+	"Dashboard workstream moved to %s\n":                                          "Dashboard-Workstream nach %s verschoben\n",
+
+	// start --pick
+	"Which workstream do you want to join?":          "Welchem Workstream möchtest du beitreten?",
+	"Enter a number from 1 to %d or a branch name\n": "Gib eine Zahl von 1 bis %d oder einen Branch-Namen ein\n",
+
+	// plan lint
+	"%s: OK\n":         "%s: OK\n",
+	"No plans to lint": "Keine Pläne zu prüfen",
+
+	// nav
+	"No navigator observations for this rotation yet": "Noch keine Beobachtungen der Navigatoren in dieser Rotation",
+	"Observation noted (%d so far this rotation)\n":   "Beobachtung notiert (%d bisher in dieser Rotation)\n",
+
+	// heartbeat
+	"Another heartbeat took over; stopping":    "Ein anderer Heartbeat hat übernommen; wird beendet",
+	"No active mob session; heartbeat stopped": "Keine aktive Mob-Session; Heartbeat beendet",
+	"Warning: could not send heartbeat: %v\n":  "Warnung: Heartbeat konnte nicht gesendet werden: %v\n",
+	"Warning: could not load session: %v\n":    "Warnung: Session konnte nicht geladen werden: %v\n",
+	"Warning: could not start heartbeat: %v\n": "Warnung: Heartbeat konnte nicht gestartet werden: %v\n",
+	"Last seen: %s (%s)\n":                     "Zuletzt gesehen: %s (%s)\n",
+	"Last seen: %s\n":                          "Zuletzt gesehen: %s\n",
+
+	// follow
+	"Following %s (Ctrl-C to stop)\n":                                "Verfolge %s (Strg-C zum Beenden)\n",
+	"The workstream has no active session":                           "Der Workstream hat keine aktive Session",
+	"Warning: could not reach the dashboard, will keep trying: %v\n": "Warnung: Dashboard nicht erreichbar, neuer Versuch folgt: %v\n",
+	"Dashboard reachable again":                                      "Dashboard wieder erreichbar",
+	"Last rotation: %s, %s\n":                                        "Letzte Rotation: %s, %s\n",
+	"New rotation by %s\n":                                           "Neue Rotation von %s\n",
+	"Plan updated:":                                                  "Plan aktualisiert:",
+	"Session started":                                                "Session gestartet",
+	"Session ended":                                                  "Session beendet",
+
+	// network
+	"Warning: ignoring httpProxy: %v\n": "Warnung: httpProxy wird ignoriert: %v\n",
+	"Warning: ignoring caBundle: %v\n":  "Warnung: caBundle wird ignoriert: %v\n",
+
+	// done guard
+	"%d tasks remain in the plan:\n": "%d Aufgaben im Plan sind noch offen:\n",
+	"%d tasks remain in the plan — archive as follow-ups (f), mark done (d), or abort (a)?": "%d Aufgaben im Plan sind noch offen — als Follow-ups archivieren (f), als erledigt markieren (d) oder abbrechen (a)?",
+	"Marked %d tasks done\n":                                 "%d Aufgaben als erledigt markiert\n",
+	"Moved %d tasks to the follow-up workstream %s\n":        "%d Aufgaben in den Follow-up-Workstream %s verschoben\n",
+	"Pick them up with 'mob-claude start -b %s'\n":           "Weiter geht es mit 'mob-claude start -b %s'\n",
+	"Warning: could not register follow-up workstream: %v\n": "Warnung: Follow-up-Workstream konnte nicht registriert werden: %v\n",
+
+	// init
+	"Created %s\n":       "%s erstellt\n",
+	"Wrote %s\n":         "%s geschrieben\n",
+	"%s is up to date\n": "%s ist aktuell\n",
+	"Leaving %s alone: it isn't managed by mob-claude\n": "%s bleibt unverändert: die Datei wird nicht von mob-claude verwaltet\n",
+	"Committable: %s\n": "Committbar: %s\n",
+	"nothing":           "nichts",
+	"Warning: could not update .gitignore: %v\n": "Warnung: .gitignore konnte nicht aktualisiert werden: %v\n",
+
+	// storage
+	"Warning: could not open storage: %v\n":    "Warnung: Speicher konnte nicht geöffnet werden: %v\n",
+	"Warning: could not copy to storage: %v\n": "Warnung: Kopie in den Speicher fehlgeschlagen: %v\n",
+	"Would copy %d plans and %d summaries\n":   "Würde %d Pläne und %d Zusammenfassungen kopieren\n",
+	"Copied %d plans and %d summaries\n":       "%d Pläne und %d Zusammenfassungen kopiert\n",
+
+	// replay
+	"Replay of %s: %d rotations\n":                       "Wiederholung von %s: %d Rotationen\n",
+	"Replay of %s":                                       "Wiederholung von %s",
+	"Plan changes are only available from the dashboard": "Planänderungen gibt es nur über das Dashboard",
+	"  Plan:":       "  Plan:",
+	"Plan changes:": "Planänderungen:",
+	"Warning: could not fetch rotations, replaying local summaries: %v\n": "Warnung: Rotationen konnten nicht abgerufen werden, lokale Zusammenfassungen werden verwendet: %v\n",
+
+	// keygen
+	"Stored a new encryptionKey. Share it with your team:":          "Neuer encryptionKey gespeichert. Teile ihn mit deinem Team:",
+	"Each teammate runs: mob-claude config set encryptionKey <key>": "Jedes Teammitglied führt aus: mob-claude config set encryptionKey <key>",
+
+	// redaction
+	"Redacted from the diff before sending it to Claude: %s\n": "Vor dem Senden an Claude aus dem Diff entfernt: %s\n",
+	"Warning: ignoring redactPatterns: %v\n":                   "Warnung: redactPatterns wird ignoriert: %v\n",
+
+	// workstream
+	"No workstreams": "Keine Workstreams",
+	"No active workstreams. Use --all to include archived ones": "Keine aktiven Workstreams. Mit --all werden archivierte einbezogen",
+	"archived":                 "archiviert",
+	"%s is already active\n":   "%s ist bereits aktiv\n",
+	"%s is already archived\n": "%s ist bereits archiviert\n",
+	"Reopened %s\n":            "%s wieder geöffnet\n",
+	"Archived %s. Reopen it with 'mob-claude workstream reopen %s'\n": "%s archiviert. Wieder öffnen mit 'mob-claude workstream reopen %s'\n",
+
+	// handoff panel
+	"\n=== Handoff from %s, %s ago ===\n": "\n=== Übergabe von %s, vor %s ===\n",
+	"TL;DR: %s\n":                         "TL;DR: %s\n",
+	"\nFull handoff brief: %s\n":          "\nVollständige Übergabe: %s\n",
+
+	// plan push/pull
+	"local":     "lokal",
+	"dashboard": "Dashboard",
+	"Push the local plan for %s to the dashboard?":        "Lokalen Plan für %s ins Dashboard hochladen?",
+	"Replace the local plan for %s with the dashboard's?": "Lokalen Plan für %s durch den aus dem Dashboard ersetzen?",
+	"The plan for %s is already in sync\n":                "Der Plan für %s ist bereits synchron\n",
+	"Pushed the plan for %s\n":                            "Plan für %s hochgeladen\n",
+	"Pulled the plan for %s\n":                            "Plan für %s heruntergeladen\n",
+
+	// stats
+	"%d rotation(s), %s at the keyboard\n": "%d Rotation(en), %s an der Tastatur\n",
+
+	// notify
+	"No notifications configured; the timer shows a desktop notification when it expires": "Keine Benachrichtigungen konfiguriert; der Timer zeigt beim Ablauf eine Desktop-Benachrichtigung",
+	"Notification channel %s saved\n":                          "Benachrichtigungskanal %s gespeichert\n",
+	"Warning: could not remove %s from the secret store: %v\n": "Warnung: %s konnte nicht aus dem Geheimnisspeicher entfernt werden: %v\n",
+	"Notification channel %s removed\n":                        "Benachrichtigungskanal %s entfernt\n",
+	"mob-claude test notification":                             "mob-claude Testbenachrichtigung",
+	"Notifications are set up for this mob":                    "Benachrichtigungen sind für diesen Mob eingerichtet",
+	"%s: sent\n":                                               "%s: gesendet\n",
+	"%s: failed: %v\n":                                         "%s: fehlgeschlagen: %v\n",
+	"Warning: could not send notification: %v\n":               "Warnung: Benachrichtigung konnte nicht gesendet werden: %v\n",
+	"Time's up for %s on %s":                                   "Die Zeit für %s auf %s ist um",
+	"%s started a mob session on %s":                           "%s hat eine Mob-Session auf %s gestartet",
+	"%s handed off %s":                                         "%s hat %s übergeben",
+	"The mob session on %s is done":                            "Die Mob-Session auf %s ist beendet",
+
+	// schedule
+	"No mob schedule. Add blocks to 'schedule' in config, or set one up on the dashboard": "Kein Mob-Zeitplan. Füge Blöcke zu 'schedule' in der Konfiguration hinzu oder richte einen im Dashboard ein",
+	"Mob schedule (from %s):\n\n": "Mob-Zeitplan (aus %s):\n\n",
+	"config":                      "Konfiguration",
+	"every day":                   "täglich",
+	"Now: %s, until %s\n":         "Jetzt: %s, bis %s\n",
+	"Next: %s, %s\n":              "Als Nächstes: %s, %s\n",
+	"Warning: could not fetch schedule: %v\n":                                  "Warnung: Zeitplan konnte nicht abgerufen werden: %v\n",
+	"Warning: starting outside the team's mob schedule (next block: %s, %s)\n": "Warnung: Start außerhalb des Mob-Zeitplans des Teams (nächster Block: %s, %s)\n",
+	"Warning: starting outside the team's mob schedule":                        "Warnung: Start außerhalb des Mob-Zeitplans des Teams",
+	"Scheduled block: %s, until %s\n":                                          "Geplanter Block: %s, bis %s\n",
+
+	// forgotten next
+	"\nWarning: %s has %d commit(s) by %s since %s started driving.\n":                             "\nWarnung: %s hat %d Commit(s) von %s, seit %s fährt.\n",
+	"A handoff likely happened without mob-claude, so this session's rotation was never recorded.": "Vermutlich wurde ohne mob-claude übergeben, daher wurde die Rotation dieser Session nie erfasst.",
+	"Record %s's rotation now and end this session?":                                               "Rotation von %s jetzt erfassen und diese Session beenden?",
+	"Warning: could not record the rotation: %v\n":                                                 "Warnung: Rotation konnte nicht erfasst werden: %v\n",
+	"Session ended. Run 'mob-claude start' to join the mob again":                                  "Session beendet. Führe 'mob-claude start' aus, um wieder beizutreten",
+	"%d commit(s), recorded after a handoff outside mob-claude":                                    "%d Commit(s), nachträglich erfasst nach einer Übergabe ohne mob-claude",
+	"Run 'mob-claude status' to record the rotation":                                               "Führe 'mob-claude status' aus, um die Rotation zu erfassen",
+	"%s committed to the mob branch; a handoff likely happened without mob-claude":                 "%s hat auf den Mob-Branch committet; vermutlich wurde ohne mob-claude übergeben",
+
+	// task claim
+	"Task %d is unassigned: %s\n":    "Aufgabe %d ist niemandem zugewiesen: %s\n",
+	"Task %d assigned to %s: %s\n":   "Aufgabe %d an %s zugewiesen: %s\n",
+	"No open tasks assigned to %s\n": "Keine offenen Aufgaben für %s\n",
+	"Your tasks, %s":                 "Deine Aufgaben, %s",
+
+	// amend-last
+	"Amended %s's rotation on %s\n":                     "Rotation von %s auf %s korrigiert\n",
+	"Queued upload updated":                             "Wartender Upload aktualisiert",
+	"Rotation updated in dashboard":                     "Rotation im Dashboard aktualisiert",
+	"Warning: could not update the queued upload: %v\n": "Warnung: Wartender Upload konnte nicht aktualisiert werden: %v\n",
+	"Warning: the dashboard's latest rotation on %s isn't this one; it was left as is\n": "Warnung: Die letzte Rotation auf %s im Dashboard ist eine andere; sie bleibt unverändert\n",
+
+	// session template
+	"Session template %s saved\n":                                                   "Sitzungsvorlage %s gespeichert\n",
+	"Session template %s applied\n":                                                 "Sitzungsvorlage %s angewendet\n",
+	"Session template %s removed\n":                                                 "Sitzungsvorlage %s entfernt\n",
+	"\nStart a session with it: mob-claude start --preset %s\n":                     "\nSitzung damit starten: mob-claude start --preset %s\n",
+	"No session templates. Save one with 'mob-claude session template save <name>'": "Keine Sitzungsvorlagen. Speichere eine mit 'mob-claude session template save <name>'",
+	"%d task(s)":                    "%d Aufgabe(n)",
+	"  Timer: %d minutes\n":         "  Timer: %d Minuten\n",
+	"  Roster: %s\n":                "  Teilnehmer: %s\n",
+	"  Plan template: %d task(s)\n": "  Planvorlage: %d Aufgabe(n)\n",
+	"Skipped the %s notification channel %s: no URL. Add it with 'mob-claude notify add %s --name %s --url <url>'\n": "%s-Benachrichtigungskanal %s übersprungen: keine URL. Füge ihn mit 'mob-claude notify add %s --name %s --url <url>' hinzu\n",
+	"URL for the %s notification channel %s (empty to skip)":                                                         "URL für den %s-Benachrichtigungskanal %s (leer zum Überspringen)",
+	"  Notifications: %d channel(s)\n":                                                                               "  Benachrichtigungen: %d Kanal/Kanäle\n",
+
+	// prune
+	"Nothing to prune":              "Nichts zu bereinigen",
+	"Would prune:":                  "Würde bereinigen:",
+	"Pruned:":                       "Bereinigt:",
+	"\nPruned old local artifacts:": "\nAlte lokale Dateien bereinigt:",
+	"  %d summary file(s)\n":        "  %d Zusammenfassungsdatei(en)\n",
+	"  Stale branch %s (plan, summaries, and handoff brief)\n": "  Veralteter Branch %s (Plan, Zusammenfassungen und Übergabenotiz)\n",
+	"  Archive %s\n":                                     "  Archiv %s\n",
+	"  %d cached dashboard response(s)\n":                "  %d zwischengespeicherte Dashboard-Antwort(en)\n",
+	"Archived to %s\n":                                   "Archiviert unter %s\n",
+	"Warning: could not prune the dashboard cache: %v\n": "Warnung: Dashboard-Cache konnte nicht bereinigt werden: %v\n",
+
+	// first-run setup
+	"=== Setting up mob-claude for this project ===":                                         "=== mob-claude für dieses Projekt einrichten ===",
+	"Press Enter to accept the default. Change anything later with 'mob-claude config set'.": "Enter übernimmt den Standardwert. Alles lässt sich später mit 'mob-claude config set' ändern.",
+	"Team name":                             "Teamname",
+	"Record rotations on a team dashboard?": "Rotationen in einem Team-Dashboard festhalten?",
+	"Dashboard URL":                         "Dashboard-URL",
+	"The dashboard needs a team name":       "Das Dashboard braucht einen Teamnamen",
+	"If it needs a token, set it with 'mob-claude config set apiToken <token>'":             "Falls es ein Token braucht, setze es mit 'mob-claude config set apiToken <token>'",
+	"Summarize rotations with Claude?":                                                      "Rotationen mit Claude zusammenfassen?",
+	"  Install the claude CLI or set %s; until then summaries are built from your notes.\n": "  Installiere die claude CLI oder setze %s; bis dahin werden Zusammenfassungen aus deinen Notizen erstellt.\n",
+	"Warning: could not write .gitignore: %v\n":                                             "Warnung: .gitignore konnte nicht geschrieben werden: %v\n",
+	"\nSaved %s\n\n": "\n%s gespeichert\n\n",
+
+	// backfill
+	"No local summaries to backfill":                     "Keine lokalen Zusammenfassungen zum Nachtragen",
+	"\nDry run: nothing was uploaded":                    "\nProbelauf: nichts wurde hochgeladen",
+	"\nBackfilled %d rotation(s) across %d branch(es)\n": "\n%d Rotation(en) in %d Branch(es) nachgetragen\n",
+
+	// sync-plans
+	"in sync":                             "synchron",
+	"push local plan":                     "lokalen Plan hochladen",
+	"pull dashboard plan":                 "Plan vom Dashboard übernehmen",
+	"skipped":                             "übersprungen",
+	"\nDry run: nothing was synced":       "\nProbelauf: nichts wurde synchronisiert",
+	"\nPushed %d and pulled %d plan(s)\n": "\n%d Plan/Pläne hochgeladen und %d übernommen\n",
+	"%s: local plan (modified %s) and dashboard plan (updated %s) differ\n": "%s: lokaler Plan (geändert %s) und Dashboard-Plan (aktualisiert %s) unterscheiden sich\n",
+	"Keep which side? push/pull/skip":                                       "Welche Seite behalten? push/pull/skip",
+
+	// nested repositories
+	"Using the enclosing repository %s, which holds the mob session\n": "Verwende das umgebende Repository %s, das die Mob-Session enthält\n",
+	"Which repository does this session belong to? 1) %s 2) %s":        "Zu welchem Repository gehört diese Session? 1) %s 2) %s",
+
+	// warnings
+	"Warning: could not load config: %v\n":                               "Warnung: Konfiguration konnte nicht geladen werden: %v\n",
+	"Warning: could not fetch plan from API: %v\n":                       "Warnung: Plan konnte nicht von der API geladen werden: %v\n",
+	"Warning: could not create plan: %v\n":                               "Warnung: Plan konnte nicht erstellt werden: %v\n",
+	"Warning: could not save plan locally: %v\n":                         "Warnung: Plan konnte nicht lokal gespeichert werden: %v\n",
+	"Warning: could not register with dashboard, will retry later: %v\n": "Warnung: Registrierung beim Dashboard fehlgeschlagen, neuer Versuch später: %v\n",
+	"Warning: could not write handoff brief: %v\n":                       "Warnung: Übergabe-Notiz konnte nicht geschrieben werden: %v\n",
+	"Warning: could not write port file: %v\n":                           "Warnung: Port-Datei konnte nicht geschrieben werden: %v\n",
+	"Warning: could not save session: %v\n":                              "Warnung: Session konnte nicht gespeichert werden: %v\n",
+	"Warning: could not get diff: %v\n":                                  "Warnung: Diff konnte nicht ermittelt werden: %v\n",
+	"Warning: summary generation failed: %v\n":                           "Warnung: Zusammenfassung konnte nicht erstellt werden: %v\n",
+	"Warning: could not journal summary: %v\n":                           "Warnung: Zusammenfassung konnte nicht gesichert werden: %v\n",
+	"Warning: could not clear summary journal: %v\n":                     "Warnung: Zusammenfassungs-Journal konnte nicht gelöscht werden: %v\n",
+	"Warning: could not save summary: %v\n":                              "Warnung: Zusammenfassung konnte nicht gespeichert werden: %v\n",
+	"Warning: could not upload rotation: %v\n":                           "Warnung: Rotation konnte nicht hochgeladen werden: %v\n",
+	"Warning: could not sync plan: %v\n":                                 "Warnung: Plan konnte nicht synchronisiert werden: %v\n",
+	"Warning: could not clear session: %v\n":                             "Warnung: Session konnte nicht zurückgesetzt werden: %v\n",
+	"Warning: %s is nested inside another repository (%s)\n":             "Warnung: %s liegt in einem anderen Repository (%s)\n",
+	"Warning: skipping %v\n":                                             "Warnung: überspringe %v\n",
+	"Warning: skipping %s: no branch recorded\n":                         "Warnung: überspringe %s: kein Branch gespeichert\n",
+	"Warning: could not register workstream %s: %v\n":                    "Warnung: Workstream %s konnte nicht registriert werden: %v\n",
+	"Warning: could not upload rotation from %s: %v\n":                   "Warnung: Rotation vom %s konnte nicht hochgeladen werden: %v\n",
+	"Warning: could not fetch plan for %s: %v\n":                         "Warnung: Plan für %s konnte nicht geladen werden: %v\n",
+	"Warning: could not save plan sync state: %v\n":                      "Warnung: Plan-Synchronisationsstand konnte nicht gespeichert werden: %v\n",
+	"Warning: could not sync plan for %s: %v\n":                          "Warnung: Plan für %s konnte nicht synchronisiert werden: %v\n",
+}
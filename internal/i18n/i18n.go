@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the language the CLI strings are written in
+const DefaultLocale = "en"
+
+var (
+	mu       sync.RWMutex
+	locale   = DefaultLocale
+	catalogs = map[string]map[string]string{
+		"de": german,
+	}
+)
+
+// Detect returns the preferred locale from the environment, checking
+// MOB_CLAUDE_LANG, then the standard LC_ALL, LC_MESSAGES and LANG variables
+func Detect() string {
+	for _, key := range []string{"MOB_CLAUDE_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			return Normalize(value)
+		}
+	}
+	return DefaultLocale
+}
+
+// Normalize reduces a locale like "de_DE.UTF-8" to its language code "de"
+func Normalize(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, "_-.@"); i != -1 {
+		value = value[:i]
+	}
+	if value == "" || value == "c" || value == "posix" {
+		return DefaultLocale
+	}
+	return value
+}
+
+// SetLocale selects the language used for translated strings
+func SetLocale(l string) {
+	mu.Lock()
+	defer mu.Unlock()
+	locale = Normalize(l)
+}
+
+// Locale returns the active language code
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// LoadCatalogDir loads user-supplied catalogs from <dir>/<lang>.json files.
+// Each file maps the English message to its translation and overrides
+// built-in entries. A missing directory is not an error.
+func LoadCatalogDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("invalid catalog %s: %w", entry.Name(), err)
+		}
+
+		lang := Normalize(strings.TrimSuffix(entry.Name(), ".json"))
+		mu.Lock()
+		if catalogs[lang] == nil {
+			catalogs[lang] = make(map[string]string)
+		}
+		for msg, translation := range messages {
+			catalogs[lang][msg] = translation
+		}
+		mu.Unlock()
+	}
+	return nil
+}
+
+// T translates an English message into the active locale, falling back to
+// the original message when no translation exists
+func T(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if translated, ok := catalogs[locale][msg]; ok && translated != "" {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf formats a translated message
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Printf prints a translated message
+func Printf(format string, args ...interface{}) {
+	fmt.Printf(T(format), args...)
+}
+
+// Println prints a translated message followed by a newline
+func Println(msg string) {
+	fmt.Println(T(msg))
+}
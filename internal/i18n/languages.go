@@ -0,0 +1,54 @@
+package i18n
+
+import "strings"
+
+// languageNames maps language codes to their English names, for prompts
+// that ask Claude to write in a language
+var languageNames = map[string]string{
+	"de": "German",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"sv": "Swedish",
+	"zh": "Chinese",
+}
+
+// LanguageName returns the English name of a language given as a code
+// ("de", "ja_JP") or a name ("German"). Unknown values are returned as is.
+func LanguageName(value string) string {
+	if name, ok := languageNames[Normalize(value)]; ok {
+		return name
+	}
+	return strings.TrimSpace(value)
+}
+
+// LanguageCode returns the language code for a code or English language
+// name, or "" if it isn't known
+func LanguageCode(value string) string {
+	if code := Normalize(value); languageNames[code] != "" {
+		return code
+	}
+	for code, name := range languageNames {
+		if strings.EqualFold(name, strings.TrimSpace(value)) {
+			return code
+		}
+	}
+	return ""
+}
+
+// TIn translates an English message into the given locale rather than the
+// active one, for text written for other people, like the handoff brief
+func TIn(locale, msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if translated, ok := catalogs[Normalize(locale)][msg]; ok && translated != "" {
+		return translated
+	}
+	return msg
+}
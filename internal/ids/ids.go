@@ -0,0 +1,25 @@
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// Generator produces unique identifiers such as idempotency keys
+type Generator interface {
+	NewID() string
+}
+
+// Random generates random hex identifiers
+var Random Generator = randomGenerator{}
+
+type randomGenerator struct{}
+
+func (randomGenerator) NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
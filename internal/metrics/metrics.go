@@ -0,0 +1,248 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// FileName is the counters file inside the config directory
+const FileName = "metrics.json"
+
+// DefaultPort is the port 'metrics serve' listens on
+const DefaultPort = 9464
+
+// ContentType is the Prometheus text exposition format Write produces
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Counters are the metrics that can't be derived from the local summaries,
+// accumulated as commands run
+type Counters struct {
+	// SummaryCount and SummarySeconds time summary generation;
+	// SummaryFallbacks counts the times Claude's output was unusable
+	SummaryCount     int     `json:"summaryCount"`
+	SummarySeconds   float64 `json:"summarySeconds"`
+	SummaryFallbacks int     `json:"summaryFallbacks"`
+
+	// APIErrors counts dashboard requests that failed or got an error response
+	APIErrors int `json:"apiErrors"`
+}
+
+// Store keeps the counters in a project's config directory
+type Store struct {
+	path string
+}
+
+// New returns the counters store in dir
+func New(dir string) *Store {
+	return &Store{path: filepath.Join(dir, FileName)}
+}
+
+// Load returns the stored counters, all zero if none have been recorded
+func (s *Store) Load() (*Counters, error) {
+	c := &Counters{}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return c, fmt.Errorf("failed to read metrics: %w", err)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return &Counters{}, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return c, nil
+}
+
+// Update applies update to the stored counters
+func (s *Store) Update(update func(*Counters)) error {
+	c, _ := s.Load()
+	update(c)
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), platform.DirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, platform.FilePerm)
+}
+
+// BranchStats are the rotations recorded for a branch
+type BranchStats struct {
+	Rotations int
+
+	// Timed is how many rotations have a duration, and DurationSeconds their total
+	Timed           int
+	DurationSeconds float64
+
+	LastRotation time.Time
+}
+
+// Snapshot is everything Write exports
+type Snapshot struct {
+	Counters Counters
+	Branches map[string]*BranchStats
+
+	// Active is set while a session is running; Remaining is the time left
+	// on its rotation timer, if one is set
+	Active    bool
+	Branch    string
+	Driver    string
+	Remaining *time.Duration
+}
+
+// AddRotation counts a rotation on a branch. A zero duration means it
+// wasn't timed.
+func (s *Snapshot) AddRotation(branch string, duration time.Duration, at time.Time) {
+	if s.Branches == nil {
+		s.Branches = make(map[string]*BranchStats)
+	}
+	stats := s.Branches[branch]
+	if stats == nil {
+		stats = &BranchStats{}
+		s.Branches[branch] = stats
+	}
+	stats.Rotations++
+	if duration > 0 {
+		stats.Timed++
+		stats.DurationSeconds += duration.Seconds()
+	}
+	if at.After(stats.LastRotation) {
+		stats.LastRotation = at
+	}
+}
+
+// Write renders the snapshot in the Prometheus text exposition format
+func Write(w io.Writer, s *Snapshot) error {
+	var b strings.Builder
+
+	branches := make([]string, 0, len(s.Branches))
+	for branch := range s.Branches {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+
+	family(&b, "mob_claude_rotations_total", "counter", "Rotations recorded in local summaries.")
+	for _, branch := range branches {
+		sample(&b, "mob_claude_rotations_total", labels("branch", branch), float64(s.Branches[branch].Rotations))
+	}
+	family(&b, "mob_claude_rotation_duration_seconds", "summary", "Length of timed rotations.")
+	for _, branch := range branches {
+		stats := s.Branches[branch]
+		sample(&b, "mob_claude_rotation_duration_seconds_sum", labels("branch", branch), stats.DurationSeconds)
+		sample(&b, "mob_claude_rotation_duration_seconds_count", labels("branch", branch), float64(stats.Timed))
+	}
+	family(&b, "mob_claude_last_rotation_timestamp_seconds", "gauge", "When the latest rotation was recorded.")
+	for _, branch := range branches {
+		sample(&b, "mob_claude_last_rotation_timestamp_seconds", labels("branch", branch), float64(s.Branches[branch].LastRotation.Unix()))
+	}
+
+	family(&b, "mob_claude_summary_duration_seconds", "summary", "Time taken to generate rotation summaries.")
+	sample(&b, "mob_claude_summary_duration_seconds_sum", "", s.Counters.SummarySeconds)
+	sample(&b, "mob_claude_summary_duration_seconds_count", "", float64(s.Counters.SummaryCount))
+	family(&b, "mob_claude_summary_fallbacks_total", "counter", "Summaries that fell back to the basic summary.")
+	sample(&b, "mob_claude_summary_fallbacks_total", "", float64(s.Counters.SummaryFallbacks))
+	family(&b, "mob_claude_api_errors_total", "counter", "Dashboard requests that failed.")
+	sample(&b, "mob_claude_api_errors_total", "", float64(s.Counters.APIErrors))
+
+	active, sessionLabels := 0.0, ""
+	if s.Active {
+		active, sessionLabels = 1, labels("branch", s.Branch, "driver", s.Driver)
+	}
+	family(&b, "mob_claude_session_active", "gauge", "Whether a mob session is running.")
+	sample(&b, "mob_claude_session_active", sessionLabels, active)
+	if s.Active && s.Remaining != nil {
+		family(&b, "mob_claude_rotation_remaining_seconds", "gauge", "Time left on the rotation timer; negative when overdue.")
+		sample(&b, "mob_claude_rotation_remaining_seconds", sessionLabels, s.Remaining.Seconds())
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func family(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sample(b *strings.Builder, name, labels string, value float64) {
+	fmt.Fprintf(b, "%s%s %s\n", name, labels, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// labels renders name/value pairs as a label set
+func labels(pairs ...string) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, pairs[i], escape.Replace(pairs[i+1])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// WriteFile writes the snapshot to path for a textfile collector, replacing
+// the file atomically so the collector never reads it half written
+func WriteFile(path string, s *Snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	err = Write(tmp, s)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), platform.FilePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Serve exposes GET /metrics on listener until ctx is cancelled, taking a
+// fresh snapshot for every scrape
+func Serve(ctx context.Context, listener net.Listener, snapshot func() (*Snapshot, error)) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "use GET", http.StatusMethodNotAllowed)
+			return
+		}
+		s, err := snapshot()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		_ = Write(w, s)
+	})
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package mob
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Checkout switches to branch, fetching it from the remote first so a
+// branch that only exists there is checked out as a tracking branch
+func (w *Wrapper) Checkout(branch string) error {
+	// A failed fetch (say, offline) still leaves local branches to check out
+	_ = exec.Command("git", "fetch", "--quiet", w.Remote(), branch).Run()
+
+	if exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch).Run() == nil {
+		return w.git("checkout", "--quiet", branch)
+	}
+	return w.git("checkout", "--quiet", "-b", branch, "--track", w.Remote()+"/"+branch)
+}
+
+// git runs a git command, returning its error output on failure
+func (w *Wrapper) git(args ...string) error {
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %w\n%s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
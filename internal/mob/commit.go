@@ -0,0 +1,126 @@
+package mob
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// HasStagedChanges reports whether the index differs from HEAD, as it does
+// after 'mob done' squashes a session
+func (w *Wrapper) HasStagedChanges() (bool, error) {
+	err := exec.Command("git", "diff", "--cached", "--quiet").Run()
+	if err == nil {
+		return false, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return true, nil
+	}
+	return false, fmt.Errorf("failed to check staged changes: %w", err)
+}
+
+// Commit commits the staged changes with the given message
+func (w *Wrapper) Commit(message string) error {
+	cmd := exec.Command("git", "commit", "-F", "-")
+	cmd.Stdin = strings.NewReader(message)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+	return nil
+}
+
+// SetSquashMessage writes message to .git/SQUASH_MSG, which git offers as
+// the default message for the next 'git commit'
+func (w *Wrapper) SetSquashMessage(message string) error {
+	output, err := exec.Command("git", "rev-parse", "--git-path", "SQUASH_MSG").Output()
+	if err != nil {
+		return fmt.Errorf("failed to locate git directory: %w", err)
+	}
+	return os.WriteFile(strings.TrimSpace(string(output)), []byte(message+"\n"), platform.FilePerm)
+}
+
+// CommitInfo is a commit on the current branch
+type CommitInfo struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Time    time.Time `json:"time"`
+	Author  string    `json:"author"`
+}
+
+// CommitsSince returns the commits made on the current branch since a
+// time, oldest first
+func (w *Wrapper) CommitsSince(since time.Time) ([]CommitInfo, error) {
+	output, err := exec.Command("git", "log", "--reverse", "--since="+since.Format(time.RFC3339), "--format=%h%x1f%cI%x1f%an%x1f%s").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	return parseCommits(string(output)), nil
+}
+
+// WipCommitsSince returns the commits on the WIP branch of a session on
+// base made since a time, oldest first, and the ref they were read from.
+// The remote's copy is read when there is one, since it has the commits
+// pushed from other machines; it's as fresh as the last fetch. Without a
+// WIP branch, the ref is "".
+func (w *Wrapper) WipCommitsSince(base string, since time.Time) (string, []CommitInfo, error) {
+	wip := w.settings.WipBranch(base)
+	ref := w.Remote() + "/" + wip
+	if !refExists("refs/remotes/" + ref) {
+		ref = wip
+		if !refExists("refs/heads/" + ref) {
+			return "", nil, nil
+		}
+	}
+	output, err := exec.Command("git", "log", "--reverse", "--since="+since.Format(time.RFC3339), "--format=%h%x1f%cI%x1f%an%x1f%s", base+".."+ref).Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list commits on %s: %w", ref, err)
+	}
+	return ref, parseCommits(string(output)), nil
+}
+
+// FetchWip updates the remote's copy of the WIP branch of a session on
+// base. It's a no-op if the remote has no such branch.
+func (w *Wrapper) FetchWip(base string) error {
+	wip := w.settings.WipBranch(base)
+	if exec.Command("git", "ls-remote", "--exit-code", "--heads", w.Remote(), wip).Run() != nil {
+		return nil
+	}
+	return w.git("fetch", "--quiet", w.Remote(), wip+":refs/remotes/"+w.Remote()+"/"+wip)
+}
+
+// parseCommits parses 'git log' output in the %h, %cI, %an, %s format,
+// separated by unit separators
+func parseCommits(output string) []CommitInfo {
+	var commits []CommitInfo
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\x1f", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		at, _ := time.Parse(time.RFC3339, parts[1])
+		commits = append(commits, CommitInfo{Hash: parts[0], Time: at, Author: parts[2], Subject: parts[3]})
+	}
+	return commits
+}
+
+// FirstCommitAdding returns the oldest commit on the current branch that
+// added text to path, or nil if there is none
+func (w *Wrapper) FirstCommitAdding(path, text string) (*CommitInfo, error) {
+	output, err := exec.Command("git", "log", "--reverse", "-S"+text, "--format=%h%x1f%cI%x1f%an%x1f%s", "--", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to search history: %w", err)
+	}
+	commits := parseCommits(string(output))
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	return &commits[0], nil
+}
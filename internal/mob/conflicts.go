@@ -0,0 +1,78 @@
+package mob
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// conflictContext is how many lines before a conflict are kept for context
+const conflictContext = 3
+
+// Conflict is an unmerged file and its conflict-marker regions
+type Conflict struct {
+	File  string
+	Hunks []string
+}
+
+// GetConflicts returns the files git reports as unmerged, with each file's
+// conflict regions (plus a few lines of leading context). Files that can't
+// be read, such as deleted or binary ones, are returned without hunks.
+func (w *Wrapper) GetConflicts() ([]Conflict, error) {
+	output, err := exec.Command("git", "diff", "--name-only", "--diff-filter=U").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicted files: %w", err)
+	}
+
+	var conflicts []Conflict
+	for _, file := range strings.Split(string(output), "\n") {
+		if file = strings.TrimSpace(file); file == "" {
+			continue
+		}
+		conflict := Conflict{File: file}
+		if data, err := os.ReadFile(file); err == nil {
+			conflict.Hunks = conflictHunks(string(data))
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, nil
+}
+
+// conflictHunks extracts the regions between <<<<<<< and >>>>>>> markers
+func conflictHunks(content string) []string {
+	lines := strings.Split(content, "\n")
+	var hunks []string
+	start := -1
+	for i, line := range lines {
+		switch {
+		case start < 0 && strings.HasPrefix(line, "<<<<<<<"):
+			start = i
+		case start >= 0 && strings.HasPrefix(line, ">>>>>>>"):
+			from := start - conflictContext
+			if from < 0 {
+				from = 0
+			}
+			hunks = append(hunks, strings.Join(lines[from:i+1], "\n"))
+			start = -1
+		}
+	}
+	return hunks
+}
+
+// FormatConflicts renders conflicts as markdown, one section per file
+func FormatConflicts(conflicts []Conflict) string {
+	var b strings.Builder
+	for _, c := range conflicts {
+		fmt.Fprintf(&b, "### %s\n", c.File)
+		if len(c.Hunks) == 0 {
+			b.WriteString("(no conflict markers; the file was deleted on one side or is binary)\n\n")
+			continue
+		}
+		for _, hunk := range c.Hunks {
+			fmt.Fprintf(&b, "```\n%s\n```\n", hunk)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package mob
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Mob engines
+const (
+	// EngineMobSh runs mob.sh, the default
+	EngineMobSh = "mob.sh"
+
+	// EngineInternal runs sessions with plain git, without mob.sh
+	EngineInternal = "internal"
+)
+
+// Engines lists the engines SetEngine accepts
+var Engines = []string{EngineMobSh, EngineInternal}
+
+// Engine runs the mob session commands. The Wrapper's git helpers work the
+// same whichever engine runs the session.
+type Engine interface {
+	// Check verifies that the engine can run
+	Check() error
+	Start(branch string, args ...string) error
+	Next(args ...string) error
+	Done(args ...string) error
+	Status() (string, error)
+}
+
+// SetEngine selects the engine that runs mob sessions: EngineMobSh, or
+// EngineInternal for teams without mob.sh. "" selects mob.sh.
+func (w *Wrapper) SetEngine(name string) error {
+	switch name {
+	case "", EngineMobSh:
+		w.engine = &mobShEngine{w}
+	case EngineInternal:
+		w.engine = &gitEngine{w}
+	default:
+		return fmt.Errorf("unknown mob engine: %s (use %s or %s)", name, EngineMobSh, EngineInternal)
+	}
+	return nil
+}
+
+// IsEngine reports whether name is an engine SetEngine accepts
+func IsEngine(name string) bool {
+	return name == "" || slices.Contains(Engines, name)
+}
+
+// mobShEngine runs the mob binary: mob.sh, or another implementation of
+// its command line set with SetMobPath
+type mobShEngine struct {
+	w *Wrapper
+}
+
+func (e *mobShEngine) Check() error {
+	return e.w.checkMobSh()
+}
+
+func (e *mobShEngine) Start(branch string, args ...string) error {
+	cmdArgs := []string{"start"}
+	if branch != "" {
+		cmdArgs = append(cmdArgs, branch)
+	}
+	return e.w.runPassthrough(append(cmdArgs, args...)...)
+}
+
+func (e *mobShEngine) Next(args ...string) error {
+	return e.w.runPassthrough(append([]string{"next"}, args...)...)
+}
+
+func (e *mobShEngine) Done(args ...string) error {
+	return e.w.runPassthrough(append([]string{"done"}, args...)...)
+}
+
+func (e *mobShEngine) Status() (string, error) {
+	return e.w.runCapture("status")
+}
@@ -0,0 +1,276 @@
+package mob
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// gitEngine runs mob sessions with plain git, for teams that can't install
+// mob.sh. It follows mob.sh's conventions, so its sessions look the same:
+// the session lives on a WIP branch named after the base branch with
+// mob.sh's prefix and qualifier, each handoff is a WIP commit pushed to
+// the remote, and done squashes the WIP branch onto the base branch. In a
+// repository without the remote, the session stays local.
+type gitEngine struct {
+	w *Wrapper
+}
+
+func (e *gitEngine) Check() error {
+	if err := exec.Command("git", "rev-parse", "--is-inside-work-tree").Run(); err != nil {
+		return fmt.Errorf("the internal mob engine needs git and a git repository: %w", err)
+	}
+	return nil
+}
+
+func (e *gitEngine) Start(branch string, args ...string) error {
+	flags, err := engineFlags("start", args, "--include-uncommitted-changes", "-i")
+	if err != nil {
+		return err
+	}
+	if branch != "" {
+		if err := e.w.git("checkout", "--quiet", branch); err != nil {
+			return err
+		}
+	}
+	base, wip, err := e.branches()
+	if err != nil {
+		return err
+	}
+	if len(flags) == 0 {
+		if files, err := e.w.UncommittedFiles(); err != nil {
+			return err
+		} else if len(files) > 0 {
+			return fmt.Errorf("there are uncommitted changes; commit or stash them first, or pass --include-uncommitted-changes")
+		}
+	}
+
+	remote, remoteWip, err := e.fetch(wip)
+	if err != nil {
+		return err
+	}
+	switch {
+	case refExists("refs/heads/" + wip):
+		if err := e.w.git("checkout", "--quiet", wip); err != nil {
+			return err
+		}
+		if remoteWip {
+			if err := e.w.git("merge", "--quiet", "--ff-only", e.w.Remote()+"/"+wip); err != nil {
+				return err
+			}
+		}
+		fmt.Print(e.w.sprintf("Joined the mob session on %s\n", wip))
+	case remoteWip:
+		if err := e.w.git("checkout", "--quiet", "-b", wip, "--track", e.w.Remote()+"/"+wip); err != nil {
+			return err
+		}
+		fmt.Print(e.w.sprintf("Joined the mob session on %s\n", wip))
+	default:
+		if err := e.w.git("checkout", "--quiet", "-b", wip, base); err != nil {
+			return err
+		}
+		if remote {
+			if err := e.w.git("push", "--quiet", "--no-verify", "--set-upstream", e.w.Remote(), wip); err != nil {
+				return err
+			}
+		}
+		fmt.Print(e.w.sprintf("Started a mob session on %s from %s\n", wip, base))
+	}
+	return nil
+}
+
+func (e *gitEngine) Next(args ...string) error {
+	flags, err := engineFlags("next", args, "--stay", "-s")
+	if err != nil {
+		return err
+	}
+	base, wip, err := e.onWipBranch()
+	if err != nil {
+		return err
+	}
+	if err := e.commitWip(); err != nil {
+		return err
+	}
+	if e.hasRemote() {
+		if err := e.w.git("push", "--quiet", "--no-verify", e.w.Remote(), wip); err != nil {
+			return err
+		}
+	}
+	if len(flags) > 0 {
+		fmt.Print(e.w.sprintf("Handed off, staying on %s\n", wip))
+		return nil
+	}
+	if err := e.w.git("checkout", "--quiet", base); err != nil {
+		return err
+	}
+	fmt.Print(e.w.sprintf("Handed off, back on %s\n", base))
+	return nil
+}
+
+func (e *gitEngine) Done(args ...string) error {
+	flags, err := engineFlags("done", args, "--no-squash")
+	if err != nil {
+		return err
+	}
+	base, wip, err := e.branches()
+	if err != nil {
+		return err
+	}
+	if current, _ := e.w.GetCurrentBranch(); current == wip {
+		if err := e.commitWip(); err != nil {
+			return err
+		}
+	}
+
+	remote, remoteWip, err := e.fetch(wip)
+	if err != nil {
+		return err
+	}
+	localWip := refExists("refs/heads/" + wip)
+	if !localWip && !remoteWip {
+		return fmt.Errorf("no mob session on %s to finish", base)
+	}
+	// Bring the session's latest commits together before merging them
+	source := wip
+	if localWip && remoteWip {
+		if err := e.w.git("checkout", "--quiet", wip); err != nil {
+			return err
+		}
+		if err := e.w.git("merge", "--quiet", "--no-edit", e.w.Remote()+"/"+wip); err != nil {
+			return err
+		}
+	} else if remoteWip {
+		source = e.w.Remote() + "/" + wip
+	}
+
+	if err := e.w.git("checkout", "--quiet", base); err != nil {
+		return err
+	}
+	if remote && refExists("refs/remotes/"+e.w.Remote()+"/"+base) {
+		if err := e.w.git("merge", "--quiet", "--ff-only", e.w.Remote()+"/"+base); err != nil {
+			return err
+		}
+	}
+	if len(flags) > 0 {
+		err = e.w.git("merge", "--quiet", "--no-edit", source)
+	} else {
+		err = e.w.git("merge", "--quiet", "--squash", source)
+	}
+	if err != nil {
+		return err
+	}
+
+	if localWip {
+		if err := e.w.git("branch", "--quiet", "-D", wip); err != nil {
+			return err
+		}
+	}
+	if remoteWip {
+		if err := e.w.git("push", "--quiet", "--no-verify", e.w.Remote(), "--delete", wip); err != nil {
+			return err
+		}
+	}
+	if len(flags) > 0 {
+		fmt.Print(e.w.sprintf("Merged %s into %s\n", wip, base))
+	} else {
+		fmt.Print(e.w.sprintf("The session's changes are staged on %s; commit them to finish\n", base))
+	}
+	return nil
+}
+
+func (e *gitEngine) Status() (string, error) {
+	base, wip, err := e.branches()
+	if err != nil {
+		return "", err
+	}
+	source := wip
+	if !refExists("refs/heads/" + wip) {
+		source = e.w.Remote() + "/" + wip
+		if !refExists("refs/remotes/" + source) {
+			return e.w.sprintf("No mob session on %s\n", base), nil
+		}
+	}
+	output, err := exec.Command("git", "log", "--oneline", base+".."+source).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list session commits: %w", err)
+	}
+	status := e.w.sprintf("Mob session on %s (WIP branch %s)\n", base, wip)
+	if commits := strings.TrimSpace(string(output)); commits != "" {
+		status += commits + "\n"
+	}
+	return status, nil
+}
+
+// branches returns the session's base and WIP branch, from the current
+// branch, which may be either
+func (e *gitEngine) branches() (base, wip string, err error) {
+	current, err := e.w.GetCurrentBranch()
+	if err != nil {
+		return "", "", err
+	}
+	if current == "" {
+		return "", "", fmt.Errorf("not on a branch")
+	}
+	base = e.w.settings.BaseBranch(current)
+	return base, e.w.settings.WipBranch(base), nil
+}
+
+// onWipBranch returns the session's branches, or an error if the current
+// branch isn't its WIP branch
+func (e *gitEngine) onWipBranch() (base, wip string, err error) {
+	base, wip, err = e.branches()
+	if err != nil {
+		return "", "", err
+	}
+	if current, _ := e.w.GetCurrentBranch(); current != wip {
+		return "", "", fmt.Errorf("not on the mob session's branch %s. Run 'mob-claude start' first", wip)
+	}
+	return base, wip, nil
+}
+
+// commitWip commits every change in the working tree, untracked files
+// included, as a WIP commit
+func (e *gitEngine) commitWip() error {
+	if err := e.w.git("add", "--all"); err != nil {
+		return err
+	}
+	staged, err := e.w.HasStagedChanges()
+	if err != nil || !staged {
+		return err
+	}
+	return e.w.git("commit", "--quiet", "--no-verify", "-m", e.w.wipCommitMessage())
+}
+
+// hasRemote reports whether the remote is configured
+func (e *gitEngine) hasRemote() bool {
+	return exec.Command("git", "remote", "get-url", e.w.Remote()).Run() == nil
+}
+
+// fetch updates the remote's branches, if there is a remote, and reports
+// whether it has the WIP branch
+func (e *gitEngine) fetch(wip string) (remote, remoteWip bool, err error) {
+	if !e.hasRemote() {
+		return false, false, nil
+	}
+	if err := e.w.git("fetch", "--quiet", "--prune", e.w.Remote()); err != nil {
+		return true, false, err
+	}
+	return true, refExists("refs/remotes/" + e.w.Remote() + "/" + wip), nil
+}
+
+// refExists reports whether a git ref exists
+func refExists(ref string) bool {
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", ref).Run() == nil
+}
+
+// engineFlags returns the flags in args, rejecting any the internal
+// engine doesn't support for the command
+func engineFlags(command string, args []string, supported ...string) ([]string, error) {
+	for _, arg := range args {
+		if !slices.Contains(supported, arg) {
+			return nil, fmt.Errorf("the internal mob engine doesn't support %q for %s", arg, command)
+		}
+	}
+	return args, nil
+}
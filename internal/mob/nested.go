@@ -0,0 +1,40 @@
+package mob
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// FindEnclosingRepo reports the repository containing the working directory
+// and, when that repository is nested inside another (a submodule or a
+// vendored checkout), the enclosing one. outer is empty when not nested.
+func FindEnclosingRepo() (inner, outer string, err error) {
+	inner, err = gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", err
+	}
+
+	// Submodules know their superproject directly
+	if super, err := gitOutput("rev-parse", "--show-superproject-working-tree"); err == nil && super != "" {
+		return inner, filepath.Clean(super), nil
+	}
+
+	// Otherwise look for a repository above the inner one
+	parent := filepath.Dir(inner)
+	if parent == inner {
+		return inner, "", nil
+	}
+	if outer, err := gitOutput("-C", parent, "rev-parse", "--show-toplevel"); err == nil && outer != "" {
+		return inner, filepath.Clean(outer), nil
+	}
+	return inner, "", nil
+}
+
+func gitOutput(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(strings.TrimSpace(string(output))), nil
+}
@@ -0,0 +1,163 @@
+package mob
+
+import (
+	"bufio"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Settings holds the mob.sh options that affect WIP branch naming and
+// commits, the remote mob.sh pushes to, and its shared timer room
+type Settings struct {
+	WipBranchPrefix             string
+	WipBranchQualifier          string
+	WipBranchQualifierSeparator string
+	WipCommitMessage            string
+	RemoteName                  string
+
+	// TimerRoom is the room on the shared web timer (MOB_TIMER_ROOM), or
+	// "" if the mob doesn't use one; TimerURL is the timer service and
+	// TimerUser the name shown for timers started from here
+	TimerRoom                      string
+	TimerRoomUseWipBranchQualifier bool
+	TimerURL                       string
+	TimerUser                      string
+}
+
+// DefaultSettings returns mob.sh's built-in defaults
+func DefaultSettings() Settings {
+	return Settings{
+		WipBranchPrefix:             "mob/",
+		WipBranchQualifierSeparator: "-",
+		WipCommitMessage:            "mob next [ci-skip] [ci skip] [skip ci]",
+		RemoteName:                  "origin",
+		TimerURL:                    "https://timer.mob.sh/",
+	}
+}
+
+// TimerRoomName returns the shared timer room to use, which mob.sh takes
+// from the WIP branch qualifier when MOB_TIMER_ROOM_USE_WIP_BRANCH_QUALIFIER
+// is set. It returns "" if there's none.
+func (s Settings) TimerRoomName() string {
+	if s.TimerRoomUseWipBranchQualifier && s.WipBranchQualifier != "" {
+		return s.WipBranchQualifier
+	}
+	return s.TimerRoom
+}
+
+// TimerRoomURL returns the web address of the shared timer room, or "" if
+// there's none
+func (s Settings) TimerRoomURL() string {
+	room := s.TimerRoomName()
+	if room == "" {
+		return ""
+	}
+	return strings.TrimSuffix(s.TimerURL, "/") + "/" + url.PathEscape(room)
+}
+
+// LoadSettings resolves mob.sh settings the same way mob.sh does:
+// ~/.mob, then the repository's .mob file, then MOB_* environment variables
+func LoadSettings() Settings {
+	s := DefaultSettings()
+
+	if home, err := os.UserHomeDir(); err == nil {
+		s.applyFile(filepath.Join(home, ".mob"))
+	}
+	if root, err := repoRoot(); err == nil {
+		s.applyFile(filepath.Join(root, ".mob"))
+	}
+	s.apply(os.LookupEnv)
+
+	return s
+}
+
+// BaseBranch strips the WIP prefix and qualifier from a mob branch name
+func (s Settings) BaseBranch(branch string) string {
+	if s.WipBranchPrefix != "" && strings.HasPrefix(branch, s.WipBranchPrefix) {
+		branch = strings.TrimPrefix(branch, s.WipBranchPrefix)
+		if s.WipBranchQualifier != "" {
+			branch = strings.TrimSuffix(branch, s.WipBranchQualifierSeparator+s.WipBranchQualifier)
+		}
+	}
+	// Remove legacy -wip suffix if present
+	return strings.TrimSuffix(branch, "-wip")
+}
+
+// WipBranch names the WIP branch of a session on base, the inverse of
+// BaseBranch, e.g. "mob/feature-auth-green" for "feature-auth"
+func (s Settings) WipBranch(base string) string {
+	branch := s.WipBranchPrefix + base
+	if s.WipBranchQualifier != "" {
+		branch += s.WipBranchQualifierSeparator + s.WipBranchQualifier
+	}
+	return branch
+}
+
+// applyFile applies KEY=value lines from a mob.sh config file, ignoring missing files
+func (s *Settings) applyFile(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	s.apply(func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	})
+}
+
+func (s *Settings) apply(lookup func(string) (string, bool)) {
+	if v, ok := lookup("MOB_WIP_BRANCH_PREFIX"); ok {
+		s.WipBranchPrefix = v
+	}
+	if v, ok := lookup("MOB_WIP_BRANCH_QUALIFIER"); ok {
+		s.WipBranchQualifier = v
+	}
+	if v, ok := lookup("MOB_WIP_BRANCH_QUALIFIER_SEPARATOR"); ok {
+		s.WipBranchQualifierSeparator = v
+	}
+	if v, ok := lookup("MOB_WIP_COMMIT_MESSAGE"); ok && v != "" {
+		s.WipCommitMessage = v
+	}
+	if v, ok := lookup("MOB_REMOTE_NAME"); ok && v != "" {
+		s.RemoteName = v
+	}
+	if v, ok := lookup("MOB_TIMER_ROOM"); ok {
+		s.TimerRoom = v
+	}
+	if v, ok := lookup("MOB_TIMER_ROOM_USE_WIP_BRANCH_QUALIFIER"); ok {
+		s.TimerRoomUseWipBranchQualifier = v == "true"
+	}
+	if v, ok := lookup("MOB_TIMER_URL"); ok && v != "" {
+		s.TimerURL = v
+	}
+	if v, ok := lookup("MOB_TIMER_USER"); ok {
+		s.TimerUser = v
+	}
+}
+
+func repoRoot() (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,45 @@
+package mob
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// UncommittedFiles returns the working tree's changes that aren't
+// committed yet, modified and untracked alike, as git status reports them
+func (w *Wrapper) UncommittedFiles() ([]string, error) {
+	output, err := exec.Command("git", "status", "--porcelain", "-z", "--untracked-files=all").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check git status: %w", err)
+	}
+
+	var files []string
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		files = append(files, entry[3:])
+		// Renames and copies are followed by the path they came from
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++
+		}
+	}
+	return files, nil
+}
+
+// StashFiles moves the given working tree changes, untracked files
+// included, into a git stash so a handoff leaves them behind
+func (w *Wrapper) StashFiles(message string, files []string) error {
+	args := append([]string{"stash", "push", "--include-untracked", "-m", message, "--"}, files...)
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git stash failed: %w", err)
+	}
+	return nil
+}
@@ -5,51 +5,123 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
 )
 
 // Wrapper provides methods to interact with the mob.sh CLI
 type Wrapper struct {
-	mobPath string
+	engine     Engine
+	mobPath    string
+	mobPathSet bool
+	settings   Settings
+	diffBase   string
+	remote     string
+	wipSummary string
+	sprintf    func(format string, args ...interface{}) string
 }
 
 // NewWrapper creates a new mob.sh wrapper
 func NewWrapper() *Wrapper {
-	// Try to find mob in PATH
-	mobPath, err := exec.LookPath("mob")
+	w := &Wrapper{mobPath: defaultMobPath(), settings: LoadSettings(), sprintf: fmt.Sprintf}
+	w.engine = &mobShEngine{w}
+	return w
+}
+
+// defaultMobPath finds mob in PATH (mob.exe / mob.cmd on Windows)
+func defaultMobPath() string {
+	mobPath, err := platform.FindExecutable("mob")
 	if err != nil {
-		mobPath = "mob" // Will fail at runtime if not found
+		return "mob" // Will fail at runtime if not found
 	}
-	return &Wrapper{mobPath: mobPath}
+	return mobPath
 }
 
-// Start executes 'mob start' with the given branch name and any extra flags
-func (w *Wrapper) Start(branch string, extraArgs ...string) error {
-	args := []string{"start"}
-	if branch != "" {
-		args = append(args, branch)
+// SetMobPath sets the mob binary to run, such as "/opt/mob/mob" or another
+// implementation of mob.sh's command line, instead of the mob found in
+// PATH. A bare name is looked up in PATH. "" restores the default.
+func (w *Wrapper) SetMobPath(path string) {
+	if path == "" {
+		w.mobPath, w.mobPathSet = defaultMobPath(), false
+		return
+	}
+	if found, err := platform.FindExecutable(path); err == nil {
+		path = found
 	}
-	args = append(args, extraArgs...)
-	return w.runPassthrough(args...)
+	w.mobPath, w.mobPathSet = path, true
+}
+
+// SetSprintf sets how the internal engine formats the messages it
+// prints, such as i18n.Sprintf to translate them
+func (w *Wrapper) SetSprintf(sprintf func(format string, args ...interface{}) string) {
+	w.sprintf = sprintf
+}
+
+// SetDiffBase sets the branch diffs are taken from, such as "develop",
+// instead of detecting it. "" restores detection.
+func (w *Wrapper) SetDiffBase(branch string) {
+	w.diffBase = branch
 }
 
-// Next executes 'mob next' to hand off to the next driver
+// SetRemote sets the git remote to use, such as "upstream", overriding
+// mob.sh's MOB_REMOTE_NAME. "" restores mob.sh's choice.
+func (w *Wrapper) SetRemote(name string) {
+	w.remote = name
+}
+
+// maxWipSummary caps the summary added to WIP commit messages, in runes
+const maxWipSummary = 72
+
+// SetWipCommitSummary adds summary, such as the rotation's TL;DR, to the
+// message of the WIP commit next makes. The message still starts with
+// mob.sh's MOB_WIP_COMMIT_MESSAGE, so its CI skip markers stay and mob.sh
+// still recognizes the commit. "" restores mob.sh's message.
+func (w *Wrapper) SetWipCommitSummary(summary string) {
+	summary, _, _ = strings.Cut(strings.TrimSpace(summary), "\n")
+	if runes := []rune(summary); len(runes) > maxWipSummary {
+		summary = strings.TrimSpace(string(runes[:maxWipSummary-1])) + "…"
+	}
+	w.wipSummary = summary
+}
+
+// wipCommitMessage is the message for the WIP commit next makes
+func (w *Wrapper) wipCommitMessage() string {
+	if w.wipSummary == "" {
+		return w.settings.WipCommitMessage
+	}
+	return w.settings.WipCommitMessage + ": " + w.wipSummary
+}
+
+// Remote returns the git remote in use: the one set with SetRemote, then
+// mob.sh's MOB_REMOTE_NAME, then origin
+func (w *Wrapper) Remote() string {
+	if w.remote != "" {
+		return w.remote
+	}
+	return w.settings.RemoteName
+}
+
+// Start joins the mob session, like 'mob start', with the given branch
+// name and any extra flags
+func (w *Wrapper) Start(branch string, extraArgs ...string) error {
+	return w.engine.Start(branch, extraArgs...)
+}
+
+// Next hands off to the next driver, like 'mob next'
 func (w *Wrapper) Next(extraArgs ...string) error {
-	args := []string{"next"}
-	args = append(args, extraArgs...)
-	return w.runPassthrough(args...)
+	return w.engine.Next(extraArgs...)
 }
 
-// Done executes 'mob done' to squash commits and complete the mob session
+// Done squashes the session's commits and completes it, like 'mob done'
 func (w *Wrapper) Done(extraArgs ...string) error {
-	args := []string{"done"}
-	args = append(args, extraArgs...)
-	return w.runPassthrough(args...)
+	return w.engine.Done(extraArgs...)
 }
 
-// Status executes 'mob status' and returns the output
+// Status describes the mob session, like 'mob status'
 func (w *Wrapper) Status() (string, error) {
-	return w.runCapture("status")
+	return w.engine.Status()
 }
 
 // GetCurrentBranch returns the current git branch name
@@ -62,14 +134,15 @@ func (w *Wrapper) GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// GetRepoURL returns the remote URL for the repository
+// GetRepoURL returns the URL of the repository's remote (see Remote),
+// normalized with NormalizeRepoURL so every clone reports the same URL
 func (w *Wrapper) GetRepoURL() (string, error) {
-	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd := exec.Command("git", "remote", "get-url", w.Remote())
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to get repo URL: %w", err)
+		return "", fmt.Errorf("failed to get repo URL for remote %s: %w", w.Remote(), err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	return NormalizeRepoURL(strings.TrimSpace(string(output))), nil
 }
 
 // GetDiffSinceLastCommit returns the git diff for staged and unstaged changes
@@ -88,29 +161,137 @@ func (w *Wrapper) GetDiffSinceLastCommit() (string, error) {
 	return string(output), nil
 }
 
-// GetDiffFromBase returns the diff from the base branch (usually main/master)
+// GetDiffFromBase returns the diff from the base branch (see DiffBases)
 func (w *Wrapper) GetDiffFromBase() (string, error) {
-	// Try to find merge-base with main or master
-	bases := []string{"origin/main", "origin/master", "main", "master"}
+	if mergeBase, ok := w.mergeBase(); ok {
+		if output, err := exec.Command("git", "diff", mergeBase).Output(); err == nil {
+			return string(output), nil
+		}
+	}
 
-	for _, base := range bases {
-		cmd := exec.Command("git", "merge-base", base, "HEAD")
-		mergeBase, err := cmd.Output()
-		if err != nil {
-			continue
+	// Fallback to just the last commit diff
+	return w.GetDiffSinceLastCommit()
+}
+
+// DiffBases returns the refs tried, in order, as the base of diffs: the
+// branch set with SetDiffBase if any, otherwise the branch the remote's
+// HEAD points at followed by main and master
+func (w *Wrapper) DiffBases() []string {
+	remote := w.Remote()
+	if w.diffBase != "" {
+		if strings.HasPrefix(w.diffBase, remote+"/") {
+			return []string{w.diffBase}
 		}
+		return []string{remote + "/" + w.diffBase, w.diffBase}
+	}
 
-		// Get diff from merge-base
-		cmd = exec.Command("git", "diff", strings.TrimSpace(string(mergeBase)))
-		output, err := cmd.Output()
-		if err != nil {
-			continue
+	bases := []string{remote + "/main", remote + "/master", "main", "master"}
+	output, err := exec.Command("git", "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD").Output()
+	if head := strings.TrimSpace(string(output)); err == nil && head != "" && !slices.Contains(bases, head) {
+		bases = append([]string{head}, bases...)
+	}
+	return bases
+}
+
+// mergeBase returns the merge base of HEAD and the first base branch that exists
+func (w *Wrapper) mergeBase() (string, bool) {
+	for _, base := range w.DiffBases() {
+		output, err := exec.Command("git", "merge-base", base, "HEAD").Output()
+		if err == nil {
+			return strings.TrimSpace(string(output)), true
 		}
-		return string(output), nil
 	}
+	return "", false
+}
 
-	// Fallback to just the last commit diff
-	return w.GetDiffSinceLastCommit()
+// GetDiffRange returns the commits that bound GetDiffFromBase's diff: the
+// merge base, and a commit of the working tree made with 'git stash create'
+// (HEAD if the tree is clean), which leaves the tree and stash untouched.
+// Diffing them with GetDiffBetween reproduces the diff after the branch has
+// moved on.
+func (w *Wrapper) GetDiffRange() (base, head string, err error) {
+	base, ok := w.mergeBase()
+	if !ok {
+		return "", "", fmt.Errorf("no merge base with %s", strings.Join(w.DiffBases(), ", "))
+	}
+	output, err := exec.Command("git", "stash", "create").Output()
+	if head = strings.TrimSpace(string(output)); err == nil && head != "" {
+		return base, head, nil
+	}
+	if output, err = exec.Command("git", "rev-parse", "HEAD").Output(); err != nil {
+		return "", "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return base, strings.TrimSpace(string(output)), nil
+}
+
+// GetDiffFrom returns the diff from ref to the working tree
+func (w *Wrapper) GetDiffFrom(ref string) (string, error) {
+	output, err := exec.Command("git", "diff", ref).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff from %s: %w", ref, err)
+	}
+	return string(output), nil
+}
+
+// GetDiffBetween returns the diff between two commits
+func (w *Wrapper) GetDiffBetween(from, to string) (string, error) {
+	output, err := exec.Command("git", "diff", from, to).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff from %s to %s: %w", from, to, err)
+	}
+	return string(output), nil
+}
+
+// GetDiffStatFromBase returns 'git diff --stat' since the base branch (see
+// DiffBases), including uncommitted changes
+func (w *Wrapper) GetDiffStatFromBase() (string, error) {
+	args := []string{"diff", "--stat", "HEAD"}
+	if mergeBase, ok := w.mergeBase(); ok {
+		args = []string{"diff", "--stat", mergeBase}
+	}
+
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff stats: %w", err)
+	}
+	return string(output), nil
+}
+
+// GetNumStatFromBase returns 'git diff --numstat' since the base branch
+// (see DiffBases), including uncommitted changes
+func (w *Wrapper) GetNumStatFromBase() (string, error) {
+	args := []string{"diff", "--numstat", "HEAD"}
+	if mergeBase, ok := w.mergeBase(); ok {
+		args = []string{"diff", "--numstat", mergeBase}
+	}
+
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff stats: %w", err)
+	}
+	return string(output), nil
+}
+
+// GetChangedFilesFromBase returns the paths changed since the base branch
+// (see DiffBases), including uncommitted changes
+func (w *Wrapper) GetChangedFilesFromBase() ([]string, error) {
+	args := []string{"diff", "--name-only", "HEAD"}
+	if mergeBase, ok := w.mergeBase(); ok {
+		args = []string{"diff", "--name-only", mergeBase}
+	}
+
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
 }
 
 // GetRecentCommits returns recent commit messages on the current branch
@@ -129,31 +310,67 @@ func (w *Wrapper) IsMobBranch() (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	// Mob branches typically have a wip/ prefix or -wip suffix
-	return strings.HasPrefix(branch, "mob/") ||
+	// Mob branches use the configured WIP prefix (mob/ by default),
+	// or the older -wip suffix and /mob- conventions
+	return (w.settings.WipBranchPrefix != "" && strings.HasPrefix(branch, w.settings.WipBranchPrefix)) ||
 		strings.HasSuffix(branch, "-wip") ||
 		strings.Contains(branch, "/mob-"), nil
 }
 
 // GetBaseBranch extracts the base branch name from a mob branch
-// e.g., "mob/feature-auth" -> "feature-auth", "feature-auth-wip" -> "feature-auth"
+// e.g., "mob/feature-auth" -> "feature-auth", "feature-auth-wip" -> "feature-auth",
+// "mob/feature-auth-green" -> "feature-auth" when the WIP qualifier is "green"
 func (w *Wrapper) GetBaseBranch() (string, error) {
 	branch, err := w.GetCurrentBranch()
 	if err != nil {
 		return "", err
 	}
+	return w.settings.BaseBranch(branch), nil
+}
+
+// GetStartBranch predicts the base branch GetBaseBranch will report once
+// 'mob start' has run with args, taking a --branch (-b) qualifier into
+// account, so a session's branch can be looked up before joining it
+func (w *Wrapper) GetStartBranch(args []string) (string, error) {
+	base, err := w.GetBaseBranch()
+	if err != nil {
+		return "", err
+	}
 
-	// Remove mob/ prefix if present
-	branch = strings.TrimPrefix(branch, "mob/")
-	// Remove -wip suffix if present
-	branch = strings.TrimSuffix(branch, "-wip")
+	qualifier := ""
+	for i, arg := range args {
+		switch {
+		case (arg == "-b" || arg == "--branch") && i+1 < len(args):
+			qualifier = args[i+1]
+		case strings.HasPrefix(arg, "--branch="):
+			qualifier = strings.TrimPrefix(arg, "--branch=")
+		}
+	}
+	if qualifier == "" || qualifier == w.settings.WipBranchQualifier {
+		return base, nil
+	}
 
-	return branch, nil
+	// mob.sh names the WIP branch with the flag's qualifier, which
+	// BaseBranch then only strips if it's the configured one
+	started := w.settings
+	started.WipBranchQualifier = qualifier
+	return w.settings.BaseBranch(started.WipBranch(base)), nil
 }
 
 // runPassthrough runs a mob command with output going directly to stdout/stderr
 func (w *Wrapper) runPassthrough(args ...string) error {
 	cmd := exec.Command(w.mobPath, args...)
+	var env []string
+	if w.remote != "" {
+		// Keep mob.sh on the same remote
+		env = append(env, "MOB_REMOTE_NAME="+w.remote)
+	}
+	if w.wipSummary != "" {
+		env = append(env, "MOB_WIP_COMMIT_MESSAGE="+w.wipCommitMessage())
+	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -175,11 +392,20 @@ func (w *Wrapper) runCapture(args ...string) (string, error) {
 	return stdout.String(), nil
 }
 
-// CheckMobInstalled verifies that mob.sh is available
+// CheckMobInstalled verifies that the engine can run: that mob.sh is
+// available, or git for the internal engine
 func (w *Wrapper) CheckMobInstalled() error {
+	return w.engine.Check()
+}
+
+// checkMobSh verifies that mob.sh is available
+func (w *Wrapper) checkMobSh() error {
 	cmd := exec.Command(w.mobPath, "version")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("mob.sh is not installed or not in PATH. Install from: https://mob.sh")
+		if w.mobPathSet {
+			return fmt.Errorf("could not run mob at %s (mobPath): %w", w.mobPath, err)
+		}
+		return fmt.Errorf("mob.sh is not installed or not in PATH. Install from: https://mob.sh, or set mobPath")
 	}
 	return nil
 }
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// chatChannel POSTs a JSON body to an incoming webhook. body renders the
+// message in the format the service expects.
+type chatChannel struct {
+	url        string
+	body       func(Message) any
+	httpClient *http.Client
+}
+
+func newChatChannel(url string, body func(Message) any) *chatChannel {
+	return &chatChannel{url: url, body: body, httpClient: &http.Client{Timeout: Timeout}}
+}
+
+func (c *chatChannel) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(c.body(msg))
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "mob-claude")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// text joins the message's title and text, wrapping the title in the
+// service's bold markup
+func text(msg Message, bold string) string {
+	if msg.Text == "" {
+		return bold + msg.Title + bold
+	}
+	return bold + msg.Title + bold + "\n" + msg.Text
+}
+
+// slackBody renders a Slack incoming webhook message
+func slackBody(msg Message) any {
+	return map[string]string{"text": text(msg, "*")}
+}
+
+// discordBody renders a Discord webhook message
+func discordBody(msg Message) any {
+	return map[string]string{"content": text(msg, "**")}
+}
+
+// teamsBody renders an Adaptive Card for a Microsoft Teams workflow webhook
+func teamsBody(msg Message) any {
+	body := []map[string]any{{"type": "TextBlock", "text": msg.Title, "weight": "Bolder", "wrap": true}}
+	if msg.Text != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": msg.Text, "wrap": true})
+	}
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]any{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+			},
+		}},
+	}
+}
+
+// webhookBody is the message itself, for custom integrations
+func webhookBody(msg Message) any {
+	return msg
+}
@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+	"github.com/mob-claude/mob-claude/internal/events"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// Timeout bounds each delivery so a slow chat service can't hold up a handoff
+const Timeout = 5 * time.Second
+
+// Message is a notification about a session event
+type Message struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	Title     string    `json:"title"`
+	Text      string    `json:"text,omitempty"`
+	Team      string    `json:"team,omitempty"`
+	Branch    string    `json:"branch,omitempty"`
+	Driver    string    `json:"driverName,omitempty"`
+
+	// Summary is the rotation or final summary of a handoff or done event,
+	// sent to webhook channels only
+	Summary *plans.Summary `json:"summary,omitempty"`
+}
+
+// Channel delivers notifications to one destination
+type Channel interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Routed reports whether n receives the named event
+func Routed(n config.Notification, event string) bool {
+	return len(n.Events) == 0 || slices.Contains(n.Events, event)
+}
+
+// DefaultRoutes are used when no notifications are configured: a desktop
+// notification when the rotation timer expires
+var DefaultRoutes = []config.Notification{{Channel: events.ChannelDesktop, Events: []string{events.TimerExpired}}}
+
+// NewChannel creates the channel a notification is configured for, reading
+// its URL from the secret store. A Slack channel without one posts to the
+// slackWebhook secret.
+func NewChannel(n config.Notification, slackWebhook string) (Channel, error) {
+	url, err := URL(n)
+	if err != nil {
+		return nil, err
+	}
+	if n.Channel == events.ChannelSlack && url == "" {
+		url = slackWebhook
+	}
+	if n.Channel != events.ChannelDesktop && url == "" {
+		return nil, fmt.Errorf("%s notifications need a URL", n.Channel)
+	}
+
+	switch n.Channel {
+	case events.ChannelDesktop:
+		return desktopChannel{}, nil
+	case events.ChannelSlack:
+		return newChatChannel(url, slackBody), nil
+	case events.ChannelDiscord:
+		return newChatChannel(url, discordBody), nil
+	case events.ChannelTeams:
+		return newChatChannel(url, teamsBody), nil
+	case events.ChannelWebhook:
+		return newChatChannel(url, webhookBody), nil
+	default:
+		return nil, fmt.Errorf("unknown notification channel: %s", n.Channel)
+	}
+}
+
+// URL returns the URL a notification posts to: its secret, or the
+// plaintext URL of a config that couldn't be moved to the secret store
+func URL(n config.Notification) (string, error) {
+	if n.Secret == "" {
+		return n.URL, nil
+	}
+	// Only notify.* keys, so a config.json can't point a channel at
+	// another secret, such as apiToken
+	if !config.IsNotifySecret(n.Secret) {
+		return "", fmt.Errorf("secret %q is not a %s<name> key", n.Secret, config.NotifySecretPrefix)
+	}
+	url, err := config.GetSecret(n.Secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", n.Secret, err)
+	}
+	if url == "" {
+		return "", fmt.Errorf("%s is not set; set it with 'mob-claude config set %s <url>'", n.Secret, n.Secret)
+	}
+	return url, nil
+}
+
+// Notifier routes events to the channels configured for them
+type Notifier struct {
+	routes       []config.Notification
+	slackWebhook string
+}
+
+// New creates a notifier for the configured routes, or DefaultRoutes if
+// there are none
+func New(routes []config.Notification, slackWebhook string) *Notifier {
+	if len(routes) == 0 {
+		routes = DefaultRoutes
+	}
+	return &Notifier{routes: routes, slackWebhook: slackWebhook}
+}
+
+// Send delivers the message to every channel routed for its event. Each
+// delivery is attempted once; the returned error joins the failures.
+func (n *Notifier) Send(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, route := range n.routes {
+		if !Routed(route, msg.Event) {
+			continue
+		}
+		channel, err := NewChannel(route, n.slackWebhook)
+		if err == nil {
+			err = channel.Send(ctx, msg)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", route.Channel, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Desktop shows a desktop notification. It's a best-effort helper: where no
+// notifier is available, such as over SSH or without notify-send, it rings
+// the terminal bell instead.
+func Desktop(title, message string) error {
+	return desktop(title, message)
+}
+
+// bell rings the terminal bell, the fallback for a desktop notification
+func bell() {
+	fmt.Fprint(os.Stderr, "\a")
+}
+
+// desktopChannel shows the message as a desktop notification
+type desktopChannel struct{}
+
+func (desktopChannel) Send(ctx context.Context, msg Message) error {
+	body := msg.Text
+	if body == "" {
+		body = msg.Title
+	}
+	return Desktop("mob-claude", body)
+}
@@ -0,0 +1,14 @@
+//go:build !windows && !darwin
+
+package notify
+
+import "os/exec"
+
+// desktop shows a notification through notify-send, which every freedesktop
+// notification daemon answers
+func desktop(title, message string) error {
+	if err := exec.Command("notify-send", "--app-name=mob-claude", title, message).Run(); err != nil {
+		bell()
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// toastScript shows a Windows toast notification through the WinRT APIs
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName("text")
+$text.Item(0).AppendChild($template.CreateTextNode('%s')) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode('%s')) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('mob-claude').Show($toast)
+`
+
+func desktop(title, message string) error {
+	script := fmt.Sprintf(toastScript, psEscape(title), psEscape(message))
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to show toast notification: %w", err)
+	}
+	return nil
+}
+
+// psEscape escapes a string for a single-quoted PowerShell literal
+func psEscape(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
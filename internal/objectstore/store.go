@@ -0,0 +1,260 @@
+// Package objectstore keeps plans and summaries in an S3 bucket, or in a
+// GCS bucket through its S3-compatible XML API
+package objectstore
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// DefaultTimeout bounds each request to the bucket
+const DefaultTimeout = 30 * time.Second
+
+// Options configure a Store
+type Options struct {
+	// Endpoint overrides the service URL, for S3-compatible stores such as
+	// MinIO. Buckets are addressed path-style under it.
+	Endpoint string
+
+	// Region is the bucket's region; "" uses us-east-1 for S3 and auto for GCS
+	Region string
+
+	Credentials Credentials
+
+	// HTTPClient sends the requests; nil uses one with DefaultTimeout
+	HTTPClient *http.Client
+}
+
+// Store is a plans.Storage in a bucket. Objects mirror the project's
+// .claude layout under the location's prefix: plans/mob-<branch>.md and
+// summaries/<branch>/<timestamp>.json.
+type Store struct {
+	bucket *url.URL
+	prefix string
+	region string
+	creds  Credentials
+	client *http.Client
+	now    func() time.Time
+}
+
+// New returns the Store at location, an s3://bucket/prefix or
+// gs://bucket/prefix URL
+func New(location string, opts Options) (*Store, error) {
+	u, err := url.Parse(location)
+	if err != nil || u.Host == "" || (u.Scheme != "s3" && u.Scheme != "gs") {
+		return nil, fmt.Errorf("invalid storage location %q: use s3://bucket/prefix or gs://bucket/prefix", location)
+	}
+	if opts.Credentials.AccessKeyID == "" || opts.Credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("no credentials for %s: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY", location)
+	}
+
+	region := opts.Region
+	if region == "" {
+		region = "us-east-1"
+		if u.Scheme == "gs" {
+			region = "auto"
+		}
+	}
+
+	var bucketURL string
+	switch {
+	case opts.Endpoint != "":
+		bucketURL = strings.TrimRight(opts.Endpoint, "/") + "/" + u.Host
+	case u.Scheme == "gs":
+		bucketURL = "https://storage.googleapis.com/" + u.Host
+	case strings.Contains(u.Host, "."):
+		// Dotted bucket names don't match the wildcard certificate of
+		// virtual-hosted addressing
+		bucketURL = "https://s3." + region + ".amazonaws.com/" + u.Host
+	default:
+		bucketURL = "https://" + u.Host + ".s3." + region + ".amazonaws.com"
+	}
+	bucket, err := url.Parse(bucketURL)
+	if err != nil || bucket.Host == "" {
+		return nil, fmt.Errorf("invalid storage endpoint %q", opts.Endpoint)
+	}
+
+	prefix := strings.Trim(u.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Store{
+		bucket: bucket,
+		prefix: prefix,
+		region: region,
+		creds:  opts.Credentials,
+		client: client,
+		now:    time.Now,
+	}, nil
+}
+
+func (s *Store) planKey(branch string) string {
+	return s.prefix + "plans/mob-" + platform.SafeFilename(branch) + ".md"
+}
+
+func (s *Store) summaryPrefix(branch string) string {
+	return s.prefix + "summaries/" + platform.SafeFilename(branch) + "/"
+}
+
+// LoadPlan implements plans.Storage
+func (s *Store) LoadPlan(branch string) (string, error) {
+	resp, err := s.do(http.MethodGet, s.planKey(branch), nil, nil, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch plan: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", storageError(resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read plan: %w", err)
+	}
+	return string(data), nil
+}
+
+// SavePlan implements plans.Storage
+func (s *Store) SavePlan(branch, content string) error {
+	if err := s.put(s.planKey(branch), []byte(content), "text/markdown; charset=utf-8"); err != nil {
+		return fmt.Errorf("failed to store plan: %w", err)
+	}
+	return nil
+}
+
+// AppendSummary implements plans.Storage
+func (s *Store) AppendSummary(branch, name string, data []byte) error {
+	if err := s.put(s.summaryPrefix(branch)+name, data, "application/json"); err != nil {
+		return fmt.Errorf("failed to store summary: %w", err)
+	}
+	return nil
+}
+
+// listResult is a ListObjectsV2 response page
+type listResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListSummaries implements plans.Storage
+func (s *Store) ListSummaries(branch string) ([]string, error) {
+	prefix := s.summaryPrefix(branch)
+	var names []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		page, err := s.list(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list summaries: %w", err)
+		}
+		for _, object := range page.Contents {
+			name := strings.TrimPrefix(object.Key, prefix)
+			if name != "" && !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+		token = page.NextContinuationToken
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *Store) list(query url.Values) (*listResult, error) {
+	resp, err := s.do(http.MethodGet, "", query, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, storageError(resp)
+	}
+	page := &listResult{}
+	if err := xml.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, fmt.Errorf("failed to decode listing: %w", err)
+	}
+	return page, nil
+}
+
+func (s *Store) put(key string, data []byte, contentType string) error {
+	resp, err := s.do(http.MethodPut, key, nil, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return storageError(resp)
+	}
+	return nil
+}
+
+// do sends a signed request for key, or for the bucket itself if key is ""
+func (s *Store) do(method, key string, query url.Values, body []byte, contentType string) (*http.Response, error) {
+	u := *s.bucket
+	if key != "" {
+		segments := strings.Split(key, "/")
+		escaped := make([]string, len(segments))
+		for i, segment := range segments {
+			escaped[i] = uriEncode(segment)
+		}
+		u.Path = strings.TrimRight(u.Path, "/") + "/" + key
+		u.RawPath = strings.TrimRight(s.bucket.EscapedPath(), "/") + "/" + strings.Join(escaped, "/")
+	} else if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	payloadHash := emptyPayloadHash
+	if len(body) > 0 {
+		payloadHash = hashHex(body)
+	}
+	sign(req, s.creds, s.region, payloadHash, s.now())
+	return s.client.Do(req)
+}
+
+// storageError turns an error response into an error, with the service's
+// error code if it sent one
+func storageError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var e struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if xml.Unmarshal(body, &e) == nil && e.Code != "" {
+		return fmt.Errorf("storage error (%d): %s: %s", resp.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Errorf("storage error (%d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
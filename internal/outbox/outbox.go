@@ -0,0 +1,241 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/mob-claude/mob-claude/pkg/api"
+)
+
+// FileName is the outbox file inside the config directory
+const FileName = "outbox.json"
+
+// RejectedFileName holds the entries the dashboard refused, parked so they
+// don't hold up the rest of the queue and can still be inspected
+const RejectedFileName = "outbox-rejected.json"
+
+// Entry kinds
+const (
+	KindRotation = "rotation"
+	KindPlan     = "plan"
+)
+
+// Entry is a queued upload
+type Entry struct {
+	Kind     string    `json:"kind"`
+	Branch   string    `json:"branch"`
+	QueuedAt time.Time `json:"queuedAt"`
+
+	// Rotation and IdempotencyKey are set for rotation entries; the key is
+	// kept so a retried upload isn't recorded twice
+	Rotation       *api.CreateRotationRequest `json:"rotation,omitempty"`
+	IdempotencyKey string                     `json:"idempotencyKey,omitempty"`
+
+	// PlanText is set for plan entries
+	PlanText string `json:"planText,omitempty"`
+
+	// Error is why the dashboard refused a parked entry
+	Error string `json:"error,omitempty"`
+}
+
+// Cipher encrypts the outbox files at rest; plans.Manager is one
+type Cipher interface {
+	Seal(data []byte) ([]byte, error)
+	Open(data []byte) ([]byte, error)
+}
+
+// Outbox is the queue of pending uploads stored in a project's config directory
+type Outbox struct {
+	path         string
+	rejectedPath string
+	cipher       Cipher
+}
+
+// New returns the outbox stored in dir
+func New(dir string) *Outbox {
+	return &Outbox{path: filepath.Join(dir, FileName), rejectedPath: filepath.Join(dir, RejectedFileName)}
+}
+
+// SetCipher has the outbox encrypt what it writes, since queued rotations
+// carry the same summaries and plans that are encrypted elsewhere. Files
+// written before are still read as they are.
+func (o *Outbox) SetCipher(c Cipher) {
+	o.cipher = c
+}
+
+// Load returns the queued entries, oldest first
+func (o *Outbox) Load() ([]Entry, error) {
+	return o.load(o.path)
+}
+
+// Rejected returns the entries the dashboard refused, oldest first
+func (o *Outbox) Rejected() ([]Entry, error) {
+	return o.load(o.rejectedPath)
+}
+
+func (o *Outbox) load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	if o.cipher != nil {
+		if data, err = o.cipher.Open(data); err != nil {
+			return nil, fmt.Errorf("failed to read outbox: %w", err)
+		}
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse outbox: %w", err)
+	}
+	return entries, nil
+}
+
+// AddRotation queues a rotation upload
+func (o *Outbox) AddRotation(branch string, rotation *api.CreateRotationRequest, at time.Time) error {
+	entries, err := o.Load()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, Entry{
+		Kind:           KindRotation,
+		Branch:         branch,
+		QueuedAt:       at,
+		Rotation:       rotation,
+		IdempotencyKey: rotation.IdempotencyKey,
+	})
+	return o.save(entries)
+}
+
+// AmendRotation applies amend to the queued upload of branch's rotation
+// that started at startedAt, and reports whether there was one
+func (o *Outbox) AmendRotation(branch string, startedAt time.Time, amend func(*api.CreateRotationRequest)) (bool, error) {
+	entries, err := o.Load()
+	if err != nil {
+		return false, err
+	}
+	for i, e := range entries {
+		if e.Kind == KindRotation && e.Branch == branch && e.Rotation != nil &&
+			e.Rotation.StartedAt.Truncate(time.Second).Equal(startedAt.Truncate(time.Second)) {
+			amend(entries[i].Rotation)
+			return true, o.save(entries)
+		}
+	}
+	return false, nil
+}
+
+// AddPlan queues a plan update. Only the latest plan per branch matters,
+// so it replaces any plan already queued for the branch.
+func (o *Outbox) AddPlan(branch, planText string, at time.Time) error {
+	entries, err := o.Load()
+	if err != nil {
+		return err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Kind != KindPlan || e.Branch != branch {
+			kept = append(kept, e)
+		}
+	}
+	kept = append(kept, Entry{Kind: KindPlan, Branch: branch, QueuedAt: at, PlanText: planText})
+	return o.save(kept)
+}
+
+// HasPlan reports whether a plan update for branch is queued
+func (o *Outbox) HasPlan(branch string) (bool, error) {
+	entries, err := o.Load()
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		if e.Kind == KindPlan && e.Branch == branch {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Flush sends the queued entries in order. An entry the dashboard refuses
+// (see api.IsRejected) is parked in RejectedFileName and flushing goes on;
+// any other failure means the dashboard is unreachable, so flushing stops
+// there and keeps it and everything after it queued. It returns how many
+// entries were sent and how many were parked.
+func (o *Outbox) Flush(ctx context.Context, client *api.Client) (sent, parked int, err error) {
+	entries, err := o.Load()
+	if err != nil || len(entries) == 0 {
+		return 0, 0, err
+	}
+
+	var rejected []Entry
+	var sendErr error
+	done := 0
+	for _, e := range entries {
+		switch e.Kind {
+		case KindRotation:
+			e.Rotation.IdempotencyKey = e.IdempotencyKey
+			_, sendErr = client.CreateRotation(ctx, e.Branch, e.Rotation)
+		case KindPlan:
+			sendErr = client.UpdatePlan(ctx, e.Branch, e.PlanText)
+		}
+		if sendErr != nil && !api.IsRejected(sendErr) {
+			break
+		}
+		if sendErr != nil {
+			e.Error = sendErr.Error()
+			rejected = append(rejected, e)
+			sendErr = nil
+		} else {
+			sent++
+		}
+		done++
+	}
+
+	if len(rejected) > 0 {
+		previous, err := o.Rejected()
+		if err != nil {
+			return sent, 0, err
+		}
+		if err := o.saveTo(o.rejectedPath, append(previous, rejected...)); err != nil {
+			return sent, 0, err
+		}
+	}
+	if err := o.save(entries[done:]); err != nil {
+		return sent, len(rejected), err
+	}
+	return sent, len(rejected), sendErr
+}
+
+// save writes the queued entries
+func (o *Outbox) save(entries []Entry) error {
+	return o.saveTo(o.path, entries)
+}
+
+// saveTo writes entries to path, removing the file once there are none
+func (o *Outbox) saveTo(path string, entries []Entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if o.cipher != nil {
+		if data, err = o.cipher.Seal(data); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), platform.DirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, platform.FilePerm)
+}
@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Permissions for files and directories mob-claude creates. Windows ignores
+// everything but the owner write bit, so these are safe on every OS.
+const (
+	DirPerm  = 0755
+	FilePerm = 0644
+)
+
+// windowsExtensions are tried when a bare command name isn't found on Windows,
+// covering installs like mob.exe (scoop) and claude.cmd (npm)
+var windowsExtensions = []string{".exe", ".cmd", ".bat"}
+
+// FindExecutable resolves a command on PATH, also trying the usual Windows
+// executable extensions
+func FindExecutable(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err == nil || runtime.GOOS != "windows" {
+		return path, err
+	}
+
+	for _, ext := range windowsExtensions {
+		if p, extErr := exec.LookPath(name + ext); extErr == nil {
+			return p, nil
+		}
+	}
+	return "", err
+}
+
+// SafeFilename replaces characters that aren't allowed in file names on
+// Windows (and path separators everywhere) with '-'
+func SafeFilename(s string) string {
+	return strings.NewReplacer(
+		"/", "-", "\\", "-", ":", "-", "*", "-", "?", "-",
+		"\"", "-", "<", "-", ">", "-", "|", "-",
+	).Replace(s)
+}
+
+// Editor returns the command line of the user's editor, from $VISUAL or
+// $EDITOR, falling back to notepad on Windows and vi elsewhere
+func Editor() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"slices"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/config"
+)
+
+// Days are the weekday names blocks use, indexed by time.Weekday
+var Days = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Window is one occurrence of a scheduled block
+type Window struct {
+	Block config.ScheduleBlock
+	Start time.Time
+	End   time.Time
+}
+
+// Name returns the block's name, or its times if it has none
+func (w Window) Name() string {
+	if w.Block.Name != "" {
+		return w.Block.Name
+	}
+	return w.Block.Start + "-" + w.Block.End
+}
+
+// Current returns the block that now falls in, or nil if it's outside
+// every block. When blocks overlap, the one that started last wins.
+func Current(blocks []config.ScheduleBlock, now time.Time) *Window {
+	var current *Window
+	for _, block := range blocks {
+		w, ok := occurrence(block, now)
+		if !ok || now.Before(w.Start) || !now.Before(w.End) {
+			continue
+		}
+		if current == nil || w.Start.After(current.Start) {
+			current = &w
+		}
+	}
+	return current
+}
+
+// Next returns the first block to start after now, looking a week ahead,
+// or nil if there's none
+func Next(blocks []config.ScheduleBlock, now time.Time) *Window {
+	var next *Window
+	for offset := 0; offset <= 7; offset++ {
+		day := now.AddDate(0, 0, offset)
+		for _, block := range blocks {
+			w, ok := occurrence(block, day)
+			if !ok || !w.Start.After(now) {
+				continue
+			}
+			if next == nil || w.Start.Before(next.Start) {
+				next = &w
+			}
+		}
+		if next != nil {
+			return next
+		}
+	}
+	return nil
+}
+
+// occurrence returns the block's window on day's date, in day's location,
+// or false if the block doesn't run that day or its times don't parse
+func occurrence(block config.ScheduleBlock, day time.Time) (Window, bool) {
+	if len(block.Days) > 0 && !slices.Contains(block.Days, Days[day.Weekday()]) {
+		return Window{}, false
+	}
+	start, err := time.Parse("15:04", block.Start)
+	if err != nil {
+		return Window{}, false
+	}
+	end, err := time.Parse("15:04", block.End)
+	if err != nil || !end.After(start) {
+		return Window{}, false
+	}
+	y, m, d := day.Date()
+	return Window{
+		Block: block,
+		Start: time.Date(y, m, d, start.Hour(), start.Minute(), 0, 0, day.Location()),
+		End:   time.Date(y, m, d, end.Hour(), end.Minute(), 0, 0, day.Location()),
+	}, true
+}
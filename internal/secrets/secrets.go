@@ -0,0 +1,247 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/zalando/go-keyring"
+)
+
+// Service is the keychain service name secrets are stored under
+const Service = "mob-claude"
+
+// ErrNotFound is returned when a secret hasn't been set
+var ErrNotFound = errors.New("secret not found")
+
+// Store persists secret values outside the repository
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// Default returns a store scoped to a project that uses the OS keychain,
+// falling back to an age-encrypted file when no keychain is available
+func Default(project string) Store {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return &fallbackStore{
+		primary:  &keyringStore{project: project},
+		fallback: &ageFileStore{dir: filepath.Join(dir, "mob-claude"), project: project},
+	}
+}
+
+// Redact masks a secret for display, keeping only the last four characters
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	if len(value) <= 8 {
+		return "********"
+	}
+	return "********" + value[len(value)-4:]
+}
+
+// keyringStore keeps secrets in the OS keychain (Keychain, Credential
+// Manager, or the Secret Service on Linux)
+type keyringStore struct {
+	project string
+}
+
+func (s *keyringStore) account(key string) string {
+	return s.project + ":" + key
+}
+
+func (s *keyringStore) Get(key string) (string, error) {
+	value, err := keyring.Get(Service, s.account(key))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+func (s *keyringStore) Set(key, value string) error {
+	return keyring.Set(Service, s.account(key), value)
+}
+
+func (s *keyringStore) Delete(key string) error {
+	err := keyring.Delete(Service, s.account(key))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// fallbackStore uses the primary store and falls back when it's unavailable
+type fallbackStore struct {
+	primary  Store
+	fallback Store
+}
+
+func (s *fallbackStore) Get(key string) (string, error) {
+	value, err := s.primary.Get(key)
+	if err == nil {
+		return value, nil
+	}
+	return s.fallback.Get(key)
+}
+
+func (s *fallbackStore) Set(key, value string) error {
+	if err := s.primary.Set(key, value); err == nil {
+		return nil
+	}
+	return s.fallback.Set(key, value)
+}
+
+func (s *fallbackStore) Delete(key string) error {
+	// The secret may live in either store; the keychain may also be unavailable
+	_ = s.primary.Delete(key)
+	return s.fallback.Delete(key)
+}
+
+// ageFileStore keeps secrets in an age-encrypted JSON file in the user's
+// config directory, encrypted to a locally generated X25519 identity
+type ageFileStore struct {
+	dir     string
+	project string
+}
+
+func (s *ageFileStore) identityPath() string {
+	return filepath.Join(s.dir, "identity.txt")
+}
+
+func (s *ageFileStore) secretsPath() string {
+	return filepath.Join(s.dir, "secrets.age")
+}
+
+func (s *ageFileStore) identity() (*age.X25519Identity, error) {
+	data, err := os.ReadFile(s.identityPath())
+	if err == nil {
+		return age.ParseX25519Identity(strings.TrimSpace(string(data)))
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate identity: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(s.identityPath(), []byte(identity.String()+"\n"), 0600); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// load decrypts all secrets, keyed by project then secret key
+func (s *ageFileStore) load(identity *age.X25519Identity) (map[string]map[string]string, error) {
+	all := make(map[string]map[string]string)
+
+	data, err := os.ReadFile(s.secretsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return all, nil
+		}
+		return nil, err
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(data), identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets: %w", err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(plain, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets: %w", err)
+	}
+	return all, nil
+}
+
+func (s *ageFileStore) save(identity *age.X25519Identity, all map[string]map[string]string) error {
+	plain, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, identity.Recipient())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+	if _, err := w.Write(plain); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.secretsPath(), buf.Bytes(), 0600)
+}
+
+func (s *ageFileStore) Get(key string) (string, error) {
+	if _, err := os.Stat(s.secretsPath()); os.IsNotExist(err) {
+		return "", ErrNotFound
+	}
+	identity, err := s.identity()
+	if err != nil {
+		return "", err
+	}
+	all, err := s.load(identity)
+	if err != nil {
+		return "", err
+	}
+	value, ok := all[s.project][key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *ageFileStore) Set(key, value string) error {
+	identity, err := s.identity()
+	if err != nil {
+		return err
+	}
+	all, err := s.load(identity)
+	if err != nil {
+		return err
+	}
+	if all[s.project] == nil {
+		all[s.project] = make(map[string]string)
+	}
+	all[s.project][key] = value
+	return s.save(identity, all)
+}
+
+func (s *ageFileStore) Delete(key string) error {
+	if _, err := os.Stat(s.secretsPath()); os.IsNotExist(err) {
+		return nil
+	}
+	identity, err := s.identity()
+	if err != nil {
+		return err
+	}
+	all, err := s.load(identity)
+	if err != nil {
+		return err
+	}
+	delete(all[s.project], key)
+	return s.save(identity, all)
+}
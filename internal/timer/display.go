@@ -0,0 +1,104 @@
+package timer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Alert styles for timer warnings
+const (
+	AlertBell  = "bell"
+	AlertFlash = "flash"
+	AlertNone  = "none"
+)
+
+// Display controls how timer warnings are rendered
+type Display struct {
+	HighContrast bool
+	LargeText    bool
+	Alert        string
+}
+
+const (
+	highContrastOn = "\x1b[1;7m"
+	styleReset     = "\x1b[0m"
+	flashOn        = "\x1b[?5h"
+	flashOff       = "\x1b[?5l"
+)
+
+// glyphs are 5-row block renderings used for large-text display
+var glyphs = map[rune][5]string{
+	'0': {"█████", "█   █", "█   █", "█   █", "█████"},
+	'1': {"   █ ", "  ██ ", "   █ ", "   █ ", "  ███"},
+	'2': {"█████", "    █", "█████", "█    ", "█████"},
+	'3': {"█████", "    █", " ████", "    █", "█████"},
+	'4': {"█   █", "█   █", "█████", "    █", "    █"},
+	'5': {"█████", "█    ", "█████", "    █", "█████"},
+	'6': {"█████", "█    ", "█████", "█   █", "█████"},
+	'7': {"█████", "    █", "   █ ", "  █  ", "  █  "},
+	'8': {"█████", "█   █", "█████", "█   █", "█████"},
+	'9': {"█████", "█   █", "█████", "    █", "█████"},
+	':': {"     ", "  █  ", "     ", "  █  ", "     "},
+	'-': {"     ", "     ", "█████", "     ", "     "},
+}
+
+// RenderLarge renders text such as "04:59" as 5 rows of block characters.
+// Characters without a glyph are skipped.
+func RenderLarge(text string) []string {
+	rows := make([]string, 5)
+	for _, r := range text {
+		g, ok := glyphs[r]
+		if !ok {
+			continue
+		}
+		for i := range rows {
+			rows[i] += g[i] + "  "
+		}
+	}
+	for i := range rows {
+		rows[i] = strings.TrimRight(rows[i], " ")
+	}
+	return rows
+}
+
+// Announce writes a warning for the time remaining using the display options
+func (d Display) Announce(w io.Writer, remaining time.Duration) {
+	level := LevelAt(remaining)
+
+	if level >= LevelUrgent {
+		switch d.Alert {
+		case AlertFlash:
+			Flash(w)
+		case AlertNone:
+		default:
+			fmt.Fprint(w, "\a")
+		}
+	}
+
+	line := fmt.Sprintf("[%s] %s", FormatRemaining(remaining), Message(remaining))
+	if d.LargeText {
+		for _, row := range RenderLarge(FormatRemaining(remaining)) {
+			fmt.Fprintln(w, d.style(row))
+		}
+	}
+	fmt.Fprintln(w, d.style(line))
+}
+
+func (d Display) style(s string) string {
+	if !d.HighContrast {
+		return s
+	}
+	return highContrastOn + s + styleReset
+}
+
+// Flash briefly inverts the terminal screen as a visual alternative to the bell
+func Flash(w io.Writer) {
+	for i := 0; i < 3; i++ {
+		fmt.Fprint(w, flashOn)
+		time.Sleep(150 * time.Millisecond)
+		fmt.Fprint(w, flashOff)
+		time.Sleep(150 * time.Millisecond)
+	}
+}
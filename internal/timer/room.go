@@ -0,0 +1,67 @@
+package timer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// roomTimeout bounds each request to the timer room, so a slow timer
+// service doesn't hold up a handoff
+const roomTimeout = 5 * time.Second
+
+// Room is a room on mob.sh's shared web timer (timer.mob.sh), where the
+// whole mob can watch the rotation count down
+type Room struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewRoom returns the timer room at url, as given by mob.Settings.TimerRoomURL
+func NewRoom(url string) *Room {
+	return &Room{url: url, httpClient: &http.Client{Timeout: roomTimeout}}
+}
+
+// URL returns the room's web address
+func (r *Room) URL() string {
+	return r.url
+}
+
+// Start starts a timer of the given length in the room, shown as user's,
+// the way 'mob timer' does
+func (r *Room) Start(ctx context.Context, minutes int, user string) error {
+	return r.put(ctx, minutes, user)
+}
+
+// Stop clears the room's timer by starting a zero-minute one
+func (r *Room) Stop(ctx context.Context, user string) error {
+	return r.put(ctx, 0, user)
+}
+
+func (r *Room) put(ctx context.Context, minutes int, user string) error {
+	body, err := json.Marshal(map[string]interface{}{"timer": minutes, "user": user})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, r.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach timer room: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("timer room error (%d): %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
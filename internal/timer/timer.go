@@ -0,0 +1,73 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/i18n"
+)
+
+// Level describes how urgent a timer warning is
+type Level int
+
+const (
+	LevelNone Level = iota
+	LevelNotice
+	LevelUrgent
+	LevelExpired
+	LevelOverdue
+)
+
+// Warning thresholds before the deadline
+const (
+	NoticeBefore = 5 * time.Minute
+	UrgentBefore = 1 * time.Minute
+)
+
+// Deadline returns the rotation deadline for a rotation starting at start
+func Deadline(start time.Time, minutes int) time.Time {
+	return start.Add(time.Duration(minutes) * time.Minute)
+}
+
+// LevelAt returns the warning level for the time remaining until the deadline
+func LevelAt(remaining time.Duration) Level {
+	switch {
+	case remaining > NoticeBefore:
+		return LevelNone
+	case remaining > UrgentBefore:
+		return LevelNotice
+	case remaining > 0:
+		return LevelUrgent
+	case remaining > -time.Minute:
+		return LevelExpired
+	default:
+		return LevelOverdue
+	}
+}
+
+// Message returns the user-facing warning for the time remaining
+func Message(remaining time.Duration) string {
+	switch LevelAt(remaining) {
+	case LevelNotice:
+		return i18n.Sprintf("%d minutes left in this rotation", int(remaining.Round(time.Minute).Minutes()))
+	case LevelUrgent:
+		return i18n.Sprintf("Less than a minute left (%ds) - wrap up and get ready to hand off", int(remaining.Seconds()))
+	case LevelExpired:
+		return i18n.T("Time's up! Run 'mob-claude next' to hand off")
+	case LevelOverdue:
+		return i18n.Sprintf("OVERDUE by %d minutes - hand off now with 'mob-claude next'", int(-remaining.Minutes()))
+	default:
+		return i18n.Sprintf("%s left in this rotation", FormatRemaining(remaining))
+	}
+}
+
+// FormatRemaining renders a duration as mm:ss, prefixed with '-' once overdue
+func FormatRemaining(remaining time.Duration) string {
+	sign := ""
+	if remaining < 0 {
+		sign = "-"
+		remaining = -remaining
+	}
+	secs := int(remaining.Round(time.Second).Seconds())
+	return fmt.Sprintf("%s%02d:%02d", sign, secs/60, secs%60)
+}
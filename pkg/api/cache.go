@@ -0,0 +1,227 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// CacheDir is the directory, under the config directory, that holds
+// cached dashboard responses
+const CacheDir = "api-cache"
+
+// SetCache keeps the dashboard's GET responses that carry an ETag in dir,
+// and revalidates them with If-None-Match, so an unchanged plan or team
+// costs a 304 instead of the whole body. Cached responses are always
+// revalidated, so they're never staler than a direct request. With a
+// cipher the entries are encrypted at rest, since they hold the same plans
+// that are encrypted elsewhere.
+func (c *Client) SetCache(dir string, cipher Cipher) {
+	if dir == "" {
+		return
+	}
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.httpClient.Transport = &cacheTransport{dir: dir, base: base, cipher: cipher}
+}
+
+// Cipher encrypts cached responses at rest; plans.Manager is one
+type Cipher interface {
+	Seal(data []byte) ([]byte, error)
+	Open(data []byte) ([]byte, error)
+}
+
+// cacheEntry is a cached response, stored as JSON in a file named for its
+// URL
+type cacheEntry struct {
+	URL         string `json:"url"`
+	ETag        string `json:"etag"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+// cacheTransport answers GET requests from the cache when the dashboard
+// says they haven't changed
+type cacheTransport struct {
+	dir    string
+	base   http.RoundTripper
+	cipher Cipher
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	entry := t.load(key)
+	if entry != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		t.touch(key)
+		return entry.response(req, resp), nil
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "" && !strings.Contains(resp.Header.Get("Cache-Control"), "no-store"):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		t.store(&cacheEntry{URL: key, ETag: resp.Header.Get("ETag"), ContentType: resp.Header.Get("Content-Type"), Body: body})
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound:
+		// The resource changed without an ETag or is gone, so the cached
+		// copy is of no further use
+		if entry != nil {
+			t.remove(key)
+		}
+	}
+	return resp, nil
+}
+
+// response turns the entry into a 200 response to req, keeping the 304's
+// headers, which describe the current version
+func (e *cacheEntry) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := notModified.Header.Clone()
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	header.Set("ETag", e.ETag)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// path returns the file an endpoint's response is cached in
+func (t *cacheTransport) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(t.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load returns the cached response for key, or nil if there's none or it
+// can't be read
+func (t *cacheTransport) load(key string) *cacheEntry {
+	data, err := os.ReadFile(t.path(key))
+	if err != nil {
+		return nil
+	}
+	plaintext := false
+	if t.cipher != nil {
+		opened, err := t.cipher.Open(data)
+		if err != nil {
+			return nil
+		}
+		// An entry cached before the key was set is sealed now
+		plaintext, data = bytes.Equal(opened, data), opened
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != key || entry.ETag == "" {
+		return nil
+	}
+	if plaintext {
+		t.store(&entry)
+	}
+	return &entry
+}
+
+// store saves entry, replacing the file whole so concurrent readers never
+// see half of it. Failing to cache isn't an error: the response was
+// already received.
+func (t *cacheTransport) store(entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if t.cipher != nil {
+		if data, err = t.cipher.Seal(data); err != nil {
+			return
+		}
+	}
+	if err := os.MkdirAll(t.dir, platform.DirPerm); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(t.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), t.path(entry.URL)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func (t *cacheTransport) remove(key string) {
+	_ = os.Remove(t.path(key))
+}
+
+// touch marks an entry as used, so PruneCache keeps it
+func (t *cacheTransport) touch(key string) {
+	now := time.Now()
+	_ = os.Chtimes(t.path(key), now, now)
+}
+
+// PruneCache deletes the cached responses in dir that haven't been used
+// since before, and returns how many there were. With dryRun nothing is
+// deleted.
+func PruneCache(dir string, before time.Time, dryRun bool) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	pruned := 0
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		pruned++
+		if !dryRun {
+			if err := os.Remove(filepath.Join(dir, e.Name())); err != nil && !os.IsNotExist(err) {
+				return pruned, err
+			}
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,779 @@
+// Package api is a client for the mob-claude dashboard API: workstreams,
+// rotations, plans, presence, and the team's schedule and announcements.
+// It's what the mob-claude CLI uses, and can be embedded by editor plugins
+// and bots that want to read or record mob sessions without shelling out.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client handles communication with the mob-claude dashboard API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	teamName   string
+
+	// transport is the innermost transport, under any auth, signing, or
+	// hook wrappers, so proxy and TLS settings apply whatever the order
+	// they're set in
+	transport *http.Transport
+}
+
+// DefaultTimeout is the per-request timeout used unless overridden
+const DefaultTimeout = 30 * time.Second
+
+// NewClient creates a new API client
+func NewClient(baseURL, teamName string) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout:   DefaultTimeout,
+			Transport: transport,
+		},
+		teamName:  teamName,
+		transport: transport,
+	}
+}
+
+// SetToken authenticates every request with a bearer token
+func (c *Client) SetToken(token string) {
+	if token == "" {
+		return
+	}
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.httpClient.Transport = &authTransport{token: token, base: base}
+}
+
+// authTransport adds an Authorization header to outgoing requests
+type authTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+// SetContactHook calls hook whenever the dashboard answers a request, so
+// callers can track when it was last reachable
+func (c *Client) SetContactHook(hook func()) {
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.httpClient.Transport = &contactTransport{hook: hook, base: base}
+}
+
+// contactTransport reports every response received from the dashboard
+type contactTransport struct {
+	hook func()
+	base http.RoundTripper
+}
+
+func (t *contactTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.hook()
+	}
+	return resp, err
+}
+
+// SetErrorHook calls hook whenever a dashboard request fails or gets an
+// error response. A 404 isn't counted, since it's how the dashboard
+// reports that something doesn't exist yet.
+func (c *Client) SetErrorHook(hook func()) {
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.httpClient.Transport = &errorTransport{hook: hook, base: base}
+}
+
+// errorTransport reports every failed request
+type errorTransport struct {
+	hook func()
+	base http.RoundTripper
+}
+
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound) {
+		t.hook()
+	}
+	return resp, err
+}
+
+// SetTimeout overrides the per-request timeout; zero disables it
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+// Workstream represents a mob programming workstream
+type Workstream struct {
+	ID        string    `json:"id"`
+	TeamID    string    `json:"teamId"`
+	RepoURL   string    `json:"repoUrl"`
+	Branch    string    `json:"branch"`
+	PlanText  string    `json:"planText,omitempty"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// CustomFields are team-defined reporting dimensions, e.g. sprint or epic
+	CustomFields map[string]string `json:"customFields,omitempty"`
+
+	// LastSeenAt is when the last heartbeat arrived from a session on the
+	// workstream, and LastSeenBy the driver it named
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	LastSeenBy string     `json:"lastSeenBy,omitempty"`
+}
+
+// Rotation represents a single driver rotation
+type Rotation struct {
+	ID              string          `json:"id"`
+	WorkstreamID    string          `json:"workstreamId"`
+	DriverName      string          `json:"driverName"`
+	DriverNote      string          `json:"driverNote,omitempty"`
+	SummaryTLDR     string          `json:"summaryTldr,omitempty"`
+	SummaryJSON     json.RawMessage `json:"summaryJson,omitempty"`
+	PlanSnapshot    string          `json:"planSnapshot,omitempty"`
+	StartedAt       time.Time       `json:"startedAt"`
+	EndedAt         time.Time       `json:"endedAt,omitempty"`
+	DurationSeconds int             `json:"durationSeconds,omitempty"`
+	ClaudeSessionID string          `json:"claudeSessionId,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`
+}
+
+// Team represents a team in the system
+type Team struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Workstreams []Workstream `json:"workstreams,omitempty"`
+}
+
+// PlanTemplate is a named plan template shared by a team
+type PlanTemplate struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Announcement is a pinned team message shown to drivers at start
+type Announcement struct {
+	ID        string    `json:"id"`
+	Message   string    `json:"message"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ScheduleBlock is a recurring block of mob time on the team's schedule
+type ScheduleBlock struct {
+	Name            string   `json:"name,omitempty"`
+	Days            []string `json:"days,omitempty"`
+	Start           string   `json:"start"`
+	End             string   `json:"end"`
+	RotationMinutes int      `json:"rotationMinutes,omitempty"`
+	Roster          []string `json:"roster,omitempty"`
+}
+
+// CreateWorkstreamRequest is the payload for creating a workstream
+type CreateWorkstreamRequest struct {
+	RepoURL string `json:"repoUrl"`
+	Branch  string `json:"branch"`
+}
+
+// CreateRotationRequest is the payload for recording a rotation
+type CreateRotationRequest struct {
+	DriverName      string          `json:"driverName"`
+	DriverNote      string          `json:"driverNote,omitempty"`
+	SummaryTLDR     string          `json:"summaryTldr,omitempty"`
+	SummaryJSON     json.RawMessage `json:"summaryJson,omitempty"`
+	PlanSnapshot    string          `json:"planSnapshot,omitempty"`
+	StartedAt       time.Time       `json:"startedAt"`
+	EndedAt         time.Time       `json:"endedAt,omitempty"`
+	DurationSeconds int             `json:"durationSeconds,omitempty"`
+
+	// ClaudeSessionID is the driver's Claude Code conversation, if known
+	ClaudeSessionID string `json:"claudeSessionId,omitempty"`
+
+	// CustomFields carries the workstream's custom fields from the plan
+	CustomFields map[string]string `json:"customFields,omitempty"`
+
+	// DiffStats is the size of the branch's changes, for velocity charts
+	DiffStats *DiffStats `json:"diffStats,omitempty"`
+
+	// Tags are what the driver says the rotation was spent on
+	Tags []string `json:"tags,omitempty"`
+
+	// IdempotencyKey lets the dashboard drop duplicate uploads of the same rotation
+	IdempotencyKey string `json:"-"`
+}
+
+// DiffStats are the numeric size of a rotation's changes
+type DiffStats struct {
+	FilesChanged int `json:"filesChanged"`
+	Insertions   int `json:"insertions"`
+	Deletions    int `json:"deletions"`
+	TestFiles    int `json:"testFiles"`
+}
+
+// UpdatePlanRequest is the payload for updating a workstream's plan
+type UpdatePlanRequest struct {
+	PlanText string `json:"planText"`
+}
+
+// RenameWorkstreamRequest is the payload for moving a workstream to a new branch
+type RenameWorkstreamRequest struct {
+	Branch string `json:"branch"`
+}
+
+// SetWorkstreamActiveRequest is the payload for archiving or reopening a
+// workstream
+type SetWorkstreamActiveRequest struct {
+	IsActive bool `json:"isActive"`
+}
+
+// UpdateRotationRequest is the payload for correcting a recorded rotation.
+// Empty fields are left as they are.
+type UpdateRotationRequest struct {
+	DriverName  string          `json:"driverName,omitempty"`
+	DriverNote  string          `json:"driverNote,omitempty"`
+	SummaryTLDR string          `json:"summaryTldr,omitempty"`
+	SummaryJSON json.RawMessage `json:"summaryJson,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+}
+
+// GetTeam fetches the team and its workstreams
+func (c *Client) GetTeam(ctx context.Context) (*Team, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s", c.baseURL, url.PathEscape(c.teamName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch team: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var team Team
+	if err := json.NewDecoder(resp.Body).Decode(&team); err != nil {
+		return nil, fmt.Errorf("failed to decode team: %w", err)
+	}
+
+	return &team, nil
+}
+
+// GetPlanTemplates fetches the team's shared plan templates
+func (c *Client) GetPlanTemplates(ctx context.Context) ([]PlanTemplate, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/plan-templates", c.baseURL, url.PathEscape(c.teamName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plan templates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var templates []PlanTemplate
+	if err := json.NewDecoder(resp.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to decode plan templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetAnnouncements fetches the team's pinned announcements
+func (c *Client) GetAnnouncements(ctx context.Context) ([]Announcement, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/announcements", c.baseURL, url.PathEscape(c.teamName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch announcements: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var announcements []Announcement
+	if err := json.NewDecoder(resp.Body).Decode(&announcements); err != nil {
+		return nil, fmt.Errorf("failed to decode announcements: %w", err)
+	}
+
+	return announcements, nil
+}
+
+// GetSchedule fetches the team's weekly mob schedule
+func (c *Client) GetSchedule(ctx context.Context) ([]ScheduleBlock, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/schedule", c.baseURL, url.PathEscape(c.teamName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schedule: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var blocks []ScheduleBlock
+	if err := json.NewDecoder(resp.Body).Decode(&blocks); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// CreateWorkstream creates or gets a workstream for the given branch
+func (c *Client) CreateWorkstream(ctx context.Context, repoURL, branch string) (*Workstream, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams", c.baseURL, url.PathEscape(c.teamName))
+
+	payload := CreateWorkstreamRequest{
+		RepoURL: repoURL,
+		Branch:  branch,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workstream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var workstream Workstream
+	if err := json.NewDecoder(resp.Body).Decode(&workstream); err != nil {
+		return nil, fmt.Errorf("failed to decode workstream: %w", err)
+	}
+
+	return &workstream, nil
+}
+
+// GetWorkstream fetches a specific workstream by branch
+func (c *Client) GetWorkstream(ctx context.Context, branch string) (*Workstream, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workstream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var workstream Workstream
+	if err := json.NewDecoder(resp.Body).Decode(&workstream); err != nil {
+		return nil, fmt.Errorf("failed to decode workstream: %w", err)
+	}
+
+	return &workstream, nil
+}
+
+// RenameWorkstream moves a workstream and its history to a new branch. It
+// returns nil if the dashboard has no workstream for the old branch.
+func (c *Client) RenameWorkstream(ctx context.Context, branch, newBranch string) (*Workstream, error) {
+	return c.patchWorkstream(ctx, branch, RenameWorkstreamRequest{Branch: newBranch}, "rename workstream")
+}
+
+// SetWorkstreamActive archives a workstream, taking it out of the
+// dashboard's picker, or reopens an archived one. It returns nil if the
+// dashboard has no workstream for the branch.
+func (c *Client) SetWorkstreamActive(ctx context.Context, branch string, active bool) (*Workstream, error) {
+	what := "archive workstream"
+	if active {
+		what = "reopen workstream"
+	}
+	return c.patchWorkstream(ctx, branch, SetWorkstreamActiveRequest{IsActive: active}, what)
+}
+
+// patchWorkstream sends a PATCH to a workstream; what describes it for
+// errors
+func (c *Client) patchWorkstream(ctx context.Context, branch string, payload interface{}, what string) (*Workstream, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s: %w", what, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var workstream Workstream
+	if err := json.NewDecoder(resp.Body).Decode(&workstream); err != nil {
+		return nil, fmt.Errorf("failed to decode workstream: %w", err)
+	}
+
+	return &workstream, nil
+}
+
+// GetPlan fetches the current plan for a workstream
+func (c *Client) GetPlan(ctx context.Context, branch string) (string, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/plan",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch plan: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	// Response might be JSON with planText field
+	var planResp struct {
+		PlanText string `json:"planText"`
+	}
+	if err := json.Unmarshal(body, &planResp); err == nil && planResp.PlanText != "" {
+		return planResp.PlanText, nil
+	}
+
+	return string(body), nil
+}
+
+// UpdatePlan updates the plan for a workstream
+func (c *Client) UpdatePlan(ctx context.Context, branch, planText string) error {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/plan",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+
+	payload := UpdatePlanRequest{PlanText: planText}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update plan: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return nil
+}
+
+// CreateRotation records a new rotation for a workstream
+func (c *Client) CreateRotation(ctx context.Context, branch string, rotation *CreateRotationRequest) (*Rotation, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/rotations",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+
+	body, err := json.Marshal(rotation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if rotation.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", rotation.IdempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rotation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var result Rotation
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode rotation: %w", err)
+	}
+
+	return &result, nil
+}
+
+// UpdateRotation corrects a recorded rotation's metadata. It returns nil
+// if the dashboard has no such rotation.
+func (c *Client) UpdateRotation(ctx context.Context, branch, id string, update *UpdateRotationRequest) (*Rotation, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/rotations/%s",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch), url.PathEscape(id))
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rotation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var rotation Rotation
+	if err := json.NewDecoder(resp.Body).Decode(&rotation); err != nil {
+		return nil, fmt.Errorf("failed to decode rotation: %w", err)
+	}
+
+	return &rotation, nil
+}
+
+// UploadArtifact attaches a file, such as the archive 'done --archive'
+// writes, to a workstream on the dashboard
+func (c *Client) UploadArtifact(ctx context.Context, branch, name, contentType string, data []byte) error {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/artifacts?name=%s",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch), url.QueryEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload artifact: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return nil
+}
+
+// GetLatestRotation fetches the most recent rotation for a workstream.
+// It returns nil if the workstream has no rotations yet.
+func (c *Client) GetLatestRotation(ctx context.Context, branch string) (*Rotation, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/rotations/latest",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest rotation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var rotation Rotation
+	if err := json.NewDecoder(resp.Body).Decode(&rotation); err != nil {
+		return nil, fmt.Errorf("failed to decode rotation: %w", err)
+	}
+
+	return &rotation, nil
+}
+
+// GetRotation fetches a single rotation of a workstream by ID.
+// It returns nil if no such rotation exists.
+func (c *Client) GetRotation(ctx context.Context, branch, id string) (*Rotation, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/rotations/%s",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch), url.PathEscape(id))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rotation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var rotation Rotation
+	if err := json.NewDecoder(resp.Body).Decode(&rotation); err != nil {
+		return nil, fmt.Errorf("failed to decode rotation: %w", err)
+	}
+
+	return &rotation, nil
+}
+
+// Ping checks if the API is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	endpoint := fmt.Sprintf("%s/api/health", c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API unhealthy (status %d)", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// handoffEndpoint is where a workstream's pending handoff bundle is kept
+func (c *Client) handoffEndpoint(branch string) string {
+	return fmt.Sprintf("%s/api/teams/%s/workstreams/%s/handoff",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+}
+
+// SendHandoff stores an encoded handoff bundle for the workstream's next
+// driver, replacing any bundle still waiting there
+func (c *Client) SendHandoff(ctx context.Context, branch string, bundle []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.handoffEndpoint(branch), bytes.NewReader(bundle))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send handoff: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return nil
+}
+
+// GetHandoff fetches the workstream's pending handoff bundle. It returns
+// nil if there is none.
+func (c *Client) GetHandoff(ctx context.Context, branch string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.handoffEndpoint(branch), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch handoff: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	bundle, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read handoff: %w", err)
+	}
+	return bundle, nil
+}
+
+// DeleteHandoff removes the workstream's pending handoff bundle once it
+// has been received
+func (c *Client) DeleteHandoff(ctx context.Context, branch string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.handoffEndpoint(branch), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete handoff: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Heartbeat tells the dashboard a session on the workstream is still alive.
+// The dashboard marks a workstream stale once its heartbeats stop, so a
+// session that dies without 'done' doesn't look active forever.
+type Heartbeat struct {
+	DriverName string     `json:"driverName"`
+	Deadline   *time.Time `json:"deadline,omitempty"`
+	SentAt     time.Time  `json:"sentAt"`
+}
+
+// SendHeartbeat reports that a session on the workstream is alive
+func (c *Client) SendHeartbeat(ctx context.Context, branch string, heartbeat Heartbeat) error {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/heartbeat",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch))
+
+	body, err := json.Marshal(heartbeat)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send heartbeat: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size used when ListOptions.Limit is unset
+const DefaultPageSize = 50
+
+// ListOptions selects a page of a list endpoint. Cursor is the NextCursor
+// of the previous page, or empty for the first page.
+type ListOptions struct {
+	Limit  int
+	Cursor string
+}
+
+// RotationPage is one page of a workstream's rotations, newest first
+type RotationPage struct {
+	Rotations  []Rotation `json:"rotations"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// WorkstreamPage is one page of a team's workstreams
+type WorkstreamPage struct {
+	Workstreams []Workstream `json:"workstreams"`
+	NextCursor  string       `json:"nextCursor,omitempty"`
+}
+
+// query encodes the options as limit and cursor query parameters
+func (o ListOptions) query() string {
+	limit := o.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+	return query.Encode()
+}
+
+// ListRotations fetches a page of a workstream's rotations, newest first.
+// An empty NextCursor means there are no more pages.
+func (c *Client) ListRotations(ctx context.Context, branch string, opts ListOptions) (*RotationPage, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/rotations?%s",
+		c.baseURL, url.PathEscape(c.teamName), url.PathEscape(branch), opts.query())
+
+	var page RotationPage
+	if err := c.getPage(ctx, endpoint, "rotations", &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ListWorkstreams fetches a page of the team's workstreams.
+// An empty NextCursor means there are no more pages.
+func (c *Client) ListWorkstreams(ctx context.Context, opts ListOptions) (*WorkstreamPage, error) {
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams?%s",
+		c.baseURL, url.PathEscape(c.teamName), opts.query())
+
+	var page WorkstreamPage
+	if err := c.getPage(ctx, endpoint, "workstreams", &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// getPage fetches a list endpoint into page; a 404 leaves page empty
+func (c *Client) getPage(ctx context.Context, endpoint, what string, page interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", what, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", what, err)
+	}
+	return nil
+}
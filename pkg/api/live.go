@@ -0,0 +1,289 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Live update transports
+const (
+	TransportAuto     = "auto"
+	TransportSSE      = "sse"
+	TransportLongPoll = "poll"
+)
+
+const (
+	// minStreamLifetime is how long an SSE stream must stay open to count as
+	// working; proxies that kill streams usually do so within seconds
+	minStreamLifetime = 10 * time.Second
+
+	// maxStreamFailures is how many short-lived streams trigger a downgrade
+	maxStreamFailures = 2
+
+	// upgradeInterval is how long to long-poll before retrying SSE
+	upgradeInterval = 5 * time.Minute
+
+	// pollTimeout is how long the dashboard may hold a long-poll request open
+	pollTimeout = 30 * time.Second
+)
+
+// Event is a live update pushed by the dashboard
+type Event struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// pollResponse is the long-poll endpoint's reply
+type pollResponse struct {
+	Events []Event `json:"events"`
+	Cursor string  `json:"cursor"`
+}
+
+// Subscription streams live workstream events, preferring SSE and falling
+// back to long-polling when streaming connections are blocked
+type Subscription struct {
+	client    *Client
+	branch    string
+	transport string
+	lastID    string
+
+	// OnTransportChange is called when the subscription switches transports
+	OnTransportChange func(transport string)
+}
+
+// Subscribe creates a live-update subscription for a workstream. transport
+// is TransportAuto, TransportSSE, or TransportLongPoll.
+func (c *Client) Subscribe(branch, transport string) *Subscription {
+	if transport == "" {
+		transport = TransportAuto
+	}
+	return &Subscription{client: c, branch: branch, transport: transport}
+}
+
+// Run delivers events to handle until ctx is cancelled or handle returns an error
+func (s *Subscription) Run(ctx context.Context, handle func(Event) error) error {
+	switch s.transport {
+	case TransportSSE:
+		for {
+			_, err := s.stream(ctx, handle)
+			var handlerErr *handlerError
+			if errors.As(err, &handlerErr) {
+				return handlerErr.err
+			}
+			if err := sleep(ctx, time.Second); err != nil {
+				return err
+			}
+		}
+	case TransportLongPoll:
+		return s.poll(ctx, handle, time.Time{})
+	}
+
+	// Auto: stream until streams keep dying, then long-poll for a while
+	// and try streaming again
+	for {
+		s.notify(TransportSSE)
+		failures := 0
+		for failures < maxStreamFailures {
+			lived, err := s.stream(ctx, handle)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var handlerErr *handlerError
+			if errors.As(err, &handlerErr) {
+				return handlerErr.err
+			}
+			if lived < minStreamLifetime {
+				failures++
+			} else {
+				failures = 0
+			}
+			if err := sleep(ctx, time.Second); err != nil {
+				return err
+			}
+		}
+
+		s.notify(TransportLongPoll)
+		if err := s.poll(ctx, handle, time.Now().Add(upgradeInterval)); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Subscription) notify(transport string) {
+	if s.OnTransportChange != nil {
+		s.OnTransportChange(transport)
+	}
+}
+
+func (s *Subscription) eventsURL() string {
+	return fmt.Sprintf("%s/api/teams/%s/workstreams/%s/events",
+		s.client.baseURL, url.PathEscape(s.client.teamName), url.PathEscape(s.branch))
+}
+
+// handlerError marks an error returned by the event handler, which should
+// end the subscription rather than trigger a reconnect
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string {
+	return e.err.Error()
+}
+
+// stream reads one SSE connection until it drops, returning how long it lived
+func (s *Subscription) stream(ctx context.Context, handle func(Event) error) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.eventsURL(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if s.lastID != "" {
+		req.Header.Set("Last-Event-ID", s.lastID)
+	}
+
+	// Streams stay open indefinitely, so skip the client's request timeout
+	httpClient := *s.client.httpClient
+	httpClient.Timeout = 0
+
+	started := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open event stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	err = readEvents(resp.Body, func(event Event) error {
+		if event.ID != "" {
+			s.lastID = event.ID
+		}
+		return handle(event)
+	})
+	return time.Since(started), err
+}
+
+// readEvents parses a server-sent event stream, calling handle for each
+// event until the stream ends. An error from handle stops reading and is
+// returned wrapped in a handlerError.
+func readEvents(body io.Reader, handle func(Event) error) error {
+	var event Event
+	var data []string
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// Blank line dispatches the buffered event
+			if len(data) > 0 {
+				event.Data = json.RawMessage(strings.Join(data, "\n"))
+				if err := handle(event); err != nil {
+					return &handlerError{err: err}
+				}
+			}
+			event, data = Event{}, nil
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
+
+// poll long-polls for events until ctx is cancelled or until is reached
+// (a zero until polls forever)
+func (s *Subscription) poll(ctx context.Context, handle func(Event) error, until time.Time) error {
+	for until.IsZero() || time.Now().Before(until) {
+		resp, err := s.pollOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			if err := sleep(ctx, 5*time.Second); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, event := range resp.Events {
+			if err := handle(event); err != nil {
+				return err
+			}
+		}
+		if resp.Cursor != "" {
+			s.lastID = resp.Cursor
+		}
+	}
+	return nil
+}
+
+func (s *Subscription) pollOnce(ctx context.Context) (*pollResponse, error) {
+	query := url.Values{}
+	query.Set("timeout", fmt.Sprintf("%d", int(pollTimeout.Seconds())))
+	if s.lastID != "" {
+		query.Set("cursor", s.lastID)
+	}
+	endpoint := s.eventsURL() + "/poll?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Allow the server to hold the request for the full poll window
+	httpClient := *s.client.httpClient
+	httpClient.Timeout = pollTimeout + 10*time.Second
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return &pollResponse{}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result pollResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+	return &result, nil
+}
+
+// sleep waits for d or until ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
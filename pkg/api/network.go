@@ -0,0 +1,69 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// SetProxy sends every request through the given proxy URL, such as
+// "http://proxy.corp:3128". An empty URL keeps the default of honoring the
+// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables.
+func (c *Client) SetProxy(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	u, err := ParseProxyURL(proxy)
+	if err != nil {
+		return err
+	}
+	c.transport.Proxy = http.ProxyURL(u)
+	return nil
+}
+
+// ParseProxyURL checks that proxy is an http, https, or socks5 URL
+func ParseProxyURL(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy must be an http, https, or socks5 URL, got %q", proxy)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", proxy)
+	}
+	return u, nil
+}
+
+// SetCABundle trusts the certificates in a PEM file in addition to the
+// system's, for dashboards behind a proxy that re-signs TLS traffic or
+// served with an internal CA. An empty path keeps the system roots only.
+func (c *Client) SetCABundle(path string) error {
+	if path == "" {
+		return nil
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no PEM certificates found in %s", path)
+	}
+
+	if c.transport.TLSClientConfig == nil {
+		c.transport.TLSClientConfig = &tls.Config{}
+	}
+	c.transport.TLSClientConfig.RootCAs = pool
+	return nil
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// presenceEvent is the SSE event type carrying the workstream's members
+	presenceEvent = "presence"
+
+	// maxPresenceBackoff caps the wait between reconnects
+	maxPresenceBackoff = 30 * time.Second
+)
+
+// errPresenceClosed reports a presence stream the dashboard ended cleanly
+var errPresenceClosed = errors.New("connection closed by the dashboard")
+
+// PresenceState is what a driver announces while connected
+type PresenceState struct {
+	SessionID  string     `json:"sessionId"`
+	DriverName string     `json:"driverName"`
+	Deadline   *time.Time `json:"deadline,omitempty"`
+}
+
+// PresenceMember is a teammate connected to a workstream
+type PresenceMember struct {
+	DriverName string     `json:"driverName"`
+	Driving    bool       `json:"driving"`
+	Deadline   *time.Time `json:"deadline,omitempty"`
+	Since      time.Time  `json:"since"`
+}
+
+// PresenceConn keeps a driver registered as present on a workstream. The
+// dashboard holds the connection open as an event stream and treats the
+// driver as gone as soon as it closes, so presence needs no cleanup call.
+type PresenceConn struct {
+	client *Client
+	branch string
+	state  PresenceState
+
+	// OnUpdate is called with the workstream's members whenever they change
+	OnUpdate func(members []PresenceMember)
+
+	// OnDisconnect is called when the connection drops, before reconnecting
+	OnDisconnect func(err error)
+}
+
+// Presence creates a presence connection for a workstream
+func (c *Client) Presence(branch string, state PresenceState) *PresenceConn {
+	return &PresenceConn{client: c, branch: branch, state: state}
+}
+
+// Run holds the presence connection open until ctx is cancelled,
+// reconnecting with backoff whenever it drops
+func (p *PresenceConn) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		lived, err := p.connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var handlerErr *handlerError
+		if errors.As(err, &handlerErr) {
+			return handlerErr.err
+		}
+		if p.OnDisconnect != nil {
+			p.OnDisconnect(err)
+		}
+
+		// A connection that stayed up a while resets the backoff
+		if lived >= minStreamLifetime {
+			backoff = time.Second
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = min(backoff*2, maxPresenceBackoff)
+	}
+}
+
+// connect announces the driver and reads presence updates until the
+// stream drops, returning how long it lived
+func (p *PresenceConn) connect(ctx context.Context) (time.Duration, error) {
+	body, err := json.Marshal(p.state)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode presence: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/api/teams/%s/workstreams/%s/presence",
+		p.client.baseURL, url.PathEscape(p.client.teamName), url.PathEscape(p.branch))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	// The connection stays open for as long as the driver is present
+	httpClient := *p.client.httpClient
+	httpClient.Timeout = 0
+
+	started := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect presence: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	err = readEvents(resp.Body, func(event Event) error {
+		if event.Type != presenceEvent || p.OnUpdate == nil {
+			return nil
+		}
+		var members []PresenceMember
+		if err := json.Unmarshal(event.Data, &members); err != nil {
+			return nil
+		}
+		p.OnUpdate(members)
+		return nil
+	})
+	if err == nil {
+		err = errPresenceClosed
+	}
+	return time.Since(started), err
+}
@@ -0,0 +1,28 @@
+// Package clock lets callers of the plans and summary packages control
+// the time they record.
+package clock
+
+import "time"
+
+// Clock provides the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the clock backed by the real wall time
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a clock that always reports the same instant, for embedders
+// that record artifacts at a time of their choosing
+type Fixed time.Time
+
+// Now returns the fixed instant
+func (f Fixed) Now() time.Time {
+	return time.Time(f)
+}
@@ -0,0 +1,283 @@
+package plans
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// ArchiveDir holds the bundles 'done --archive' leaves of finished
+// branches, one subdirectory per branch
+const ArchiveDir = ".claude/mob/archive"
+
+// DiffStatFile is the name of the diff stats inside an archive
+const DiffStatFile = "diffstat.txt"
+
+// GetArchiveDir returns the directory a branch's archives are written to
+func (m *Manager) GetArchiveDir(branch string) string {
+	return filepath.Join(m.projectRoot, ArchiveDir, platform.SafeFilename(branch))
+}
+
+// Archive bundles a branch's plan, summaries, and handoff brief, along with
+// the final diff stats, into a dated .tar.gz in the branch's archive
+// directory. With an encryption key, the whole archive is encrypted and
+// named .tar.gz.age. It returns the archive's path.
+func (m *Manager) Archive(branch, diffStat string, at time.Time) (string, error) {
+	return m.createArchive(branch, at.Format("2006-01-02T15-04-05"), func(tw *tar.Writer) error {
+		return m.writeArchive(tw, branch, diffStat, at)
+	})
+}
+
+// ArchiveSummaries bundles some of a branch's summary files into a dated
+// .tar.gz named "<date>-summaries" in the branch's archive directory,
+// encrypted like Archive's. It returns the archive's path.
+func (m *Manager) ArchiveSummaries(branch string, files []string, at time.Time) (string, error) {
+	return m.createArchive(branch, at.Format("2006-01-02T15-04-05")+"-summaries", func(tw *tar.Writer) error {
+		return m.addSummaries(tw, files, at)
+	})
+}
+
+// createArchive writes a .tar.gz, or .tar.gz.age with an encryption key,
+// named name in the branch's archive directory, with write filling it in
+func (m *Manager) createArchive(branch, name string, write func(tw *tar.Writer) error) (string, error) {
+	dir := m.GetArchiveDir(branch)
+	if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
+		return "", err
+	}
+	archivePath := filepath.Join(dir, name+".tar.gz")
+	if m.identity != nil {
+		archivePath += ".age"
+	}
+
+	f, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, platform.FilePerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive: %w", err)
+	}
+	var dst io.WriteCloser = nopCloser{f}
+	if m.identity != nil {
+		if dst, err = age.Encrypt(f, m.identity.Recipient()); err != nil {
+			f.Close()
+			os.Remove(archivePath)
+			return "", fmt.Errorf("failed to encrypt archive: %w", err)
+		}
+	}
+	gz := gzip.NewWriter(dst)
+	tw := tar.NewWriter(gz)
+
+	err = write(tw)
+	if closeErr := tw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archivePath)
+		return "", fmt.Errorf("failed to write archive: %w", err)
+	}
+	return archivePath, nil
+}
+
+func (m *Manager) writeArchive(tw *tar.Writer, branch, diffStat string, at time.Time) error {
+	addFile := func(name, src string) error {
+		data, err := os.ReadFile(src)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return addToArchive(tw, name, data, at)
+	}
+
+	if err := addFile("plan.md", m.GetPlanPath(branch)); err != nil {
+		return err
+	}
+	if brief, err := m.LoadHandoff(branch); err != nil {
+		return err
+	} else if brief != "" {
+		if err := addToArchive(tw, "handoff.md", []byte(brief), at); err != nil {
+			return err
+		}
+	}
+	files, err := m.ListBranchSummaries(branch)
+	if err != nil {
+		return err
+	}
+	if err := m.addSummaries(tw, files, at); err != nil {
+		return err
+	}
+	if diffStat != "" {
+		return addToArchive(tw, DiffStatFile, []byte(diffStat), at)
+	}
+	return nil
+}
+
+// addSummaries adds summary files to an archive under summaries/
+func (m *Manager) addSummaries(tw *tar.Writer, files []string, at time.Time) error {
+	for _, file := range files {
+		// Summaries go in decrypted when the key is at hand: an encrypted
+		// archive protects them, and a plain one can't be made readable
+		data, err := m.readArtifact(file)
+		if errors.Is(err, ErrEncrypted) {
+			data, err = os.ReadFile(file)
+		}
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := addToArchive(tw, path.Join("summaries", filepath.Base(file)), data, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addToArchive(tw *tar.Writer, name string, data []byte, at time.Time) error {
+	header := &tar.Header{Name: name, Mode: platform.FilePerm, Size: int64(len(data)), ModTime: at}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ListArchives returns the paths of every branch's archives, oldest first
+// within each branch
+func (m *Manager) ListArchives() ([]string, error) {
+	root := filepath.Join(m.projectRoot, ArchiveDir)
+	dirs, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var archives []string
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(root, dir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && isArchive(e.Name()) {
+				archives = append(archives, filepath.Join(root, dir.Name(), e.Name()))
+			}
+		}
+	}
+	return archives, nil
+}
+
+// isArchive reports whether name is an archive's file name
+func isArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tar.gz.age")
+}
+
+// LoadArchiveSummaries reads the summaries bundled in an archive, decrypting
+// the archive, and the summaries inside it, with the team key as needed
+func (m *Manager) LoadArchiveSummaries(archivePath string) ([]*Summary, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer f.Close()
+
+	var src io.Reader = f
+	if strings.HasSuffix(archivePath, ".age") {
+		if m.identity == nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(archivePath), ErrEncrypted)
+		}
+		if src, err = age.Decrypt(f, m.identity); err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s (wrong encryptionKey?): %w", filepath.Base(archivePath), err)
+		}
+	}
+	gz, err := gzip.NewReader(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", filepath.Base(archivePath), err)
+	}
+	defer gz.Close()
+
+	var summaries []*Summary
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", filepath.Base(archivePath), err)
+		}
+		if path.Dir(header.Name) != "summaries" || !strings.HasSuffix(header.Name, ".json") {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", filepath.Base(archivePath), err)
+		}
+		// A plain archive made without the key holds summaries still
+		// encrypted
+		if data, err = m.open(data); err != nil {
+			return nil, fmt.Errorf("%s in %s: %w", header.Name, filepath.Base(archivePath), err)
+		}
+		summary := &Summary{}
+		if err := json.Unmarshal(data, summary); err != nil {
+			return nil, fmt.Errorf("failed to parse %s in %s: %w", header.Name, filepath.Base(archivePath), err)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// nopCloser lets the archive file stand in for an encrypting writer
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+// CleanBranch removes a finished branch's working files: its plan, its
+// summaries, and its handoff brief
+func (m *Manager) CleanBranch(branch string) error {
+	if err := os.Remove(m.GetPlanPath(branch)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove plan: %w", err)
+	}
+	if brief, _ := m.LoadHandoff(branch); brief != "" {
+		if err := os.Remove(filepath.Join(m.projectRoot, HandoffFile)); err != nil {
+			return fmt.Errorf("failed to remove handoff brief: %w", err)
+		}
+	}
+
+	files, err := m.ListBranchSummaries(branch)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove summary: %w", err)
+		}
+	}
+	// Drop the emptied branch directory; the index is rebuilt on next read
+	_ = os.Remove(filepath.Join(m.GetSummariesDir(), platform.SafeFilename(branch)))
+	_, err = m.SummaryIndex()
+	return err
+}
@@ -0,0 +1,39 @@
+package plans
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCoveredLen caps each entry of a summary's covered-changes digest
+const maxCoveredLen = 60
+
+// CoveredDigest compacts a summary's changes into its covered-changes
+// digest: each change cut at its first semicolon and to at most maxCoveredLen
+// characters
+func CoveredDigest(changes []string) []string {
+	var digest []string
+	for _, change := range changes {
+		change = strings.TrimSpace(change)
+		if i := strings.Index(change, ";"); i > 0 {
+			change = change[:i]
+		}
+		change = strings.TrimRight(strings.TrimSpace(change), ".,")
+		if utf8.RuneCountInString(change) > maxCoveredLen {
+			change = string([]rune(change)[:maxCoveredLen-3]) + "..."
+		}
+		if change != "" {
+			digest = append(digest, change)
+		}
+	}
+	return digest
+}
+
+// CoveredChanges returns the summary's covered-changes digest, working it
+// out from the changes for summaries saved before digests were kept
+func (s *Summary) CoveredChanges() []string {
+	if len(s.Covered) > 0 {
+		return s.Covered
+	}
+	return CoveredDigest(s.Changes)
+}
@@ -0,0 +1,89 @@
+package plans
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is how many unchanged lines DiffLines keeps around each change
+const diffContext = 2
+
+// DiffLines renders a line diff from old to new: removed lines start with
+// "-", added lines with "+", and unchanged lines near a change with a space.
+// Runs of unchanged lines further away are collapsed to "...". It returns
+// "" when the texts are equal.
+func DiffLines(old, new string) string {
+	a := strings.Split(strings.TrimRight(old, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(new, "\n"), "\n")
+
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	changed := false
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{'-', a[i]})
+			changed = true
+			i++
+		default:
+			lines = append(lines, line{'+', b[j]})
+			changed = true
+			j++
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	// Keep unchanged lines only when they're close to a change
+	near := make([]bool, len(lines))
+	for k, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for c := max(0, k-diffContext); c <= min(len(lines)-1, k+diffContext); c++ {
+			near[c] = true
+		}
+	}
+
+	var out strings.Builder
+	skipped := false
+	for k, l := range lines {
+		if !near[k] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			out.WriteString("...\n")
+			skipped = false
+		}
+		fmt.Fprintf(&out, "%c %s\n", l.op, l.text)
+	}
+	if skipped {
+		out.WriteString("...\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,80 @@
+package plans
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiffStats are the numeric size of a rotation's changes, from
+// 'git diff --numstat'
+type DiffStats struct {
+	FilesChanged int `json:"filesChanged"`
+	Insertions   int `json:"insertions"`
+	Deletions    int `json:"deletions"`
+
+	// TestFiles is how many of the changed files are tests
+	TestFiles int `json:"testFiles"`
+}
+
+// renameBraces matches the changed part of a rename in numstat output, as
+// in "src/{old => new}/file.go"
+var renameBraces = regexp.MustCompile(`\{[^{}]* => ([^{}]*)\}`)
+
+// ParseNumStat totals 'git diff --numstat' output. Binary files count as
+// changed files without any lines.
+func ParseNumStat(numstat string) *DiffStats {
+	stats := &DiffStats{}
+	for _, line := range strings.Split(numstat, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		stats.FilesChanged++
+		if n, err := strconv.Atoi(fields[0]); err == nil {
+			stats.Insertions += n
+		}
+		if n, err := strconv.Atoi(fields[1]); err == nil {
+			stats.Deletions += n
+		}
+		if IsTestFile(renamedPath(fields[2])) {
+			stats.TestFiles++
+		}
+	}
+	return stats
+}
+
+// renamedPath returns the new path of a numstat path, which git writes as
+// "old => new" or "dir/{old => new}/file" for renames
+func renamedPath(p string) string {
+	if !strings.Contains(p, " => ") {
+		return p
+	}
+	if renameBraces.MatchString(p) {
+		p = renameBraces.ReplaceAllString(p, "$1")
+		return strings.ReplaceAll(p, "//", "/")
+	}
+	return p[strings.Index(p, " => ")+len(" => "):]
+}
+
+// IsTestFile reports whether a path looks like a test by the naming
+// conventions of common languages: foo_test.go, foo.test.ts, foo.spec.js,
+// test_foo.py, FooTest.java, or anything under a test directory
+func IsTestFile(p string) bool {
+	for _, dir := range strings.Split(strings.ToLower(path.Dir(p)), "/") {
+		switch dir {
+		case "test", "tests", "__tests__", "spec", "specs", "testdata":
+			return true
+		}
+	}
+
+	base := path.Base(p)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	if strings.HasSuffix(stem, "Test") || strings.HasSuffix(stem, "Tests") {
+		return true
+	}
+	stem = strings.ToLower(stem)
+	return strings.HasSuffix(stem, "_test") || strings.HasSuffix(stem, ".test") ||
+		strings.HasSuffix(stem, ".spec") || strings.HasPrefix(stem, "test_")
+}
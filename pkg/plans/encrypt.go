@@ -0,0 +1,95 @@
+package plans
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// ageHeader starts every age-encrypted file
+const ageHeader = "age-encryption.org/"
+
+// ErrEncrypted is returned when reading an encrypted artifact without the
+// team key
+var ErrEncrypted = errors.New("file is encrypted; set encryptionKey to the team key to read it")
+
+// SetEncryptionKey encrypts summaries, the summary journal, the handoff
+// brief, and archives at rest with the team's age X25519 key ("AGE-SECRET-KEY-1..."). Files written
+// before are still read as they are.
+func (m *Manager) SetEncryptionKey(key string) error {
+	identity, err := age.ParseX25519Identity(strings.TrimSpace(key))
+	if err != nil {
+		return err
+	}
+	m.identity = identity
+	return nil
+}
+
+// Encrypted reports whether the Manager encrypts what it writes
+func (m *Manager) Encrypted() bool {
+	return m.identity != nil
+}
+
+// IsEncrypted reports whether data is age-encrypted
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageHeader))
+}
+
+// Seal encrypts data to the team key, or returns it as is without one, for
+// callers that keep their own files alongside the Manager's
+func (m *Manager) Seal(data []byte) ([]byte, error) {
+	return m.seal(data)
+}
+
+// Open decrypts data written by Seal, passing plaintext through
+func (m *Manager) Open(data []byte) ([]byte, error) {
+	return m.open(data)
+}
+
+// seal encrypts data to the team key, or returns it as is without one
+func (m *Manager) seal(data []byte) ([]byte, error) {
+	if m.identity == nil {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, m.identity.Recipient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// open decrypts data if it's encrypted
+func (m *Manager) open(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if m.identity == nil {
+		return nil, ErrEncrypted
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), m.identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt (wrong encryptionKey?): %w", err)
+	}
+	return io.ReadAll(r)
+}
+
+// readArtifact reads a file the Manager may have encrypted
+func (m *Manager) readArtifact(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return m.open(data)
+}
@@ -0,0 +1,121 @@
+package plans
+
+import (
+	"sort"
+	"time"
+)
+
+// SessionGap is the longest pause between rotations on a branch that still
+// counts as the same session
+const SessionGap = 4 * time.Hour
+
+// ImbalanceThreshold is how far, as a fraction of an even share, a driver's
+// keyboard time may stray before it's flagged
+const ImbalanceThreshold = 0.5
+
+// DriverTime is one driver's share of the keyboard
+type DriverTime struct {
+	Name      string
+	Rotations int
+	Time      time.Duration
+	// Share is the driver's fraction of all keyboard time
+	Share float64
+	// Over and Under flag drivers far above or below an even share
+	Over, Under bool
+}
+
+// FairnessReport is per-driver keyboard time over a set of sessions
+type FairnessReport struct {
+	Sessions int
+	Total    time.Duration
+	Drivers  []DriverTime
+}
+
+// Sessions groups summaries into sessions: rotations on the same branch
+// with no more than SessionGap between them. Sessions are returned oldest
+// first, each with its rotations in order.
+func Sessions(summaries []*Summary) [][]*Summary {
+	sorted := append([]*Summary(nil), summaries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return rotationStart(sorted[i]).Before(rotationStart(sorted[j])) })
+
+	var sessions [][]*Summary
+	open := make(map[string]int) // branch -> index of its latest session
+	for _, s := range sorted {
+		i, ok := open[s.Branch]
+		if ok {
+			last := sessions[i][len(sessions[i])-1]
+			ok = rotationStart(s).Sub(rotationEnd(last)) <= SessionGap
+		}
+		if !ok {
+			sessions = append(sessions, nil)
+			i = len(sessions) - 1
+			open[s.Branch] = i
+		}
+		sessions[i] = append(sessions[i], s)
+	}
+	return sessions
+}
+
+// Fairness reports each driver's keyboard time over the last n sessions
+// (all of them if n is 0 or less), busiest driver first
+func Fairness(summaries []*Summary, n int) FairnessReport {
+	sessions := Sessions(summaries)
+	if n > 0 && len(sessions) > n {
+		sessions = sessions[len(sessions)-n:]
+	}
+
+	report := FairnessReport{Sessions: len(sessions)}
+	byName := make(map[string]*DriverTime)
+	for _, session := range sessions {
+		for _, s := range session {
+			name := s.DriverName
+			if name == "" {
+				name = "unknown"
+			}
+			d := byName[name]
+			if d == nil {
+				d = &DriverTime{Name: name}
+				byName[name] = d
+			}
+			d.Rotations++
+			d.Time += s.RotationDuration()
+			report.Total += s.RotationDuration()
+		}
+	}
+
+	even := 0.0
+	if len(byName) > 0 {
+		even = 1 / float64(len(byName))
+	}
+	for _, d := range byName {
+		if report.Total > 0 {
+			d.Share = float64(d.Time) / float64(report.Total)
+			d.Over = len(byName) > 1 && d.Share > even*(1+ImbalanceThreshold)
+			d.Under = len(byName) > 1 && d.Share < even*(1-ImbalanceThreshold)
+		}
+		report.Drivers = append(report.Drivers, *d)
+	}
+	sort.Slice(report.Drivers, func(i, j int) bool {
+		if report.Drivers[i].Time != report.Drivers[j].Time {
+			return report.Drivers[i].Time > report.Drivers[j].Time
+		}
+		return report.Drivers[i].Name < report.Drivers[j].Name
+	})
+	return report
+}
+
+// rotationStart is when a rotation started, falling back to its summary time
+func rotationStart(s *Summary) time.Time {
+	if !s.StartedAt.IsZero() {
+		return s.StartedAt
+	}
+	return s.Timestamp
+}
+
+// rotationEnd is when a rotation ended, falling back to its summary time
+func rotationEnd(s *Summary) time.Time {
+	if !s.EndedAt.IsZero() {
+		return s.EndedAt
+	}
+	return s.Timestamp
+}
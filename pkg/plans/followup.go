@@ -0,0 +1,66 @@
+package plans
+
+import (
+	"fmt"
+	"strings"
+)
+
+// followUpTemplate is the plan of a follow-up workstream; {{from}} is the
+// branch the tasks came from and {{tasks}} their checkbox lines
+const followUpTemplate = `# Mob Session: {{branch}}
+
+## Goal
+Finish the tasks left open when the mob on {{from}} wrapped up.
+
+## Tasks
+{{tasks}}
+## Current Status
+Not started. Carried over from {{from}}.
+
+---
+Created: {{created}}
+`
+
+// FollowUpBranch is the branch that takes over the open tasks of a branch
+// whose session ended before they were done
+func FollowUpBranch(branch string) string {
+	return branch + "-followups"
+}
+
+// OpenTasks returns the tasks that aren't done yet
+func OpenTasks(tasks []Task) []Task {
+	var open []Task
+	for _, t := range tasks {
+		if !t.Done {
+			open = append(open, t)
+		}
+	}
+	return open
+}
+
+// SaveFollowUps carries tasks left open on branch over to the plan of its
+// follow-up branch, creating the plan or adding a section to it, and
+// returns the follow-up branch
+func (m *Manager) SaveFollowUps(branch string, tasks []Task) (string, error) {
+	followUp := FollowUpBranch(branch)
+	var lines strings.Builder
+	for _, t := range tasks {
+		fmt.Fprintf(&lines, "- [ ] %s\n", t.Text)
+	}
+
+	existing, err := m.LoadPlan(followUp)
+	if err != nil {
+		return "", err
+	}
+	plan := ""
+	if existing == "" {
+		template := strings.NewReplacer("{{from}}", branch, "{{tasks}}", lines.String()).Replace(followUpTemplate)
+		plan = m.RenderTemplate(followUp, template)
+	} else {
+		plan = fmt.Sprintf("%s\n\n## Follow-ups from %s\n%s", strings.TrimRight(existing, "\n"), branch, lines.String())
+	}
+	if err := m.SavePlan(followUp, plan); err != nil {
+		return "", err
+	}
+	return followUp, nil
+}
@@ -0,0 +1,128 @@
+package plans
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterFence opens and closes a plan's YAML front-matter block
+const frontMatterFence = "---"
+
+// FrontMatter is the YAML metadata block at the top of a plan file
+type FrontMatter struct {
+	// Branch is the branch the plan belongs to, so it doesn't have to be
+	// recovered from the file name
+	Branch string `yaml:"branch,omitempty"`
+
+	// WorkstreamID is the dashboard workstream the branch is registered as
+	WorkstreamID string `yaml:"workstreamId,omitempty"`
+
+	// Ticket is the issue the branch works on, such as PROJ-123 or #42
+	Ticket string `yaml:"ticket,omitempty"`
+
+	// Owner is who is responsible for the workstream, by default the
+	// driver who created the plan
+	Owner string `yaml:"owner,omitempty"`
+
+	// SyncedHash is the ContentHash of the plan when it was last synced
+	// with the dashboard
+	SyncedHash string `yaml:"syncedHash,omitempty"`
+
+	// Fields are the workstream's custom fields from the dashboard, such as
+	// sprint, epic, or component
+	Fields map[string]string `yaml:"fields,omitempty"`
+}
+
+// IsEmpty reports whether the front matter has nothing worth writing
+func (fm *FrontMatter) IsEmpty() bool {
+	return fm.Branch == "" && fm.WorkstreamID == "" && fm.Ticket == "" && fm.Owner == "" &&
+		fm.SyncedHash == "" && len(fm.Fields) == 0
+}
+
+// ParseFrontMatter splits a plan into its front matter and the markdown
+// body after it. A plan without front matter returns an empty FrontMatter
+// and the plan unchanged.
+func ParseFrontMatter(plan string) (*FrontMatter, string, error) {
+	fm := &FrontMatter{}
+	rest, ok := strings.CutPrefix(plan, frontMatterFence+"\n")
+	if !ok {
+		return fm, plan, nil
+	}
+
+	end := strings.Index(rest, "\n"+frontMatterFence+"\n")
+	if end < 0 {
+		return fm, plan, nil
+	}
+	if err := yaml.Unmarshal([]byte(rest[:end]), fm); err != nil {
+		return &FrontMatter{}, plan, fmt.Errorf("failed to parse plan front matter: %w", err)
+	}
+	return fm, rest[end+len(frontMatterFence)+2:], nil
+}
+
+// WithFrontMatter renders fm ahead of the plan body. Empty front matter is
+// left out entirely.
+func WithFrontMatter(fm *FrontMatter, body string) (string, error) {
+	if fm.IsEmpty() {
+		return body, nil
+	}
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(fm); err != nil {
+		return "", fmt.Errorf("failed to write plan front matter: %w", err)
+	}
+	return frontMatterFence + "\n" + b.String() + frontMatterFence + "\n" + body, nil
+}
+
+// ReadFrontMatter returns a plan's front matter, or an empty one if it
+// has none or it can't be parsed
+func ReadFrontMatter(plan string) *FrontMatter {
+	fm, _, err := ParseFrontMatter(plan)
+	if err != nil {
+		return &FrontMatter{}
+	}
+	return fm
+}
+
+// UpdateFrontMatter applies update to a plan's front matter, keeping the
+// rest of the plan as is
+func UpdateFrontMatter(plan string, update func(fm *FrontMatter)) (string, error) {
+	fm, body, err := ParseFrontMatter(plan)
+	if err != nil {
+		return "", err
+	}
+	update(fm)
+	return WithFrontMatter(fm, body)
+}
+
+// PlanFields returns the custom fields in a plan's front matter
+func PlanFields(plan string) map[string]string {
+	fm, _, err := ParseFrontMatter(plan)
+	if err != nil {
+		return nil
+	}
+	return fm.Fields
+}
+
+// SetPlanFields replaces the custom fields in a plan's front matter,
+// keeping the rest of the plan as is
+func SetPlanFields(plan string, fields map[string]string) (string, error) {
+	return UpdateFrontMatter(plan, func(fm *FrontMatter) { fm.Fields = fields })
+}
+
+// UpdatePlanFrontMatter applies update to the front matter of a branch's
+// plan file, saving it only if something changed. A missing plan is left
+// alone.
+func (m *Manager) UpdatePlanFrontMatter(branch string, update func(fm *FrontMatter)) error {
+	plan, err := m.LoadPlan(branch)
+	if err != nil || plan == "" {
+		return err
+	}
+	updated, err := UpdateFrontMatter(plan, update)
+	if err != nil || updated == plan {
+		return err
+	}
+	return m.SavePlan(branch, updated)
+}
@@ -0,0 +1,148 @@
+package plans
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/i18n"
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// HandoffFile is the brief left for the next driver. It lives in the working
+// tree so mob next carries it along with the WIP commit.
+const HandoffFile = ".claude/mob/handoff.md"
+
+// BuildHandoff renders the handoff brief for the next driver from the
+// rotation summary, the plan, and the files touched on the branch. Its
+// headings are written in lang (a language code; "" for English), while the
+// first line stays fixed so LoadHandoff can match it to the branch.
+func BuildHandoff(summary *Summary, plan string, files []string, lang string) string {
+	t := func(msg string) string { return i18n.TIn(lang, msg) }
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Handoff: %s\n\n", summary.Branch)
+	driver := summary.DriverName
+	if driver == "" {
+		driver = t("previous driver")
+	}
+	fmt.Fprintf(&b, t("_From %s at %s_\n"), driver, summary.Timestamp.Format("2006-01-02 15:04"))
+
+	fmt.Fprintf(&b, "\n## TL;DR\n%s\n", summary.TLDR)
+	if summary.DriverNote != "" {
+		fmt.Fprintf(&b, "\n## %s\n%s\n", t("Driver note"), summary.DriverNote)
+	}
+	writeList(&b, t("Navigator notes"), summary.NavigatorNotes)
+	writeList(&b, t("Next steps"), summary.NextSteps)
+
+	if next := NextUnblockedTask(ParseTasks(plan)); next != nil {
+		fmt.Fprintf(&b, "\n## %s\n%d. %s\n", t("Next task"), next.Number, next.Text)
+	}
+	writeList(&b, t("Outstanding tasks"), OutstandingTasks(plan, lang))
+	writeList(&b, t("Open questions"), OpenQuestions(plan))
+
+	// mob-claude's own bookkeeping isn't interesting to the next driver
+	var touched []string
+	for _, f := range files {
+		if !strings.HasPrefix(f, ".claude/mob/") {
+			touched = append(touched, f)
+		}
+	}
+	writeList(&b, t("Files touched"), touched)
+	if u := summary.Uncommitted; u != nil && !u.Included {
+		writeList(&b, t("Left out of the handoff"), u.Files)
+	}
+
+	return b.String()
+}
+
+// OutstandingTasks lists the plan's unchecked tasks as "2. Add tests",
+// noting each one's owner and, in lang, the tasks it's blocked by
+func OutstandingTasks(plan, lang string) []string {
+	tasks := ParseTasks(plan)
+	var outstanding []string
+	for _, task := range tasks {
+		if task.Done {
+			continue
+		}
+		line := fmt.Sprintf("%d. %s", task.Number, task.Text)
+		if task.Owner != "" {
+			line += " (@" + task.Owner + ")"
+		}
+		if waiting := task.BlockedBy(tasks); len(waiting) > 0 {
+			line += fmt.Sprintf(i18n.TIn(lang, " (blocked by %s)"), joinNumbers(waiting))
+		}
+		outstanding = append(outstanding, line)
+	}
+	return outstanding
+}
+
+// OpenQuestions returns the plan lines that ask a question, skipping
+// headings and completed tasks
+func OpenQuestions(plan string) []string {
+	var questions []string
+	for _, line := range strings.Split(plan, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasSuffix(line, "?") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "- [x]") || strings.HasPrefix(line, "- [X]") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*"))
+		line = strings.TrimSpace(strings.TrimPrefix(line, "[ ]"))
+		questions = append(questions, line)
+	}
+	return questions
+}
+
+func writeList(b *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n", heading)
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+}
+
+func joinNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = fmt.Sprintf("%d", n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// LoadHandoff reads the handoff brief left for branch, returning an empty
+// string if there is none or it was written for another branch
+func (m *Manager) LoadHandoff(branch string) (string, error) {
+	data, err := m.readArtifact(filepath.Join(m.projectRoot, HandoffFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read handoff brief: %w", err)
+	}
+	if !strings.HasPrefix(string(data), fmt.Sprintf("# Handoff: %s\n", branch)) {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+// SaveHandoff writes the handoff brief for the next driver
+func (m *Manager) SaveHandoff(brief string) error {
+	path := filepath.Join(m.projectRoot, HandoffFile)
+	if err := os.MkdirAll(filepath.Dir(path), platform.DirPerm); err != nil {
+		return err
+	}
+	data, err := m.seal([]byte(brief))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, platform.FilePerm); err != nil {
+		return fmt.Errorf("failed to write handoff brief: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package plans
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// JournalFile holds a generated summary until it has been saved and
+// uploaded, so a crash mid-handoff doesn't lose it
+const JournalFile = ".claude/mob/summary-journal.json"
+
+func (m *Manager) journalPath() string {
+	return filepath.Join(m.projectRoot, JournalFile)
+}
+
+// JournalSummary durably records a freshly generated summary. The file is
+// synced and renamed into place so a crash never leaves a partial journal.
+func (m *Manager) JournalSummary(summary *Summary) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	if data, err = m.seal(data); err != nil {
+		return err
+	}
+
+	path := m.journalPath()
+	if err := os.MkdirAll(filepath.Dir(path), platform.DirPerm); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".summary-journal-*")
+	if err != nil {
+		return fmt.Errorf("failed to create journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// RecoverSummary returns the journaled summary, or nil if there is none
+func (m *Manager) RecoverSummary() (*Summary, error) {
+	data, err := m.readArtifact(m.journalPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	summary := &Summary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, fmt.Errorf("failed to parse journal: %w", err)
+	}
+	return summary, nil
+}
+
+// ClearJournal removes the journaled summary once it's safely stored
+func (m *Manager) ClearJournal() error {
+	if err := os.Remove(m.journalPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
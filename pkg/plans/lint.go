@@ -0,0 +1,135 @@
+package plans
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RequiredSections are the "## " headings every plan must have
+var RequiredSections = []string{"Goal", "Current Status"}
+
+// Lint severities. Errors mean the plan isn't being maintained; warnings
+// point at leftovers worth cleaning up.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintProblem is something wrong with a plan's structure
+type LintProblem struct {
+	// Line is the 1-based line the problem is on, or 0 for the whole plan
+	Line     int    `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (p LintProblem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", p.Line, p.Severity, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.Severity, p.Message)
+}
+
+// placeholderTask matches the example tasks in the default template
+var placeholderTask = regexp.MustCompile(`^- \[ \] Task \d+$`)
+
+// isPlaceholder reports whether a plan line is template filler: an
+// italic hint such as "_Describe the goal of this mob session_", or one
+// of the default template's example tasks
+func isPlaceholder(line string) bool {
+	line = strings.TrimSpace(line)
+	if len(line) > 2 && strings.HasPrefix(line, "_") && strings.HasSuffix(line, "_") {
+		return true
+	}
+	return placeholderTask.MatchString(line)
+}
+
+// isBoilerplate reports whether a line carries no content of its own: a
+// blank line, the footer rule, or the "Created:" stamp
+func isBoilerplate(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "" || line == "---" || strings.HasPrefix(line, "Created:")
+}
+
+// Lint checks a plan's structure: every required section is present and
+// filled in, no template placeholders are left behind, and the plan isn't
+// still the untouched template
+func Lint(plan string) []LintProblem {
+	// Report line numbers in the file, front matter included
+	offset := 0
+	if _, body, err := ParseFrontMatter(plan); err == nil && body != plan {
+		offset = strings.Count(plan[:len(plan)-len(body)], "\n")
+		plan = body
+	}
+
+	var problems []LintProblem
+	preamble, sections := splitSections(plan)
+	if len(sections) == 0 {
+		return []LintProblem{{Severity: LintError, Message: "the plan has no \"## \" sections"}}
+	}
+
+	// line is the number of the line before the section being checked
+	line := offset + len(preamble)
+
+	found := make(map[string]bool)
+	touched := false
+	for _, s := range sections {
+		line++
+		name := strings.TrimSpace(strings.TrimPrefix(s.heading, "## "))
+		required := containsFold(RequiredSections, name)
+		if required {
+			found[strings.ToLower(name)] = true
+		}
+
+		filled := false
+		for i, l := range s.lines {
+			switch {
+			case isPlaceholder(l):
+				severity := LintWarning
+				if required {
+					severity = LintError
+				}
+				problems = append(problems, LintProblem{
+					Line:     line + i + 1,
+					Severity: severity,
+					Message:  fmt.Sprintf("placeholder left in %s: %s", name, strings.TrimSpace(l)),
+				})
+			case !isBoilerplate(l):
+				filled = true
+			}
+		}
+		if required && !filled {
+			problems = append(problems, LintProblem{Line: line, Severity: LintError, Message: fmt.Sprintf("section %s is empty", name)})
+		}
+		touched = touched || filled
+		line += len(s.lines)
+	}
+
+	for _, name := range RequiredSections {
+		if !found[strings.ToLower(name)] {
+			problems = append(problems, LintProblem{Severity: LintError, Message: fmt.Sprintf("missing required section ## %s", name)})
+		}
+	}
+	if !touched {
+		problems = append(problems, LintProblem{Severity: LintError, Message: "the plan is still the untouched template"})
+	}
+
+	// In file order, with whole-plan problems last
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Line, problems[j].Line
+		return a != 0 && (b == 0 || a < b)
+	})
+	return problems
+}
+
+// HasLintErrors reports whether any of the problems is an error
+func HasLintErrors(problems []LintProblem) bool {
+	for _, p := range problems {
+		if p.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
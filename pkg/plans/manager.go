@@ -0,0 +1,548 @@
+// Package plans manages a project's mob-claude files: the per-branch plan
+// markdown under .claude/plans, the saved rotation summaries and their
+// index, plan templates, the handoff brief, and archives.
+package plans
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/mob-claude/mob-claude/internal/platform"
+	"github.com/mob-claude/mob-claude/pkg/clock"
+)
+
+const (
+	PlansDir     = ".claude/plans"
+	SummariesDir = ".claude/mob/summaries"
+	TemplatesDir = ".claude/mob/templates"
+)
+
+// Manager handles plan file operations
+type Manager struct {
+	projectRoot string
+	clock       clock.Clock
+	files       *FileStorage
+
+	// remote receives a copy of every save, see SetRemote
+	remote      Storage
+	remoteError func(error)
+
+	// identity is the team key summaries are encrypted with, see
+	// SetEncryptionKey
+	identity *age.X25519Identity
+}
+
+// NewManager creates a new plan manager for the current project
+func NewManager() (*Manager, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return NewManagerAt(cwd), nil
+}
+
+// NewManagerAt creates a plan manager for the project rooted at
+// projectRoot, for tools that don't run from inside the project
+func NewManagerAt(projectRoot string) *Manager {
+	return &Manager{projectRoot: projectRoot, clock: clock.System, files: NewFileStorage(projectRoot)}
+}
+
+// SetClock overrides the clock used for plan timestamps
+func (m *Manager) SetClock(c clock.Clock) {
+	m.clock = c
+}
+
+// GetPlanPath returns the path to the plan file for a given branch
+func (m *Manager) GetPlanPath(branch string) string {
+	return m.files.PlanPath(branch)
+}
+
+// GetSummariesDir returns the path to the summaries directory
+func (m *Manager) GetSummariesDir() string {
+	return filepath.Join(m.projectRoot, SummariesDir)
+}
+
+// EnsureDirs creates the necessary directories if they don't exist
+func (m *Manager) EnsureDirs() error {
+	dirs := []string{
+		filepath.Join(m.projectRoot, PlansDir),
+		filepath.Join(m.projectRoot, SummariesDir),
+	}
+
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// LoadPlan reads the plan file for a given branch
+func (m *Manager) LoadPlan(branch string) (string, error) {
+	return m.files.LoadPlan(branch)
+}
+
+// SavePlan writes the plan file for a given branch, and copies it to the
+// remote Storage if there is one
+func (m *Manager) SavePlan(branch, content string) error {
+	if err := m.EnsureDirs(); err != nil {
+		return err
+	}
+	if err := m.files.SavePlan(branch, content); err != nil {
+		return err
+	}
+	m.copyToRemote(func(s Storage) error { return s.SavePlan(branch, content) })
+	return nil
+}
+
+// PlanExists checks if a plan file exists for the given branch
+func (m *Manager) PlanExists(branch string) bool {
+	planPath := m.GetPlanPath(branch)
+	_, err := os.Stat(planPath)
+	return err == nil
+}
+
+// CreateDefaultPlan creates a new plan file with a template
+func (m *Manager) CreateDefaultPlan(branch string) error {
+	return m.CreatePlanFromTemplate(branch, DefaultTemplate)
+}
+
+// DefaultTemplate is used when no named template is selected
+const DefaultTemplate = `# Mob Session: {{branch}}
+
+## Goal
+_Describe the goal of this mob session_
+
+## Current Status
+- [ ] Task 1
+- [ ] Task 2
+
+## Notes
+_Add notes during the session_
+
+## Decisions Made
+_Document important decisions_
+
+---
+Created: {{created}}
+`
+
+// CreatePlanFromTemplate creates a new plan file from template text,
+// replacing the {{branch}} and {{created}} placeholders
+func (m *Manager) CreatePlanFromTemplate(branch, template string) error {
+	return m.SavePlan(branch, m.RenderTemplate(branch, template))
+}
+
+// RenderTemplate fills in a plan template's {{branch}} and {{created}}
+// placeholders
+func (m *Manager) RenderTemplate(branch, template string) string {
+	return strings.NewReplacer(
+		"{{branch}}", branch,
+		"{{created}}", m.clock.Now().Format(time.RFC3339),
+	).Replace(template)
+}
+
+// TemplateFromPlan turns a branch's plan into a plan template: its front
+// matter is dropped, its tasks unchecked, and the branch name in its title
+// and its creation time replaced with the {{branch}} and {{created}}
+// placeholders
+func TemplateFromPlan(plan, branch string) string {
+	if _, body, err := ParseFrontMatter(plan); err == nil {
+		plan = body
+	}
+	lines := strings.Split(plan, "\n")
+	titled := false
+	for i, line := range lines {
+		if !titled && strings.HasPrefix(line, "# ") {
+			lines[i] = strings.ReplaceAll(line, branch, "{{branch}}")
+			titled = true
+		}
+		if strings.HasPrefix(line, "Created: ") {
+			lines[i] = "Created: {{created}}"
+		}
+		lines[i] = taskPattern.ReplaceAllString(lines[i], "${1} ${3}${4}")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LoadTemplate reads a named plan template from the templates directory.
+// It returns an empty string if the template doesn't exist.
+func (m *Manager) LoadTemplate(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid template name: %q", name)
+	}
+
+	path := filepath.Join(m.projectRoot, TemplatesDir, name+".md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	return string(data), nil
+}
+
+// SaveTemplate writes a named plan template to the templates directory,
+// replacing any template of that name
+func (m *Manager) SaveTemplate(name, content string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid template name: %q", name)
+	}
+
+	path := filepath.Join(m.projectRoot, TemplatesDir, name+".md")
+	if err := os.MkdirAll(filepath.Dir(path), platform.DirPerm); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), platform.FilePerm); err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+	return nil
+}
+
+// ListTemplates returns the names of the local plan templates
+func (m *Manager) ListTemplates() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(m.projectRoot, TemplatesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".md"))
+		}
+	}
+	return names, nil
+}
+
+// SummarySchemaVersion is the version of the summary format, in saved
+// files and in the summaryJson of uploads. Bump it when a field is removed
+// or changes meaning; adding a field doesn't need a bump. Summaries saved
+// before versioning read as version 0.
+const SummarySchemaVersion = 1
+
+// Summary represents a rotation summary
+type Summary struct {
+	// SchemaVersion is the SummarySchemaVersion the summary was saved with
+	SchemaVersion int `json:"schemaVersion,omitempty"`
+
+	Timestamp  time.Time `json:"timestamp"`
+	DriverName string    `json:"driverName"`
+	DriverNote string    `json:"driverNote"`
+	TLDR       string    `json:"tldr"`
+	Changes    []string  `json:"changes"`
+	NextSteps  []string  `json:"nextSteps"`
+	Branch     string    `json:"branch"`
+
+	// Rotation numbers the branch's rotations from 1. SaveSummary assigns
+	// it; summaries saved before it existed have 0.
+	Rotation int `json:"rotation,omitempty"`
+
+	// Tags are what the driver says the rotation was spent on, e.g.
+	// "backend" or "tests", normalized with NormalizeTags
+	Tags []string `json:"tags,omitempty"`
+
+	// StartedAt and EndedAt bound the rotation; Duration is the time between
+	// them in seconds
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+	Duration  int       `json:"durationSeconds"`
+
+	// ClaudeSessionID is the driver's Claude Code conversation, which the
+	// next driver can pick up with 'mob-claude claude-resume'
+	ClaudeSessionID string `json:"claudeSessionId,omitempty"`
+
+	// Uncommitted records the changes left uncommitted at handoff and
+	// whether the driver chose to include them
+	Uncommitted *UncommittedChanges `json:"uncommitted,omitempty"`
+
+	// Covered is a compact digest of the changes the summary reported, so
+	// later summaries on the branch can leave them out
+	Covered []string `json:"covered,omitempty"`
+
+	// NavigatorNotes are the navigators' observations during the rotation,
+	// each rendered as "[15:04] name: text"
+	NavigatorNotes []string `json:"navigatorNotes,omitempty"`
+
+	// DiffStats is the size of the branch's changes against its base when
+	// the summary was written
+	DiffStats *DiffStats `json:"diffStats,omitempty"`
+
+	// DiffBase and DiffHead are the commits the summarized diff ran
+	// between, so the summary can be regenerated from the same changes
+	DiffBase string `json:"diffBase,omitempty"`
+	DiffHead string `json:"diffHead,omitempty"`
+
+	// RemainingTasks records the plan tasks still open when the session
+	// ended and what became of them
+	RemainingTasks *RemainingTasks `json:"remainingTasks,omitempty"`
+
+	// UploadKey is the idempotency key the rotation is recorded on the
+	// dashboard with. It's set before the summary is journaled and saved
+	// with it, so neither a recovered summary nor a backfill records the
+	// rotation twice.
+	UploadKey string `json:"uploadKey,omitempty"`
+}
+
+// Outcomes for the tasks still open when a session ends
+const (
+	RemainingFollowUp = "followup"
+	RemainingDone     = "done"
+)
+
+// RemainingTasks are the plan tasks left open at 'done'
+type RemainingTasks struct {
+	Tasks []string `json:"tasks"`
+
+	// Outcome is RemainingFollowUp if they moved to the FollowUp branch's
+	// plan, or RemainingDone if they were checked off
+	Outcome  string `json:"outcome"`
+	FollowUp string `json:"followUp,omitempty"`
+}
+
+// UncommittedChanges are the working tree changes found when handing off
+type UncommittedChanges struct {
+	Files []string `json:"files"`
+
+	// Included is whether they went into the WIP commit; if not, they were
+	// stashed on the driver's machine
+	Included bool `json:"included"`
+}
+
+// SetRotationTimes records when the rotation started and ended, along with
+// its duration
+func (s *Summary) SetRotationTimes(startedAt, endedAt time.Time) {
+	s.StartedAt = startedAt
+	s.EndedAt = endedAt
+	if !startedAt.IsZero() && endedAt.After(startedAt) {
+		s.Duration = int(endedAt.Sub(startedAt).Seconds())
+	}
+}
+
+// RotationDuration returns how long the rotation lasted, or zero if unknown
+func (s *Summary) RotationDuration() time.Duration {
+	return time.Duration(s.Duration) * time.Second
+}
+
+// maxSummaryCollisions bounds how many rotation numbers SaveSummary tries
+// when another summary already has the file name
+const maxSummaryCollisions = 100
+
+// SaveSummary writes a summary to its branch's directory under the
+// summaries directory and records it in the summary index. It's copied to
+// the remote Storage if there is one.
+//
+// A new summary gets the branch's next rotation number, which is part of
+// its file name. If another process took that name in the meantime, the
+// next free number is used rather than overwriting it. Saving the same
+// summary again, e.g. when a handoff recovered from the journal is
+// finished, replaces its file.
+func (m *Manager) SaveSummary(summary *Summary) error {
+	if err := m.EnsureDirs(); err != nil {
+		return err
+	}
+	entries, err := m.SummaryIndex()
+	if err != nil {
+		return err
+	}
+
+	if saved := savedRotation(entries, summary); saved != nil {
+		summary.Rotation = saved.Rotation
+		return m.writeSummary(saved.File, summary, true)
+	}
+
+	if summary.Rotation == 0 {
+		summary.Rotation = nextRotation(entries, summary.Branch)
+	}
+	for attempt := 0; ; attempt++ {
+		err := m.writeSummary(summaryFile(summary), summary, false)
+		if !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+		if attempt == maxSummaryCollisions {
+			return fmt.Errorf("failed to save summary: %s is taken", summaryFile(summary))
+		}
+		summary.Rotation++
+	}
+}
+
+// ReplaceSummary overwrites the saved summary at path, one of the paths
+// ListSummaries returns, keeping its rotation number
+func (m *Manager) ReplaceSummary(path string, summary *Summary) error {
+	file, err := filepath.Rel(m.GetSummariesDir(), path)
+	if err != nil || strings.HasPrefix(file, "..") {
+		return fmt.Errorf("%s is not a saved summary", path)
+	}
+	previous, err := m.LoadSummary(path)
+	if err != nil {
+		return err
+	}
+	// Rewriting a newer format would drop the fields this version doesn't know
+	if previous.SchemaVersion > SummarySchemaVersion {
+		return fmt.Errorf("%s was saved by a newer mob-claude (summary schema version %d, this one writes %d); upgrade to change it", filepath.Base(path), previous.SchemaVersion, SummarySchemaVersion)
+	}
+	summary.Rotation = previous.Rotation
+	return m.writeSummary(filepath.ToSlash(file), summary, true)
+}
+
+// savedRotation finds the index entry of the summary if it was saved
+// before: same branch, driver, and timestamp
+func savedRotation(entries []SummaryIndexEntry, summary *Summary) *SummaryIndexEntry {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := &entries[i]
+		if e.Branch == summary.Branch && e.DriverName == summary.DriverName && e.Timestamp.Equal(summary.Timestamp) {
+			return e
+		}
+	}
+	return nil
+}
+
+// nextRotation is the number for a new rotation on the branch. Summaries
+// from before rotation numbers count too.
+func nextRotation(entries []SummaryIndexEntry, branch string) int {
+	count, highest := 0, 0
+	for _, e := range entries {
+		if e.Branch != branch {
+			continue
+		}
+		count++
+		if e.Rotation > highest {
+			highest = e.Rotation
+		}
+	}
+	if count > highest {
+		highest = count
+	}
+	return highest + 1
+}
+
+// writeSummary stores the summary as file, relative to the summaries
+// directory. Without replace, an existing file is left alone and the error
+// wraps fs.ErrExist.
+func (m *Manager) writeSummary(file string, summary *Summary, replace bool) error {
+	summary.SchemaVersion = SummarySchemaVersion
+
+	uncommitted := ""
+	if u := summary.Uncommitted; u != nil {
+		uncommitted = fmt.Sprintf(`,
+  "uncommitted": {"files": [%s], "included": %t}`, formatStringArray(u.Files), u.Included)
+	}
+
+	diffStats := ""
+	if d := summary.DiffStats; d != nil {
+		diffStats = fmt.Sprintf(`,
+  "diffStats": {"filesChanged": %d, "insertions": %d, "deletions": %d, "testFiles": %d}`, d.FilesChanged, d.Insertions, d.Deletions, d.TestFiles)
+	}
+
+	diffRange := ""
+	if summary.DiffBase != "" && summary.DiffHead != "" {
+		diffRange = fmt.Sprintf(`,
+  "diffBase": "%s",
+  "diffHead": "%s"`, escapeJSON(summary.DiffBase), escapeJSON(summary.DiffHead))
+	}
+
+	uploadKey := ""
+	if summary.UploadKey != "" {
+		uploadKey = fmt.Sprintf(`,
+  "uploadKey": "%s"`, escapeJSON(summary.UploadKey))
+	}
+
+	remaining := ""
+	if r := summary.RemainingTasks; r != nil {
+		remaining = fmt.Sprintf(`,
+  "remainingTasks": {"tasks": [%s], "outcome": "%s", "followUp": "%s"}`, formatStringArray(r.Tasks), escapeJSON(r.Outcome), escapeJSON(r.FollowUp))
+	}
+
+	// Format as JSON manually to avoid import cycle
+	content := fmt.Sprintf(`{
+  "schemaVersion": %d,
+  "timestamp": "%s",
+  "driverName": "%s",
+  "driverNote": "%s",
+  "tldr": "%s",
+  "changes": [%s],
+  "nextSteps": [%s],
+  "branch": "%s",
+  "rotation": %d,
+  "tags": [%s],
+  "startedAt": "%s",
+  "endedAt": "%s",
+  "durationSeconds": %d,
+  "claudeSessionId": "%s",
+  "covered": [%s],
+  "navigatorNotes": [%s]%s%s%s%s%s
+}`,
+		SummarySchemaVersion,
+		summary.Timestamp.Format(time.RFC3339),
+		escapeJSON(summary.DriverName),
+		escapeJSON(summary.DriverNote),
+		escapeJSON(summary.TLDR),
+		formatStringArray(summary.Changes),
+		formatStringArray(summary.NextSteps),
+		escapeJSON(summary.Branch),
+		summary.Rotation,
+		formatStringArray(summary.Tags),
+		summary.StartedAt.Format(time.RFC3339),
+		summary.EndedAt.Format(time.RFC3339),
+		summary.Duration,
+		escapeJSON(summary.ClaudeSessionID),
+		formatStringArray(summary.Covered),
+		formatStringArray(summary.NavigatorNotes),
+		uncommitted,
+		diffStats,
+		diffRange,
+		uploadKey,
+		remaining,
+	)
+
+	data, err := m.seal([]byte(content))
+	if err != nil {
+		return err
+	}
+	name := path.Base(file)
+	if replace {
+		err = m.files.AppendSummary(summary.Branch, name, data)
+	} else {
+		err = m.files.createSummary(summary.Branch, name, data)
+	}
+	if err != nil {
+		return err
+	}
+	if err := m.indexSummary(file, summary); err != nil {
+		return err
+	}
+	m.copyToRemote(func(s Storage) error { return s.AppendSummary(summary.Branch, name, data) })
+	return nil
+}
+
+// Helper functions
+func escapeJSON(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "\"", "\\\"")
+	s = strings.ReplaceAll(s, "\n", "\\n")
+	s = strings.ReplaceAll(s, "\r", "\\r")
+	s = strings.ReplaceAll(s, "\t", "\\t")
+	return s
+}
+
+func formatStringArray(arr []string) string {
+	if len(arr) == 0 {
+		return ""
+	}
+	var quoted []string
+	for _, s := range arr {
+		quoted = append(quoted, fmt.Sprintf("\"%s\"", escapeJSON(s)))
+	}
+	return strings.Join(quoted, ", ")
+}
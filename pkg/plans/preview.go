@@ -0,0 +1,148 @@
+package plans
+
+import (
+	"strings"
+)
+
+// DefaultPreviewLines caps the status plan preview when no limit is configured
+const DefaultPreviewLines = 20
+
+// DefaultPreviewSections are shown in full by the default preview; other
+// sections contribute only their unchecked tasks
+var DefaultPreviewSections = []string{"Goal"}
+
+// planSection is a "## " heading and the lines under it
+type planSection struct {
+	heading string
+	lines   []string
+}
+
+// splitSections breaks a plan into the lines before the first "## " heading
+// and the sections that follow
+func splitSections(plan string) (preamble []string, sections []planSection) {
+	current := -1
+	for _, line := range strings.Split(strings.TrimRight(plan, "\n"), "\n") {
+		if strings.HasPrefix(line, "## ") {
+			sections = append(sections, planSection{heading: line})
+			current++
+			continue
+		}
+		if current < 0 {
+			preamble = append(preamble, line)
+		} else {
+			sections[current].lines = append(sections[current].lines, line)
+		}
+	}
+	return preamble, sections
+}
+
+// Preview selects the plan lines to show in status. Sections named in
+// sections (matched case-insensitively), or the Goal section when none are
+// given, are shown in full; every other section contributes its unchecked
+// tasks, so outstanding work is never hidden. The result is capped at maxLines (DefaultPreviewLines
+// when zero or less), and hidden counts the non-blank plan lines left out.
+func Preview(plan string, sections []string, maxLines int) (lines []string, hidden int) {
+	if maxLines <= 0 {
+		maxLines = DefaultPreviewLines
+	}
+	if _, body, err := ParseFrontMatter(plan); err == nil {
+		plan = body
+	}
+	full := sections
+	if len(full) == 0 {
+		full = DefaultPreviewSections
+	}
+
+	preamble, parts := splitSections(plan)
+	for _, line := range preamble {
+		if strings.HasPrefix(line, "# ") {
+			lines = append(lines, line)
+		}
+	}
+
+	for _, section := range parts {
+		title := strings.TrimSpace(strings.TrimPrefix(section.heading, "## "))
+
+		var body []string
+		if containsFold(full, title) {
+			body = trimBlank(section.lines)
+		} else {
+			for _, line := range section.lines {
+				if m := taskPattern.FindStringSubmatch(line); m != nil && m[2] == " " {
+					body = append(body, line)
+				}
+			}
+		}
+		if len(body) > 0 {
+			lines = append(lines, "", section.heading)
+			lines = append(lines, body...)
+		}
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+	if hidden = countNonBlank(strings.Split(plan, "\n")) - countNonBlank(lines); hidden < 0 {
+		hidden = 0
+	}
+	return lines, hidden
+}
+
+func countNonBlank(lines []string) int {
+	n := 0
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimBlank drops leading and trailing blank lines
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// PlanTitle returns a one-line description of a plan: its "# " heading, or
+// for the default "# Mob Session: <branch>" heading, the first line of its
+// Goal section. Template placeholders such as "_Describe the goal..._" don't
+// count, so an untouched plan returns "".
+func PlanTitle(plan string) string {
+	if _, body, err := ParseFrontMatter(plan); err == nil {
+		plan = body
+	}
+	preamble, sections := splitSections(plan)
+	for _, line := range preamble {
+		if title, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok && !strings.HasPrefix(title, "Mob Session:") {
+			return strings.TrimSpace(title)
+		}
+	}
+	for _, s := range sections {
+		if !strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(s.heading, "## ")), "Goal") {
+			continue
+		}
+		for _, line := range s.lines {
+			line = strings.TrimSpace(line)
+			if line == "" || (strings.HasPrefix(line, "_") && strings.HasSuffix(line, "_")) {
+				continue
+			}
+			return line
+		}
+	}
+	return ""
+}
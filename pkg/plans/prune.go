@@ -0,0 +1,201 @@
+package plans
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// PruneOptions chooses what Prune removes
+type PruneOptions struct {
+	// Before is the cutoff: summaries saved before it are pruned. The zero
+	// time keeps every summary.
+	Before time.Time
+
+	// KeepArchives is how many archives each branch keeps, newest first;
+	// 0 keeps them all
+	KeepArchives int
+
+	// Branches prunes stale branches whole; without it only summaries and
+	// archives are pruned
+	Branches bool
+
+	// Active is the current session's branch, whose plan is never pruned
+	Active string
+
+	// Delete removes pruned summaries outright instead of archiving them.
+	// A stale branch is archived all the same, so its plan is never lost.
+	Delete bool
+
+	// DryRun reports what would be pruned without removing anything
+	DryRun bool
+}
+
+// PruneResult is what Prune removed, or would remove on a dry run
+type PruneResult struct {
+	// Summaries is how many summary files were pruned
+	Summaries int `json:"summaries"`
+
+	// Branches are the stale branches pruned whole: plan, summaries, and
+	// handoff brief
+	Branches []string `json:"branches,omitempty"`
+
+	// Archived are the archives written to keep what was pruned
+	Archived []string `json:"archived,omitempty"`
+
+	// Archives are the old archives deleted to stay within KeepArchives
+	Archives []string `json:"archives,omitempty"`
+}
+
+// Prune applies a retention policy to the local artifacts. Summaries saved
+// before the cutoff are archived, or deleted with opts.Delete. With
+// opts.Branches, a branch whose summaries and plan are all older than the
+// cutoff is stale, and is archived and cleaned whole like 'done --archive'
+// does. Then each branch's archives beyond opts.KeepArchives are deleted,
+// oldest first.
+func (m *Manager) Prune(opts PruneOptions) (*PruneResult, error) {
+	result := &PruneResult{}
+	now := m.clock.Now()
+
+	if !opts.Before.IsZero() {
+		entries, err := m.SummaryIndex()
+		if err != nil {
+			return nil, err
+		}
+		var branches []string
+		byBranch := make(map[string][]SummaryIndexEntry)
+		for _, e := range entries {
+			if _, ok := byBranch[e.Branch]; !ok {
+				branches = append(branches, e.Branch)
+			}
+			byBranch[e.Branch] = append(byBranch[e.Branch], e)
+		}
+
+		for _, branch := range branches {
+			var old []SummaryIndexEntry
+			for _, e := range byBranch[branch] {
+				if e.Timestamp.Before(opts.Before) {
+					old = append(old, e)
+				}
+			}
+			if len(old) == 0 {
+				continue
+			}
+			result.Summaries += len(old)
+
+			if opts.Branches && len(old) == len(byBranch[branch]) && branch != opts.Active && m.planOlderThan(branch, opts.Before) {
+				result.Branches = append(result.Branches, branch)
+				if opts.DryRun {
+					continue
+				}
+				archivePath, err := m.Archive(branch, "", now)
+				if err != nil {
+					return result, err
+				}
+				result.Archived = append(result.Archived, archivePath)
+				if err := m.CleanBranch(branch); err != nil {
+					return result, err
+				}
+				continue
+			}
+
+			if opts.DryRun {
+				continue
+			}
+			files := m.summaryPaths(old)
+			if !opts.Delete {
+				archivePath, err := m.ArchiveSummaries(branch, files, now)
+				if err != nil {
+					return result, err
+				}
+				result.Archived = append(result.Archived, archivePath)
+			}
+			for _, file := range files {
+				if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+					return result, fmt.Errorf("failed to remove summary: %w", err)
+				}
+			}
+			// Drop the branch directory if that emptied it
+			_ = os.Remove(filepath.Join(m.GetSummariesDir(), platform.SafeFilename(branch)))
+		}
+		if !opts.DryRun {
+			// Rebuild the index without the removed files
+			if _, err := m.SummaryIndex(); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if opts.KeepArchives > 0 {
+		if err := m.pruneArchives(opts, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// planOlderThan reports whether the branch's plan was last changed before
+// t, or it has none
+func (m *Manager) planOlderThan(branch string, t time.Time) bool {
+	info, err := os.Stat(m.GetPlanPath(branch))
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	return info.ModTime().Before(t)
+}
+
+// pruneArchives deletes each branch's archives beyond opts.KeepArchives.
+// Archive names start with their date, so name order is age order.
+func (m *Manager) pruneArchives(opts PruneOptions, result *PruneResult) error {
+	root := filepath.Join(m.projectRoot, ArchiveDir)
+	dirs, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(root, dir.Name()))
+		if err != nil {
+			return err
+		}
+		var archives []string
+		for _, e := range entries {
+			if !e.IsDir() && isArchive(e.Name()) {
+				archives = append(archives, e.Name())
+			}
+		}
+		if len(archives) <= opts.KeepArchives {
+			continue
+		}
+		sort.Strings(archives)
+		for _, name := range archives[:len(archives)-opts.KeepArchives] {
+			archivePath := filepath.Join(root, dir.Name(), name)
+			result.Archives = append(result.Archives, archivePath)
+			if opts.DryRun {
+				continue
+			}
+			if err := os.Remove(archivePath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove archive: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// PruneBefore returns the cutoff for keeping summaries the given number of
+// days; 0 days keeps them all and returns the zero time
+func PruneBefore(now time.Time, days int) time.Time {
+	if days <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -days)
+}
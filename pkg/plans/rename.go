@@ -0,0 +1,108 @@
+package plans
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RenameBranch moves everything mob-claude keeps for branch from over to
+// branch to: the plan file, the summaries, the handoff brief, and the plan
+// sync state. It refuses to overwrite a plan that to already has.
+func (m *Manager) RenameBranch(from, to string) error {
+	if from == to {
+		return nil
+	}
+	if m.PlanExists(from) && m.PlanExists(to) && m.GetPlanPath(from) != m.GetPlanPath(to) {
+		return fmt.Errorf("%s already has a plan", to)
+	}
+
+	if err := m.renamePlan(from, to); err != nil {
+		return err
+	}
+	if err := m.renameSummaries(from, to); err != nil {
+		return err
+	}
+
+	if brief, err := m.LoadHandoff(from); err != nil {
+		return err
+	} else if brief != "" {
+		brief = strings.Replace(brief, "# Handoff: "+from+"\n", "# Handoff: "+to+"\n", 1)
+		if err := m.SaveHandoff(brief); err != nil {
+			return err
+		}
+	}
+
+	state, err := m.LoadSyncState()
+	if err != nil {
+		return err
+	}
+	if hash, ok := state[from]; ok {
+		delete(state, from)
+		state[to] = hash
+		return m.SaveSyncState(state)
+	}
+	return nil
+}
+
+// renamePlan moves a branch's plan file, pointing its front matter and
+// default heading at the new branch
+func (m *Manager) renamePlan(from, to string) error {
+	plan, err := m.LoadPlan(from)
+	if err != nil || plan == "" {
+		return err
+	}
+	plan, err = UpdateFrontMatter(plan, func(fm *FrontMatter) {
+		if fm.Branch != "" {
+			fm.Branch = to
+		}
+	})
+	if err != nil {
+		return err
+	}
+	plan = strings.Replace(plan, "# Mob Session: "+from+"\n", "# Mob Session: "+to+"\n", 1)
+
+	if err := m.SavePlan(to, plan); err != nil {
+		return err
+	}
+	if m.GetPlanPath(to) == m.GetPlanPath(from) {
+		return nil
+	}
+	if err := os.Remove(m.GetPlanPath(from)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove old plan: %w", err)
+	}
+	return nil
+}
+
+// renameSummaries saves each of a branch's summaries again under the new
+// branch and removes the originals
+func (m *Manager) renameSummaries(from, to string) error {
+	files, err := m.ListBranchSummaries(from)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		summary, err := m.LoadSummary(file)
+		if err != nil {
+			continue
+		}
+		summary.Branch = to
+		if err := m.SaveSummary(summary); err != nil {
+			return err
+		}
+		moved := filepath.Join(m.GetSummariesDir(), filepath.FromSlash(summaryFile(summary)))
+		if moved == file {
+			continue
+		}
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove summary: %w", err)
+		}
+	}
+	if len(files) > 0 {
+		// Drop the emptied branch directory and the stale index entries
+		_ = os.Remove(filepath.Dir(files[0]))
+		_, err = m.SummaryIndex()
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package plans
+
+import (
+	"strings"
+	"time"
+)
+
+// DecisionsSection is the plan section decisions are recorded in
+const DecisionsSection = "Decisions Made"
+
+// Where a search match was found
+const (
+	SourceDecision = "decision"
+	SourceTLDR     = "tldr"
+	SourceChange   = "change"
+	SourceNextStep = "next step"
+	SourceNote     = "note"
+)
+
+// SearchMatch is a plan decision or summary entry matching a search
+type SearchMatch struct {
+	Branch string `json:"branch"`
+	Source string `json:"source"`
+	Text   string `json:"text"`
+
+	// Summary is the rotation that introduced the match, or nil if unknown
+	Summary *Summary `json:"summary,omitempty"`
+}
+
+// MatchesQuery reports whether text contains every word of query, ignoring case
+func MatchesQuery(text, query string) bool {
+	text = strings.ToLower(text)
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// Decisions returns the entries of a plan's Decisions Made section, without
+// their list markers. Placeholder text in italics is skipped.
+func Decisions(plan string) []string {
+	if _, body, err := ParseFrontMatter(plan); err == nil {
+		plan = body
+	}
+	_, sections := splitSections(plan)
+	var decisions []string
+	for _, section := range sections {
+		if !strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(section.heading, "## ")), DecisionsSection) {
+			continue
+		}
+		for _, line := range section.lines {
+			line = strings.TrimSpace(line)
+			if line == "" || line == "---" || strings.HasPrefix(line, "_") {
+				continue
+			}
+			line = strings.TrimSpace(strings.TrimLeft(line, "-*"))
+			decisions = append(decisions, line)
+		}
+	}
+	return decisions
+}
+
+// SearchSummary returns the entries of a summary that match query
+func SearchSummary(summary *Summary, query string) []SearchMatch {
+	var matches []SearchMatch
+	add := func(source, text string) {
+		if MatchesQuery(text, query) {
+			matches = append(matches, SearchMatch{Branch: summary.Branch, Source: source, Text: text, Summary: summary})
+		}
+	}
+	add(SourceTLDR, summary.TLDR)
+	for _, change := range summary.Changes {
+		add(SourceChange, change)
+	}
+	for _, step := range summary.NextSteps {
+		add(SourceNextStep, step)
+	}
+	add(SourceNote, summary.DriverNote)
+	return matches
+}
+
+// RotationAt returns the rotation, from a branch's summaries in
+// chronological order, that was under way at t: the last one to start at or
+// before it. The WIP commit mob next makes after a rotation ends still
+// belongs to that rotation, since the next one hasn't started yet. It
+// returns nil if t is before the first rotation.
+func RotationAt(summaries []*Summary, t time.Time) *Summary {
+	var at *Summary
+	for _, s := range summaries {
+		if rotationStart(s).After(t) {
+			break
+		}
+		at = s
+	}
+	return at
+}
@@ -0,0 +1,143 @@
+package plans
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// Storage persists plans and rotation summaries. The Manager always keeps
+// them in the project's FileStorage, where Claude Code and mob.sh see them;
+// a remote Storage set with SetRemote gets a copy of every write, so teams
+// can keep them centrally.
+type Storage interface {
+	// LoadPlan returns the branch's plan, or "" if it has none
+	LoadPlan(branch string) (string, error)
+	SavePlan(branch, content string) error
+
+	// AppendSummary stores a summary of the branch under name, such as
+	// "2024-05-01T10-00-00.json". Saving the same name again replaces it.
+	AppendSummary(branch, name string, data []byte) error
+
+	// ListSummaries returns the names of the branch's summaries, oldest first
+	ListSummaries(branch string) ([]string, error)
+}
+
+// FileStorage is the Storage in a project's .claude directory
+type FileStorage struct {
+	root string
+}
+
+// NewFileStorage returns the Storage of the project at root
+func NewFileStorage(root string) *FileStorage {
+	return &FileStorage{root: root}
+}
+
+// PlanPath returns the path of the branch's plan file
+func (s *FileStorage) PlanPath(branch string) string {
+	filename := fmt.Sprintf("mob-%s.md", platform.SafeFilename(branch))
+	return filepath.Join(s.root, PlansDir, filename)
+}
+
+// summaryDir returns the directory of the branch's summaries
+func (s *FileStorage) summaryDir(branch string) string {
+	return filepath.Join(s.root, SummariesDir, platform.SafeFilename(branch))
+}
+
+// LoadPlan implements Storage
+func (s *FileStorage) LoadPlan(branch string) (string, error) {
+	data, err := os.ReadFile(s.PlanPath(branch))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil // No plan yet
+		}
+		return "", fmt.Errorf("failed to read plan: %w", err)
+	}
+	return string(data), nil
+}
+
+// SavePlan implements Storage
+func (s *FileStorage) SavePlan(branch, content string) error {
+	planPath := s.PlanPath(branch)
+	if err := os.MkdirAll(filepath.Dir(planPath), platform.DirPerm); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(planPath), err)
+	}
+	if err := os.WriteFile(planPath, []byte(content), platform.FilePerm); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+	return nil
+}
+
+// AppendSummary implements Storage
+func (s *FileStorage) AppendSummary(branch, name string, data []byte) error {
+	dir := s.summaryDir(branch)
+	if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name), data, platform.FilePerm)
+}
+
+// createSummary writes a summary file that mustn't exist yet. If it does,
+// the error wraps fs.ErrExist.
+func (s *FileStorage) createSummary(branch, name string, data []byte) error {
+	dir := s.summaryDir(branch)
+	if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, platform.FilePerm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// ListSummaries implements Storage
+func (s *FileStorage) ListSummaries(branch string) ([]string, error) {
+	entries, err := os.ReadDir(s.summaryDir(branch))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	// Names are timestamps, so they sort chronologically
+	sort.Strings(names)
+	return names, nil
+}
+
+// SetRemote makes the Manager copy every plan and summary it saves to
+// remote as well. Copies that fail are reported to onError rather than
+// failing the save, since the local files are the ones in use.
+func (m *Manager) SetRemote(remote Storage, onError func(error)) {
+	m.remote = remote
+	m.remoteError = onError
+}
+
+// Remote returns the Storage set with SetRemote, or nil
+func (m *Manager) Remote() Storage {
+	return m.remote
+}
+
+// copyToRemote runs a write against the remote Storage, if there is one
+func (m *Manager) copyToRemote(write func(Storage) error) {
+	if m.remote == nil {
+		return
+	}
+	if err := write(m.remote); err != nil && m.remoteError != nil {
+		m.remoteError(err)
+	}
+}
@@ -0,0 +1,333 @@
+package plans
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// SummaryIndexFile lists every saved summary. It lives in the summaries
+// directory, next to one subdirectory of summaries per branch.
+const SummaryIndexFile = "index.json"
+
+// SummaryIndexEntry locates one saved summary
+type SummaryIndexEntry struct {
+	// File is the summary's path relative to the summaries directory,
+	// e.g. "feature-auth/2024-05-01T10-00-00-r004.json"
+	File       string    `json:"file"`
+	Branch     string    `json:"branch"`
+	Rotation   int       `json:"rotation,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	DriverName string    `json:"driverName,omitempty"`
+}
+
+// summaryTimeLayout formats the timestamp that starts a summary's file name
+const summaryTimeLayout = "2006-01-02T15-04-05"
+
+// summaryFile is where a summary is stored, relative to the summaries
+// directory, named by its timestamp and, when it has one, its rotation number, e.g.
+// "feature-auth/2024-05-01T10-00-00-r004.json"
+func summaryFile(summary *Summary) string {
+	name := summary.Timestamp.Format(summaryTimeLayout)
+	if summary.Rotation > 0 {
+		name += fmt.Sprintf("-r%03d", summary.Rotation)
+	}
+	return path.Join(platform.SafeFilename(summary.Branch), name+".json")
+}
+
+// ListSummaries returns the paths of all summaries in chronological order
+func (m *Manager) ListSummaries() ([]string, error) {
+	entries, err := m.SummaryIndex()
+	if err != nil {
+		return nil, err
+	}
+	return m.summaryPaths(entries), nil
+}
+
+// ListBranchSummaries returns the paths of a branch's summaries in
+// chronological order
+func (m *Manager) ListBranchSummaries(branch string) ([]string, error) {
+	entries, err := m.SummaryIndex()
+	if err != nil {
+		return nil, err
+	}
+	var matching []SummaryIndexEntry
+	for _, e := range entries {
+		if e.Branch == branch {
+			matching = append(matching, e)
+		}
+	}
+	return m.summaryPaths(matching), nil
+}
+
+// LoadSummary reads and parses a summary file
+func (m *Manager) LoadSummary(path string) (*Summary, error) {
+	data, err := m.readArtifact(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read summary: %w", err)
+	}
+
+	summary := &Summary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, fmt.Errorf("failed to parse summary %s: %w", filepath.Base(path), err)
+	}
+	return summary, nil
+}
+
+// LoadBranchSummaries returns the summaries recorded for a branch in
+// chronological order, skipping files that can't be read
+func (m *Manager) LoadBranchSummaries(branch string) ([]*Summary, error) {
+	files, err := m.ListBranchSummaries(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []*Summary
+	for _, file := range files {
+		if summary, err := m.LoadSummary(file); err == nil {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// GetLatestSummary returns the most recent summary file content on any branch
+func (m *Manager) GetLatestSummary() (string, error) {
+	files, err := m.ListSummaries()
+	if err != nil || len(files) == 0 {
+		return "", err
+	}
+	data, err := m.readArtifact(files[len(files)-1])
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetLatestSummaryForBranch returns the most recent summary file content
+// for a branch, or "" if it has none
+func (m *Manager) GetLatestSummaryForBranch(branch string) (string, error) {
+	files, err := m.ListBranchSummaries(branch)
+	if err != nil || len(files) == 0 {
+		return "", err
+	}
+	data, err := m.readArtifact(files[len(files)-1])
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SummaryIndex returns the summary index, oldest first. An index that is
+// missing or out of step with the files on disk is rebuilt, and summaries
+// still stored flat in the summaries directory (the old layout) are moved
+// into their branch's directory first.
+func (m *Manager) SummaryIndex() ([]SummaryIndexEntry, error) {
+	files, legacy, err := m.summaryFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(legacy) == 0 {
+		entries, err := m.readSummaryIndex()
+		if err == nil && indexMatches(entries, files) {
+			return entries, nil
+		}
+	}
+	return m.rebuildSummaryIndex(append(files, legacy...))
+}
+
+// summaryFiles lists the summary files on disk relative to the summaries
+// directory, separating ones still in the old flat layout
+func (m *Manager) summaryFiles() (files, legacy []string, err error) {
+	dir := m.GetSummariesDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			if entry.Name() != SummaryIndexFile && strings.HasSuffix(entry.Name(), ".json") {
+				legacy = append(legacy, entry.Name())
+			}
+			continue
+		}
+		branchEntries, err := os.ReadDir(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, f := range branchEntries {
+			if !f.IsDir() && strings.HasSuffix(f.Name(), ".json") {
+				files = append(files, path.Join(entry.Name(), f.Name()))
+			}
+		}
+	}
+	return files, legacy, nil
+}
+
+// indexMatches reports whether the index lists exactly the given files
+func indexMatches(entries []SummaryIndexEntry, files []string) bool {
+	if len(entries) != len(files) {
+		return false
+	}
+	indexed := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		indexed[e.File] = true
+	}
+	for _, f := range files {
+		if !indexed[f] {
+			return false
+		}
+	}
+	return true
+}
+
+// rebuildSummaryIndex reads every summary file, moving old flat ones into
+// their branch's directory, and writes a fresh index. A summary that can't
+// be read, such as one encrypted to a key that isn't set, is still indexed
+// from its path, so rotation numbers and pruning take it into account; an
+// unreadable one in the old flat layout can't be placed, and is an error
+// when it's encrypted.
+func (m *Manager) rebuildSummaryIndex(files []string) ([]SummaryIndexEntry, error) {
+	dir := m.GetSummariesDir()
+	var entries []SummaryIndexEntry
+	var unreadable []string
+	branches := make(map[string]string)
+	for _, file := range files {
+		summary, err := m.LoadSummary(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			if strings.Contains(file, "/") {
+				unreadable = append(unreadable, file)
+			} else if errors.Is(err, ErrEncrypted) {
+				return nil, fmt.Errorf("summary %s: %w", file, err)
+			}
+			continue
+		}
+		if !strings.Contains(file, "/") {
+			moved := path.Join(platform.SafeFilename(summary.Branch), file)
+			target := filepath.Join(dir, filepath.FromSlash(moved))
+			if err := os.MkdirAll(filepath.Dir(target), platform.DirPerm); err != nil {
+				return nil, err
+			}
+			if err := os.Rename(filepath.Join(dir, file), target); err != nil {
+				return nil, fmt.Errorf("failed to move summary %s: %w", file, err)
+			}
+			file = moved
+		}
+		branches[path.Dir(file)] = summary.Branch
+		entries = append(entries, indexEntry(file, summary))
+	}
+	for _, file := range unreadable {
+		entries = append(entries, pathIndexEntry(file, branches))
+	}
+
+	sortSummaryIndex(entries)
+	if err := m.writeSummaryIndex(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// pathIndexEntry indexes a summary that can't be read from its path, as
+// summaryFile names it. The branch is the one the directory's other
+// summaries name, or else the directory's name.
+func pathIndexEntry(file string, branches map[string]string) SummaryIndexEntry {
+	entry := SummaryIndexEntry{File: file, Branch: branches[path.Dir(file)]}
+	if entry.Branch == "" {
+		entry.Branch = path.Dir(file)
+	}
+	name := strings.TrimSuffix(path.Base(file), ".json")
+	if len(name) < len(summaryTimeLayout) {
+		return entry
+	}
+	if t, err := time.ParseInLocation(summaryTimeLayout, name[:len(summaryTimeLayout)], time.Local); err == nil {
+		entry.Timestamp = t
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(name[len(summaryTimeLayout):], "-r")); err == nil {
+		entry.Rotation = n
+	}
+	return entry
+}
+
+// indexSummary adds or replaces a saved summary's index entry
+func (m *Manager) indexSummary(file string, summary *Summary) error {
+	entries, err := m.SummaryIndex()
+	if err != nil {
+		return err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.File != file {
+			kept = append(kept, e)
+		}
+	}
+	kept = append(kept, indexEntry(file, summary))
+	sortSummaryIndex(kept)
+	return m.writeSummaryIndex(kept)
+}
+
+func indexEntry(file string, summary *Summary) SummaryIndexEntry {
+	return SummaryIndexEntry{File: file, Branch: summary.Branch, Rotation: summary.Rotation, Timestamp: summary.Timestamp, DriverName: summary.DriverName}
+}
+
+func sortSummaryIndex(entries []SummaryIndexEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].Timestamp.Before(entries[j].Timestamp)
+		}
+		if entries[i].Rotation != entries[j].Rotation {
+			return entries[i].Rotation < entries[j].Rotation
+		}
+		return entries[i].File < entries[j].File
+	})
+}
+
+func (m *Manager) readSummaryIndex() ([]SummaryIndexEntry, error) {
+	data, err := os.ReadFile(filepath.Join(m.GetSummariesDir(), SummaryIndexFile))
+	if err != nil {
+		return nil, err
+	}
+	var entries []SummaryIndexEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (m *Manager) writeSummaryIndex(entries []SummaryIndexEntry) error {
+	if entries == nil {
+		entries = []SummaryIndexEntry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.GetSummariesDir(), platform.DirPerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(m.GetSummariesDir(), SummaryIndexFile), data, platform.FilePerm); err != nil {
+		return fmt.Errorf("failed to write summary index: %w", err)
+	}
+	return nil
+}
+
+// summaryPaths turns index entries into absolute file paths
+func (m *Manager) summaryPaths(entries []SummaryIndexEntry) []string {
+	paths := make([]string, len(entries))
+	for i, e := range entries {
+		paths[i] = filepath.Join(m.GetSummariesDir(), filepath.FromSlash(e.File))
+	}
+	return paths
+}
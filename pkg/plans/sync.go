@@ -0,0 +1,132 @@
+package plans
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// SyncStateFile records the plan hash last synced for each branch
+const SyncStateFile = ".claude/mob/plan-sync.json"
+
+// LocalPlan is a plan file found in the plans directory
+type LocalPlan struct {
+	Branch  string
+	Path    string
+	ModTime time.Time
+}
+
+// PlanHash returns a stable hash of plan text for change detection
+func PlanHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// ContentHash hashes a plan's markdown without its front matter, so
+// metadata such as a new syncedHash doesn't count as an edit
+func ContentHash(plan string) string {
+	if _, body, err := ParseFrontMatter(plan); err == nil {
+		plan = body
+	}
+	return PlanHash(plan)
+}
+
+// LastSyncedHash returns the ContentHash a plan had when it was last synced:
+// the syncedHash in its front matter, else recorded (the hash kept in the
+// sync state file), else ""
+func LastSyncedHash(plan, recorded string) string {
+	if hash := ReadFrontMatter(plan).SyncedHash; hash != "" {
+		return hash
+	}
+	return recorded
+}
+
+// MarkSynced records the plan's current ContentHash as its syncedHash
+func MarkSynced(plan string) (string, error) {
+	hash := ContentHash(plan)
+	return UpdateFrontMatter(plan, func(fm *FrontMatter) { fm.SyncedHash = hash })
+}
+
+// ListPlans returns the local plan files. The branch is read from the
+// plan's front matter, then its "# Mob Session: <branch>" heading, falling
+// back to the file name.
+func (m *Manager) ListPlans() ([]LocalPlan, error) {
+	dir := filepath.Join(m.projectRoot, PlansDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var result []LocalPlan
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "mob-") || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		branch := strings.TrimSuffix(strings.TrimPrefix(name, "mob-"), ".md")
+		if data, err := os.ReadFile(path); err == nil {
+			if named := ReadFrontMatter(string(data)).Branch; named != "" && m.GetPlanPath(named) == path {
+				branch = named
+			} else if heading := planHeadingBranch(string(data)); heading != "" && m.GetPlanPath(heading) == path {
+				branch = heading
+			}
+		}
+		result = append(result, LocalPlan{Branch: branch, Path: path, ModTime: info.ModTime()})
+	}
+	return result, nil
+}
+
+// planHeadingBranch extracts the branch from a default plan heading
+func planHeadingBranch(plan string) string {
+	for _, line := range strings.Split(plan, "\n") {
+		if branch, ok := strings.CutPrefix(strings.TrimSpace(line), "# Mob Session:"); ok {
+			return strings.TrimSpace(branch)
+		}
+	}
+	return ""
+}
+
+// LoadSyncState returns the last synced plan hash per branch
+func (m *Manager) LoadSyncState() (map[string]string, error) {
+	state := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(m.projectRoot, SyncStateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read plan sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse plan sync state: %w", err)
+	}
+	return state, nil
+}
+
+// SaveSyncState writes the last synced plan hash per branch
+func (m *Manager) SaveSyncState(state map[string]string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(m.projectRoot, SyncStateFile)
+	if err := os.MkdirAll(filepath.Dir(path), platform.DirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, platform.FilePerm)
+}
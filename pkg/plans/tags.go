@@ -0,0 +1,99 @@
+package plans
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Untagged groups rotations without tags in TagStats
+const Untagged = "untagged"
+
+// NormalizeTags lowercases and trims tags, dropping empty ones and
+// duplicates while keeping their order
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// HasAnyTag reports whether a rotation's tags include one of want. Every
+// rotation matches when want is empty.
+func HasAnyTag(tags, want []string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, w := range NormalizeTags(want) {
+		for _, tag := range tags {
+			if tag == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TagTime is the time spent on rotations with one tag
+type TagTime struct {
+	Name      string
+	Rotations int
+	Time      time.Duration
+	// Share is the fraction of all rotation time. A rotation with several
+	// tags counts toward each, so shares can add up to more than 1.
+	Share float64
+}
+
+// TagReport is rotation time per tag
+type TagReport struct {
+	Rotations int
+	Total     time.Duration
+	Tags      []TagTime
+}
+
+// TagStats totals rotations and their time by tag, busiest tag first.
+// Rotations without tags are grouped under Untagged.
+func TagStats(summaries []*Summary) TagReport {
+	report := TagReport{Rotations: len(summaries)}
+	byName := make(map[string]*TagTime)
+	for _, s := range summaries {
+		report.Total += s.RotationDuration()
+		tags := s.Tags
+		if len(tags) == 0 {
+			tags = []string{Untagged}
+		}
+		for _, name := range tags {
+			t := byName[name]
+			if t == nil {
+				t = &TagTime{Name: name}
+				byName[name] = t
+			}
+			t.Rotations++
+			t.Time += s.RotationDuration()
+		}
+	}
+
+	for _, t := range byName {
+		if report.Total > 0 {
+			t.Share = float64(t.Time) / float64(report.Total)
+		}
+		report.Tags = append(report.Tags, *t)
+	}
+	sort.Slice(report.Tags, func(i, j int) bool {
+		if report.Tags[i].Time != report.Tags[j].Time {
+			return report.Tags[i].Time > report.Tags[j].Time
+		}
+		if report.Tags[i].Rotations != report.Tags[j].Rotations {
+			return report.Tags[i].Rotations > report.Tags[j].Rotations
+		}
+		return report.Tags[i].Name < report.Tags[j].Name
+	})
+	return report
+}
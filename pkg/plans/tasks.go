@@ -0,0 +1,167 @@
+package plans
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Task is a checkbox item in a plan file
+type Task struct {
+	// Number is the task's 1-based position among all checkbox items in the plan
+	Number    int
+	Text      string
+	Done      bool
+	DependsOn []int
+	// Owner is who the task is assigned to, from a trailing "(@alice)" or
+	// "(owner: Alice Smith)"
+	Owner string
+	line  int
+}
+
+// ownerName is a task owner's name: any number of words of letters, digits,
+// apostrophes, dots, underscores, and hyphens, starting with a letter
+const ownerName = `[\p{L}_][\p{L}\p{N}'’._-]*(?: [\p{L}\p{N}'’._-]+)*`
+
+var (
+	taskPattern       = regexp.MustCompile(`^(\s*[-*]\s+\[)([ xX])(\]\s+)(.*)$`)
+	dependencyPattern = regexp.MustCompile(`(?i)\s*\((?:depends on|after)\s*:?\s*([\d,\s]+)\)\s*$`)
+	// ownerPattern matches a trailing "(@alice)" or "(owner: Seán O'Brien)".
+	// The marker keeps notes such as "(backend)" or "(optional)" from being
+	// read as owners.
+	ownerPattern     = regexp.MustCompile(`(?i)\s*\((?:@|owner\s*:\s*)(` + ownerName + `)\)\s*$`)
+	ownerNamePattern = regexp.MustCompile(`^` + ownerName + `$`)
+)
+
+// ParseTasks extracts checkbox tasks from plan text in document order.
+// A task may declare dependencies with a trailing "(depends on 1, 2)" or
+// "(after 1,2)", and an owner with a trailing "(@alice)" or
+// "(owner: Alice Smith)", in either order.
+func ParseTasks(plan string) []Task {
+	var tasks []Task
+	for i, line := range strings.Split(plan, "\n") {
+		m := taskPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		task := Task{
+			Number: len(tasks) + 1,
+			Text:   m[4],
+			Done:   m[2] != " ",
+			line:   i,
+		}
+		for {
+			if dm := dependencyPattern.FindStringSubmatch(task.Text); dm != nil && task.DependsOn == nil {
+				task.Text = strings.TrimSpace(strings.TrimSuffix(task.Text, dm[0]))
+				task.DependsOn = []int{}
+				for _, field := range strings.Split(dm[1], ",") {
+					if n, err := strconv.Atoi(strings.TrimSpace(field)); err == nil {
+						task.DependsOn = append(task.DependsOn, n)
+					}
+				}
+				continue
+			}
+			if om := ownerPattern.FindStringSubmatch(task.Text); om != nil && task.Owner == "" {
+				task.Text = strings.TrimSpace(strings.TrimSuffix(task.Text, om[0]))
+				task.Owner = om[1]
+				continue
+			}
+			break
+		}
+		if len(task.DependsOn) == 0 {
+			task.DependsOn = nil
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// BlockedBy returns the numbers of the task's dependencies that aren't done yet
+func (t Task) BlockedBy(tasks []Task) []int {
+	var blocked []int
+	for _, dep := range t.DependsOn {
+		if dep < 1 || dep > len(tasks) || !tasks[dep-1].Done {
+			blocked = append(blocked, dep)
+		}
+	}
+	return blocked
+}
+
+// NextUnblockedTask returns the first open task whose dependencies are all done
+func NextUnblockedTask(tasks []Task) *Task {
+	for i := range tasks {
+		if !tasks[i].Done && len(tasks[i].BlockedBy(tasks)) == 0 {
+			return &tasks[i]
+		}
+	}
+	return nil
+}
+
+// SetTaskDone checks or unchecks task number n in the plan text
+func SetTaskDone(plan string, n int, done bool) (string, error) {
+	tasks := ParseTasks(plan)
+	if n < 1 || n > len(tasks) {
+		return "", fmt.Errorf("no task %d (plan has %d tasks)", n, len(tasks))
+	}
+
+	mark := " "
+	if done {
+		mark = "x"
+	}
+	lines := strings.Split(plan, "\n")
+	idx := tasks[n-1].line
+	lines[idx] = taskPattern.ReplaceAllString(lines[idx], "${1}"+mark+"${3}${4}")
+	return strings.Join(lines, "\n"), nil
+}
+
+// OwnedBy reports whether the task is assigned to name: the owner is the
+// name, or its first word, ignoring case. "alice" owns a driver named
+// "Alice Smith"'s tasks.
+func (t Task) OwnedBy(name string) bool {
+	if t.Owner == "" || name == "" {
+		return false
+	}
+	first, _, _ := strings.Cut(name, " ")
+	return strings.EqualFold(t.Owner, name) || strings.EqualFold(t.Owner, first)
+}
+
+// SetTaskOwner assigns task number n in the plan text to owner, replacing
+// any owner it had; "" unassigns it. Dependencies are kept as written.
+func SetTaskOwner(plan string, n int, owner string) (string, error) {
+	tasks := ParseTasks(plan)
+	if n < 1 || n > len(tasks) {
+		return "", fmt.Errorf("no task %d (plan has %d tasks)", n, len(tasks))
+	}
+	if owner != "" && !ownerNamePattern.MatchString(owner) {
+		return "", fmt.Errorf("invalid owner %q: use a name of letters, digits, spaces, and apostrophes", owner)
+	}
+
+	lines := strings.Split(plan, "\n")
+	idx := tasks[n-1].line
+	m := taskPattern.FindStringSubmatch(lines[idx])
+
+	// Peel the annotations off the end, keeping the dependency as written
+	text, deps := m[4], ""
+	for {
+		if dm := dependencyPattern.FindString(text); dm != "" && deps == "" {
+			text, deps = strings.TrimSuffix(text, dm), strings.TrimSpace(dm)
+			continue
+		}
+		if om := ownerPattern.FindString(text); om != "" {
+			text = strings.TrimSuffix(text, om)
+			continue
+		}
+		break
+	}
+	text = strings.TrimSpace(text)
+	if owner != "" {
+		text += " (@" + owner + ")"
+	}
+	if deps != "" {
+		text += " " + deps
+	}
+	lines[idx] = m[1] + m[2] + m[3] + text
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,39 @@
+package plans
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// ticketKeyPattern matches issue keys such as PROJ-123 (Jira, Linear, YouTrack)
+	ticketKeyPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])([a-z][a-z0-9]+-[0-9]+)(?:$|[^0-9])`)
+
+	// issueNumberPattern matches a leading issue number such as 123-fix-login
+	// or gh-123, as GitHub and GitLab name branches created from issues
+	issueNumberPattern = regexp.MustCompile(`(?i)^(?:gh-|issue-)?([0-9]+)(?:$|[-_])`)
+)
+
+// branchWords are common branch name words that look like a ticket key
+// when followed by a number, as in release-2 or fix-3
+var branchWords = map[string]bool{
+	"feature": true, "feat": true, "fix": true, "bugfix": true, "hotfix": true,
+	"release": true, "chore": true, "wip": true, "mob": true, "v": true,
+}
+
+// TicketFromBranch returns the ticket a branch name refers to, such as
+// "PROJ-123" for feature/proj-123-login or "#42" for 42-fix-crash, or ""
+// if it names none
+func TicketFromBranch(branch string) string {
+	name := branch[strings.LastIndex(branch, "/")+1:]
+	if m := issueNumberPattern.FindStringSubmatch(name); m != nil {
+		return "#" + m[1]
+	}
+	if m := ticketKeyPattern.FindStringSubmatch(name); m != nil {
+		prefix := strings.ToLower(m[1][:strings.Index(m[1], "-")])
+		if !branchWords[prefix] {
+			return strings.ToUpper(m[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+package summary
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// APIKeyEnv is the environment variable holding the Anthropic API key used
+// when the claude CLI isn't installed
+const APIKeyEnv = "ANTHROPIC_API_KEY"
+
+// APIBaseURLEnv overrides the Anthropic API's address, e.g. for a gateway
+const APIBaseURLEnv = "ANTHROPIC_BASE_URL"
+
+// DefaultAPIBaseURL is where Messages API requests go by default
+const DefaultAPIBaseURL = "https://api.anthropic.com"
+
+// APIVersion is the Messages API version requests are made against
+const APIVersion = "2023-06-01"
+
+// DefaultAPIMaxTokens caps API responses when claude.maxOutputTokens isn't
+// set. It leaves room for a whole plan, the longest thing Claude returns.
+const DefaultAPIMaxTokens = 4096
+
+// APITimeout bounds each Messages API request
+const APITimeout = 2 * time.Minute
+
+// apiModels maps the model aliases the CLI accepts to API model names.
+// Any other model is passed through as is.
+var apiModels = map[string]string{
+	"haiku":  "claude-haiku-4-5",
+	"sonnet": "claude-sonnet-4-5",
+	"opus":   "claude-opus-4-1",
+}
+
+// apiAvailable reports whether an API key is set for calling Claude
+// without the CLI
+func apiAvailable() bool {
+	return os.Getenv(APIKeyEnv) != ""
+}
+
+// apiModel returns the API model name for a configured model
+func apiModel(model string) string {
+	if name, ok := apiModels[model]; ok {
+		return name
+	}
+	return model
+}
+
+type apiMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type apiRequest struct {
+	Model     string       `json:"model"`
+	MaxTokens int          `json:"max_tokens"`
+	System    string       `json:"system,omitempty"`
+	Messages  []apiMessage `json:"messages"`
+}
+
+type apiResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+}
+
+type apiError struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// callAPI sends the prompt to the Messages API and returns the reply's
+// text. It honors the model, system prompt, and output limit; the
+// CLI-only options (max turns, allowed tools, extra args) don't apply.
+func (g *Generator) callAPI(prompt string) (string, error) {
+	maxTokens := g.options.MaxOutputTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultAPIMaxTokens
+	}
+	body, err := json.Marshal(apiRequest{
+		Model:     apiModel(g.model),
+		MaxTokens: maxTokens,
+		System:    g.options.SystemPrompt,
+		Messages:  []apiMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	baseURL := os.Getenv(APIBaseURLEnv)
+	if baseURL == "" {
+		baseURL = DefaultAPIBaseURL
+	}
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "mob-claude")
+	req.Header.Set("x-api-key", os.Getenv(APIKeyEnv))
+	req.Header.Set("anthropic-version", APIVersion)
+
+	client := &http.Client{Timeout: APITimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("anthropic API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read anthropic API response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+			return "", fmt.Errorf("anthropic API returned %d: %s", resp.StatusCode, apiErr.Error.Message)
+		}
+		return "", fmt.Errorf("anthropic API returned %d", resp.StatusCode)
+	}
+
+	var result apiResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("failed to parse anthropic API response: %w", err)
+	}
+	var text strings.Builder
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return "", fmt.Errorf("anthropic API returned no text (stop reason: %s)", result.StopReason)
+	}
+	return text.String(), nil
+}
@@ -0,0 +1,153 @@
+package summary
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// MinCLIVersion is the oldest claude CLI mob-claude works with: the first
+// stable release, which has -p and --model
+const MinCLIVersion = "1.0.0"
+
+// hiddenFlagsSince are flags the CLI leaves out of --help, with the
+// version that has them
+var hiddenFlagsSince = map[string]string{
+	"--max-turns": "1.0.0",
+}
+
+// CLIInfo is what the installed claude CLI supports
+type CLIInfo struct {
+	Path string
+
+	// Version is the CLI's version, e.g. "1.0.43", or "" if it couldn't
+	// be told
+	Version string
+
+	// OutputFormats are the --output-format values the CLI lists; nil if
+	// it has no --output-format
+	OutputFormats []string
+
+	MaxTurns           bool
+	AppendSystemPrompt bool
+	AllowedTools       bool
+	Resume             bool
+	SessionID          bool
+}
+
+// SupportsOutputFormat reports whether --output-format accepts format
+func (c *CLIInfo) SupportsOutputFormat(format string) bool {
+	for _, f := range c.OutputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	versionPattern = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+	// outputFormatLine finds the --output-format entry in --help and the
+	// quoted values it lists
+	outputFormatLine = regexp.MustCompile(`--output-format[^\n]*`)
+	quotedWord       = regexp.MustCompile(`"([a-z-]+)"`)
+)
+
+// ErrCLINotFound is returned when the claude CLI isn't installed
+var ErrCLINotFound = errors.New("claude CLI not found. Install from: https://claude.ai/code")
+
+var (
+	detectOnce sync.Once
+	detected   *CLIInfo
+	detectErr  error
+)
+
+// DetectCLI finds the claude CLI and works out what it supports from its
+// --version and --help. It's detected once per run. A CLI older than
+// MinCLIVersion is an error that says so.
+func DetectCLI() (*CLIInfo, error) {
+	detectOnce.Do(func() {
+		detected, detectErr = detectCLI()
+	})
+	return detected, detectErr
+}
+
+func detectCLI() (*CLIInfo, error) {
+	claudePath, err := platform.FindExecutable("claude")
+	if err != nil {
+		return nil, ErrCLINotFound
+	}
+
+	out, err := exec.Command(claudePath, "--version").Output()
+	if err != nil {
+		return nil, fmt.Errorf("claude CLI found but not working: %w", err)
+	}
+	info := &CLIInfo{Path: claudePath, Version: versionPattern.FindString(string(out))}
+	if info.Version != "" && compareVersions(info.Version, MinCLIVersion) < 0 {
+		return nil, fmt.Errorf("claude CLI %s is too old: mob-claude needs %s or later. Update it with 'claude update'", info.Version, MinCLIVersion)
+	}
+
+	// Without readable help, assume a current CLI rather than turn
+	// everything off
+	help, err := exec.Command(claudePath, "--help").Output()
+	if err != nil || !strings.Contains(string(help), "--") {
+		info.OutputFormats = []string{"text", "json", "stream-json"}
+		info.MaxTurns, info.AppendSystemPrompt, info.AllowedTools, info.Resume, info.SessionID = true, true, true, true, true
+		return info, nil
+	}
+	info.parseHelp(string(help))
+	return info, nil
+}
+
+// parseHelp reads the supported flags from the CLI's --help
+func (c *CLIInfo) parseHelp(help string) {
+	has := func(flag string) bool {
+		if strings.Contains(help, flag) {
+			return true
+		}
+		since, hidden := hiddenFlagsSince[flag]
+		return hidden && c.Version != "" && compareVersions(c.Version, since) >= 0
+	}
+	c.MaxTurns = has("--max-turns")
+	c.AppendSystemPrompt = has("--append-system-prompt")
+	c.AllowedTools = has("--allowedTools") || has("--allowed-tools")
+	c.Resume = has("--resume")
+	c.SessionID = has("--session-id")
+
+	if line := outputFormatLine.FindString(help); line != "" {
+		for _, m := range quotedWord.FindAllStringSubmatch(line, -1) {
+			c.OutputFormats = append(c.OutputFormats, m[1])
+		}
+		if len(c.OutputFormats) == 0 {
+			c.OutputFormats = []string{"text"}
+		}
+	}
+}
+
+// compareVersions compares dotted version numbers, returning -1, 0, or 1
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
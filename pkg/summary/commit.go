@@ -0,0 +1,105 @@
+package summary
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// conventionalSubject matches a conventional-commit subject line
+var conventionalSubject = regexp.MustCompile(`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([^)]+\))?!?: \S`)
+
+// maxSubjectLen is the longest subject line accepted from Claude
+const maxSubjectLen = 72
+
+// CommitMessage writes a conventional-commit message for the squashed
+// session from its rotation summaries. Output that isn't a valid message is
+// sent back to Claude, up to the retry limit; after that, or if Claude
+// fails, DefaultCommitMessage is used.
+func (g *Generator) CommitMessage(branch string, summaries []*plans.Summary) string {
+	basePrompt := commitPrompt(branch, summaries)
+	prompt := basePrompt
+
+	for attempt := 0; ; attempt++ {
+		result, err := g.callClaude(prompt)
+		if err != nil {
+			return DefaultCommitMessage(branch, summaries)
+		}
+
+		message := strings.Trim(strings.TrimSpace(result), "`")
+		message = strings.TrimSpace(message)
+		problems := validateCommitMessage(message)
+		if len(problems) == 0 {
+			return message
+		}
+		if attempt >= g.retries {
+			return DefaultCommitMessage(branch, summaries)
+		}
+		prompt = correctivePrompt(basePrompt, result, problems)
+	}
+}
+
+func commitPrompt(branch string, summaries []*plans.Summary) string {
+	var b strings.Builder
+	for _, s := range summaries {
+		fmt.Fprintf(&b, "Rotation by %s: %s\n", s.DriverName, s.TLDR)
+		for _, change := range s.Changes {
+			fmt.Fprintf(&b, "- %s\n", change)
+		}
+	}
+
+	return fmt.Sprintf(`Write the commit message for squashing a mob programming session on branch %q
+into a single commit. These are the summaries of its rotations, oldest first:
+
+%s
+Format:
+- First line: a conventional commit subject, e.g. "feat(auth): add OAuth login" (max %d chars)
+- A blank line
+- A bullet list ("- ") of the notable changes, merged and deduplicated across rotations
+
+Respond ONLY with the commit message, no markdown fences or explanation.`, branch, b.String(), maxSubjectLen)
+}
+
+// validateCommitMessage checks a generated message's shape
+func validateCommitMessage(message string) []string {
+	lines := strings.Split(message, "\n")
+	subject := lines[0]
+
+	var problems []string
+	if !conventionalSubject.MatchString(subject) {
+		problems = append(problems, `the first line must be a conventional commit subject like "feat(scope): summary"`)
+	}
+	if len(subject) > maxSubjectLen {
+		problems = append(problems, fmt.Sprintf("the subject line must be at most %d characters (got %d)", maxSubjectLen, len(subject)))
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		problems = append(problems, "the subject must be followed by a blank line")
+	}
+	return problems
+}
+
+// DefaultCommitMessage builds a squash commit message from the summaries
+// without Claude: a feat subject named after the branch and every change
+// listed once
+func DefaultCommitMessage(branch string, summaries []*plans.Summary) string {
+	subject := strings.NewReplacer("-", " ", "_", " ").Replace(branch)
+	lines := []string{"feat: " + subject}
+
+	seen := make(map[string]bool)
+	var changes []string
+	for _, s := range summaries {
+		for _, change := range s.Changes {
+			if change = strings.TrimSpace(change); change != "" && !seen[change] {
+				seen[change] = true
+				changes = append(changes, "- "+change)
+			}
+		}
+	}
+	if len(changes) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, changes...)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,34 @@
+package summary
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxConflictLen caps how much conflict text is sent to Claude
+const maxConflictLen = 12000
+
+// ExplainConflicts asks Claude to explain merge conflicts (as rendered by
+// mob.FormatConflicts) and suggest an order for resolving them. The hunks
+// are redacted like any diff, since both sides can quote secrets.
+func (g *Generator) ExplainConflicts(conflicts string) (string, error) {
+	conflicts = g.redact(conflicts)
+	if len(conflicts) > maxConflictLen {
+		conflicts = conflicts[:maxConflictLen] + "\n... (truncated)"
+	}
+
+	prompt := fmt.Sprintf(`A mob programming handoff hit merge conflicts on the shared wip branch.
+For each file, "ours" is between <<<<<<< and =======, "theirs" between ======= and >>>>>>>.
+
+%s
+
+In plain text (no markdown headings, at most 12 lines):
+1. Briefly explain what each side of the conflicts was trying to do.
+2. Suggest an order to resolve the files in, and how to resolve each one.`, conflicts)
+
+	response, err := g.callClaude(prompt)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(response), nil
+}
@@ -0,0 +1,66 @@
+package summary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/internal/platform"
+)
+
+// Fixture is a recorded rotation used to evaluate summary prompts. It is
+// stored as <name>.diff with an optional <name>.note holding the driver note.
+type Fixture struct {
+	Name       string
+	Diff       string
+	DriverNote string
+}
+
+// LoadFixtures reads every fixture in dir, sorted by name
+func LoadFixtures(dir string) ([]Fixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var fixtures []Fixture
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".diff") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".diff")
+		diff, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture %s: %w", name, err)
+		}
+		note, _ := os.ReadFile(filepath.Join(dir, name+".note"))
+		fixtures = append(fixtures, Fixture{
+			Name:       name,
+			Diff:       string(diff),
+			DriverNote: strings.TrimSpace(string(note)),
+		})
+	}
+	sort.Slice(fixtures, func(i, j int) bool { return fixtures[i].Name < fixtures[j].Name })
+	return fixtures, nil
+}
+
+// SaveFixture writes a fixture into dir
+func SaveFixture(dir string, f Fixture) error {
+	if f.Name == "" || strings.ContainsAny(f.Name, `/\`) {
+		return fmt.Errorf("invalid fixture name: %q", f.Name)
+	}
+	if err := os.MkdirAll(dir, platform.DirPerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, f.Name+".diff"), []byte(f.Diff), platform.FilePerm); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	if f.DriverNote != "" {
+		if err := os.WriteFile(filepath.Join(dir, f.Name+".note"), []byte(f.DriverNote+"\n"), platform.FilePerm); err != nil {
+			return fmt.Errorf("failed to write fixture: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,517 @@
+// Package summary generates rotation summaries, commit messages, and plan
+// updates with Claude, through the claude CLI or, without one, the
+// Anthropic API. Summaries follow the JSON Schema returned by JSONSchema.
+package summary
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mob-claude/mob-claude/internal/claudecode"
+	"github.com/mob-claude/mob-claude/pkg/clock"
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// MaxOutputTokensEnv is the environment variable the claude CLI reads its
+// response token limit from
+const MaxOutputTokensEnv = "CLAUDE_CODE_MAX_OUTPUT_TOKENS"
+
+// DefaultRetries is how many times a summary that fails validation is
+// regenerated before falling back to a basic summary
+const DefaultRetries = 2
+
+// DefaultContextSummaries is how many of the branch's previous summaries
+// Claude sees, so a new summary doesn't repeat what they reported
+const DefaultContextSummaries = 3
+
+// Generator handles AI-powered summary generation using Claude CLI, or
+// the Anthropic API when only an API key is available
+type Generator struct {
+	model    string
+	maxTurns int
+	retries  int
+	clock    clock.Clock
+	handoff  string
+	prompt   string
+	language string
+	activity string
+	options  CLIOptions
+	previous []*plans.Summary
+	navNotes []string
+
+	// redactor scrubs secrets from diffs before they're sent, and
+	// onRedact is told what it found
+	redactor *Redactor
+	onRedact func([]Redaction)
+
+	// paths leaves files out of the diffs sent to Claude
+	paths *PathFilter
+
+	// onGenerate is told how long each Generate call took
+	onGenerate func(elapsed time.Duration, fallback bool)
+}
+
+// NewGenerator creates a new summary generator
+func NewGenerator(model string, maxTurns int) *Generator {
+	return &Generator{
+		model:    model,
+		maxTurns: maxTurns,
+		retries:  DefaultRetries,
+		clock:    clock.System,
+	}
+}
+
+// SetRetries sets how many corrective retries a summary that fails
+// validation gets
+func (g *Generator) SetRetries(n int) {
+	g.retries = n
+}
+
+// SetClock overrides the clock used to timestamp summaries
+func (g *Generator) SetClock(c clock.Clock) {
+	g.clock = c
+}
+
+// SetPromptTemplate replaces the built-in summary prompt. The template may
+// use {{driverNote}}, {{navigator}}, {{handoff}}, {{previous}},
+// {{activity}}, {{diff}}, {{language}}, and {{schema}} (the JSON Schema of
+// the expected reply) placeholders.
+func (g *Generator) SetPromptTemplate(template string) {
+	g.prompt = template
+}
+
+// SetLanguage asks Claude to write summaries in a language, given as an
+// English name like "German"; "" leaves the choice to Claude
+func (g *Generator) SetLanguage(language string) {
+	g.language = language
+}
+
+// SetActivity gives Claude the rotation's activity log (commits, saves, and
+// test runs, as rendered by activity.Format) so the summary can reflect how
+// the work went, not just where it ended
+func (g *Generator) SetActivity(log string) {
+	g.activity = log
+}
+
+// SetGenerateHook calls hook after every Generate with how long it took and
+// whether it fell back to the basic summary
+func (g *Generator) SetGenerateHook(hook func(elapsed time.Duration, fallback bool)) {
+	g.onGenerate = hook
+}
+
+// SetHandoff gives Claude the handoff brief the driver started from, so the
+// summary can pick up where the previous rotation left off
+func (g *Generator) SetHandoff(brief string) {
+	g.handoff = brief
+}
+
+// SetPreviousSummaries gives Claude the branch's earlier summaries, oldest
+// first. The diff covers the whole branch, so without them each summary
+// would report the earlier rotations' changes again.
+func (g *Generator) SetPreviousSummaries(summaries []*plans.Summary) {
+	g.previous = summaries
+}
+
+// SetNavigatorNotes gives Claude the navigators' observations from the
+// rotation. They're also kept on the summaries Generate returns.
+func (g *Generator) SetNavigatorNotes(notes []string) {
+	g.navNotes = notes
+}
+
+// SetRedactor has every diff scrubbed of secrets before it's sent to
+// Claude. onRedact, if set, is told what was replaced whenever anything was.
+func (g *Generator) SetRedactor(r *Redactor, onRedact func([]Redaction)) {
+	g.redactor = r
+	g.onRedact = onRedact
+}
+
+// SetPathFilter leaves the changes to files the filter doesn't keep out of
+// every diff sent to Claude, so generated and vendored files don't crowd
+// out the source changes within the size limit
+func (g *Generator) SetPathFilter(f *PathFilter) {
+	g.paths = f
+}
+
+// prepareDiff filters and redacts a diff before it's sent to Claude
+func (g *Generator) prepareDiff(diff string) string {
+	if g.paths != nil {
+		diff = g.paths.Filter(diff)
+	}
+	return g.redact(diff)
+}
+
+// redact scrubs secrets from a diff if a redactor is set
+func (g *Generator) redact(diff string) string {
+	if g.redactor == nil {
+		return diff
+	}
+	diff, redactions := g.redactor.Redact(diff)
+	if len(redactions) > 0 && g.onRedact != nil {
+		g.onRedact(redactions)
+	}
+	return diff
+}
+
+// CLIOptions are extra generation controls passed to the claude CLI
+type CLIOptions struct {
+	// SystemPrompt is appended to Claude Code's system prompt
+	SystemPrompt string
+
+	// AllowedTools are the tools Claude may use without asking
+	AllowedTools []string
+
+	// MaxOutputTokens caps each response; 0 uses the CLI's default
+	MaxOutputTokens int
+
+	// ExtraArgs are added to every invocation
+	ExtraArgs []string
+}
+
+// SetCLIOptions sets extra controls for every claude invocation
+func (g *Generator) SetCLIOptions(opts CLIOptions) {
+	g.options = opts
+}
+
+// GeneratedSummary is the structured output from Claude
+type GeneratedSummary struct {
+	TLDR      string   `json:"tldr"`
+	Changes   []string `json:"changes"`
+	NextSteps []string `json:"nextSteps"`
+}
+
+// Validate checks that a generated summary is usable: a TL;DR, 2-4 changes,
+// and 1-3 next steps concrete enough to act on. It returns the problems found.
+func (s *GeneratedSummary) Validate() []string {
+	var problems []string
+	if strings.TrimSpace(s.TLDR) == "" {
+		problems = append(problems, "tldr is empty")
+	} else if utf8.RuneCountInString(s.TLDR) > 100 {
+		problems = append(problems, "tldr is longer than 100 characters")
+	}
+	if n := len(nonEmpty(s.Changes)); n < 2 || n > 4 {
+		problems = append(problems, fmt.Sprintf("changes has %d entries, expected 2-4", n))
+	}
+	steps := nonEmpty(s.NextSteps)
+	if n := len(steps); n < 1 || n > 3 {
+		problems = append(problems, fmt.Sprintf("nextSteps has %d entries, expected 1-3", n))
+	}
+	for _, step := range steps {
+		if isVague(step) {
+			problems = append(problems, fmt.Sprintf("next step %q is too vague to act on", step))
+		}
+	}
+	return problems
+}
+
+// isVague reports whether a next step is too short to act on: fewer than
+// three words, or for scripts written without spaces, fewer than six characters
+func isVague(step string) bool {
+	for _, r := range step {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+			return utf8.RuneCountInString(strings.TrimSpace(step)) < 6
+		}
+	}
+	return len(strings.Fields(step)) < 3
+}
+
+func nonEmpty(items []string) []string {
+	var result []string
+	for _, item := range items {
+		if strings.TrimSpace(item) != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Generate creates a summary using Claude CLI. Output that fails validation
+// is sent back to Claude with the problems listed, up to the retry limit.
+func (g *Generator) Generate(diff string, driverNote string, branch string) (*plans.Summary, error) {
+	started := time.Now()
+	finish := func(summary *plans.Summary, fallback bool) (*plans.Summary, error) {
+		if g.onGenerate != nil {
+			g.onGenerate(time.Since(started), fallback)
+		}
+		return summary, nil
+	}
+
+	basePrompt := g.buildPrompt(diff, driverNote)
+	prompt := basePrompt
+
+	var generated *GeneratedSummary
+	for attempt := 0; ; attempt++ {
+		// Call Claude CLI with structured output
+		result, err := g.callClaude(prompt)
+		if err != nil {
+			// Return a basic summary if Claude fails
+			return finish(g.fallbackSummary(driverNote, branch), true)
+		}
+
+		// Parse and check the structured output
+		var problems []string
+		generated, problems = g.checkResponse(result)
+		if len(problems) == 0 {
+			break
+		}
+		if attempt >= g.retries {
+			return finish(g.fallbackSummary(driverNote, branch), true)
+		}
+		prompt = correctivePrompt(basePrompt, result, problems)
+	}
+
+	return finish(&plans.Summary{
+		Timestamp:  g.clock.Now(),
+		DriverName: "", // Will be set by caller
+		DriverNote: driverNote,
+		TLDR:       generated.TLDR,
+		Changes:    generated.Changes,
+		NextSteps:  generated.NextSteps,
+		Branch:     branch,
+		Covered:    plans.CoveredDigest(generated.Changes),
+
+		NavigatorNotes: g.navNotes,
+	}, false)
+}
+
+func (g *Generator) buildPrompt(diff string, driverNote string) string {
+	diff = g.prepareDiff(diff)
+
+	// Truncate diff if too long
+	maxDiffLen := 10000
+	if len(diff) > maxDiffLen {
+		diff = diff[:maxDiffLen] + "\n... (truncated)"
+	}
+
+	handoff := ""
+	if g.handoff != "" {
+		handoff = fmt.Sprintf("\nHandoff brief the driver started from:\n%s\n", g.handoff)
+	}
+
+	previous := ""
+	if len(g.previous) > 0 {
+		var b strings.Builder
+		b.WriteString("\nAlready reported by earlier rotations (the diff still contains these; don't repeat them, report only what changed since):\n")
+		for _, s := range g.previous {
+			fmt.Fprintf(&b, "- %s, %s: %s\n", s.DriverName, s.Timestamp.Format("2006-01-02 15:04"), s.TLDR)
+			for _, change := range s.CoveredChanges() {
+				fmt.Fprintf(&b, "  - %s\n", change)
+			}
+		}
+		previous = b.String()
+	}
+
+	navigator := ""
+	if len(g.navNotes) > 0 {
+		navigator = fmt.Sprintf("\nNavigator observations during the rotation:\n- %s\n", strings.Join(g.navNotes, "\n- "))
+	}
+
+	activity := ""
+	if g.activity != "" {
+		activity = fmt.Sprintf("\nActivity during the rotation:\n%s", g.activity)
+	}
+
+	language := ""
+	if g.language != "" {
+		language = fmt.Sprintf("Write the tldr, changes, and nextSteps in %s; keep the JSON keys in English.", g.language)
+	}
+
+	if g.prompt != "" {
+		return strings.NewReplacer(
+			"{{driverNote}}", driverNote,
+			"{{navigator}}", navigator,
+			"{{handoff}}", handoff,
+			"{{previous}}", previous,
+			"{{activity}}", activity,
+			"{{diff}}", diff,
+			"{{language}}", language,
+			"{{schema}}", JSONSchema(),
+		).Replace(g.prompt)
+	}
+
+	return fmt.Sprintf(`Analyze this git diff from a mob programming rotation and create a brief summary.
+
+Driver's note: %s
+%s%s%s%s
+Git diff:
+%s
+
+Return a JSON object matching this JSON Schema:
+%s
+%s
+Respond ONLY with valid JSON, no markdown or explanation.`, driverNote, navigator, handoff, previous, activity, diff, JSONSchema(), language)
+}
+
+// Evaluate runs a single generation attempt, without retries or fallback,
+// and returns Claude's parsed output along with its validation problems
+func (g *Generator) Evaluate(diff, driverNote string) (*GeneratedSummary, []string, error) {
+	result, err := g.callClaude(g.buildPrompt(diff, driverNote))
+	if err != nil {
+		return nil, nil, err
+	}
+	generated, problems := g.checkResponse(result)
+	return generated, problems, nil
+}
+
+// correctivePrompt asks Claude to fix a response that failed validation
+func correctivePrompt(basePrompt, response string, problems []string) string {
+	return fmt.Sprintf(`%s
+
+Your previous response was:
+%s
+
+It had these problems:
+- %s
+
+Fix them and respond again with ONLY the corrected JSON object.`, basePrompt, response, strings.Join(problems, "\n- "))
+}
+
+func (g *Generator) callClaude(prompt string) (string, error) {
+	// Find the CLI and what it supports, so an old one fails here with a
+	// clear message rather than with its own usage error
+	cli, err := DetectCLI()
+	if errors.Is(err, ErrCLINotFound) && apiAvailable() {
+		// Without the CLI, an API key still gets Claude's answer
+		return g.callAPI(prompt)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// A CLI without --append-system-prompt gets the system prompt as part
+	// of the prompt instead
+	if g.options.SystemPrompt != "" && !cli.AppendSystemPrompt {
+		prompt = g.options.SystemPrompt + "\n\n" + prompt
+	}
+
+	args := []string{
+		"-p", prompt,
+		"--model", g.model,
+	}
+	if cli.MaxTurns {
+		args = append(args, "--max-turns", fmt.Sprintf("%d", g.maxTurns))
+	}
+	if cli.SupportsOutputFormat("text") {
+		args = append(args, "--output-format", "text")
+	}
+	if g.options.SystemPrompt != "" && cli.AppendSystemPrompt {
+		args = append(args, "--append-system-prompt", g.options.SystemPrompt)
+	}
+	if len(g.options.AllowedTools) > 0 && cli.AllowedTools {
+		args = append(args, "--allowedTools", strings.Join(g.options.AllowedTools, ","))
+	}
+	// Tag the call's transcript as mob-claude's own, so it isn't taken
+	// for the driver's conversation
+	if cli.SessionID {
+		if id, err := claudecode.NewOwnSession(); err == nil {
+			args = append(args, "--session-id", id)
+		}
+	}
+	args = append(args, g.options.ExtraArgs...)
+
+	cmd := exec.Command(cli.Path, args...)
+	cmd.Env = append(os.Environ(), claudecode.InternalEnv+"=1")
+	if g.options.MaxOutputTokens > 0 {
+		// The CLI takes the output limit from the environment, not a flag
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%d", MaxOutputTokensEnv, g.options.MaxOutputTokens))
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("claude CLI failed: %w\n%s", err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// checkResponse parses Claude's response, checks it against the JSON
+// Schema, and then against the quality rules of Validate. It returns the
+// problems found, along with the summary whenever it could be decoded.
+func (g *Generator) checkResponse(response string) (*GeneratedSummary, []string) {
+	raw, err := extractJSON(response)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	problems := ValidateSchema([]byte(raw))
+
+	var summary GeneratedSummary
+	if err := json.Unmarshal([]byte(raw), &summary); err != nil {
+		if len(problems) == 0 {
+			problems = []string{fmt.Sprintf("failed to parse JSON: %v", err)}
+		}
+		return nil, problems
+	}
+	if len(problems) == 0 {
+		problems = summary.Validate()
+	}
+	return &summary, problems
+}
+
+// extractJSON finds the JSON object in Claude's response, which may be
+// wrapped in a markdown code block or surrounded by text
+func extractJSON(response string) (string, error) {
+	// Try to extract JSON from the response
+	response = strings.TrimSpace(response)
+
+	// Handle markdown code blocks
+	if strings.HasPrefix(response, "```json") {
+		response = strings.TrimPrefix(response, "```json")
+		response = strings.TrimSuffix(response, "```")
+		response = strings.TrimSpace(response)
+	} else if strings.HasPrefix(response, "```") {
+		response = strings.TrimPrefix(response, "```")
+		response = strings.TrimSuffix(response, "```")
+		response = strings.TrimSpace(response)
+	}
+
+	// Find JSON object in response
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start == -1 || end == -1 || end <= start {
+		return "", fmt.Errorf("no JSON object found in response")
+	}
+	return response[start : end+1], nil
+}
+
+func (g *Generator) fallbackSummary(driverNote string, branch string) *plans.Summary {
+	tldr := "Rotation completed"
+	if driverNote != "" {
+		// Use driver note as TLDR if provided
+		if len(driverNote) > 100 {
+			tldr = driverNote[:97] + "..."
+		} else {
+			tldr = driverNote
+		}
+	}
+
+	return &plans.Summary{
+		Timestamp:  g.clock.Now(),
+		DriverNote: driverNote,
+		TLDR:       tldr,
+		Changes:    []string{"Changes made during rotation"},
+		NextSteps:  []string{"Continue from where the previous driver left off"},
+		Branch:     branch,
+
+		NavigatorNotes: g.navNotes,
+	}
+}
+
+// CheckClaudeAvailable verifies that the Claude CLI is installed, working,
+// and recent enough, or, with no CLI installed, that an API key is set
+func CheckClaudeAvailable() error {
+	_, err := DetectCLI()
+	if errors.Is(err, ErrCLINotFound) && apiAvailable() {
+		return nil
+	}
+	return err
+}
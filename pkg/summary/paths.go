@@ -0,0 +1,122 @@
+package summary
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxOmittedListed caps how many left-out files are named in the prompt
+const maxOmittedListed = 10
+
+// PathFilter decides which files' changes are sent to Claude. Patterns are
+// globs: * and ? stay within a path segment, ** spans any number of them,
+// and a pattern without a slash matches at any depth, like in .gitignore.
+// A pattern naming a directory covers everything below it.
+type PathFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// NewPathFilter creates a filter keeping files that match an include
+// pattern, or every file without any, unless they match an exclude pattern
+func NewPathFilter(include, exclude []string) *PathFilter {
+	f := &PathFilter{}
+	for _, pattern := range include {
+		f.include = append(f.include, globRegexp(pattern))
+	}
+	for _, pattern := range exclude {
+		f.exclude = append(f.exclude, globRegexp(pattern))
+	}
+	return f
+}
+
+// Keep reports whether changes to file, a slash-separated path relative to
+// the repository root, are sent to Claude
+func (f *PathFilter) Keep(file string) bool {
+	if len(f.include) > 0 && !matchesAny(f.include, file) {
+		return false
+	}
+	return !matchesAny(f.exclude, file)
+}
+
+func matchesAny(patterns []*regexp.Regexp, file string) bool {
+	for _, re := range patterns {
+		if re.MatchString(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// globRegexp translates a glob to an anchored regexp
+func globRegexp(glob string) *regexp.Regexp {
+	glob = strings.Trim(strings.TrimSpace(glob), "/")
+	var b strings.Builder
+	b.WriteString("^")
+	if !strings.Contains(glob, "/") {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case c == '*' && strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+	return regexp.MustCompile(b.String())
+}
+
+// Filter drops the sections of a unified diff for files the filter doesn't
+// keep, and notes which were left out at the end
+func (f *PathFilter) Filter(diff string) string {
+	var kept strings.Builder
+	var omitted []string
+	keep := true
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			file := diffPath(line)
+			keep = file == "" || f.Keep(file)
+			if !keep {
+				omitted = append(omitted, file)
+			}
+		}
+		if keep {
+			kept.WriteString(line)
+		}
+	}
+	if len(omitted) == 0 {
+		return diff
+	}
+
+	listed := omitted
+	if len(listed) > maxOmittedListed {
+		listed = listed[:maxOmittedListed]
+	}
+	fmt.Fprintf(&kept, "\n(Changes to %d files left out by path filters: %s", len(omitted), strings.Join(listed, ", "))
+	if len(omitted) > len(listed) {
+		fmt.Fprintf(&kept, ", and %d more", len(omitted)-len(listed))
+	}
+	kept.WriteString(")\n")
+	return kept.String()
+}
+
+// diffPath is the file a "diff --git a/<path> b/<path>" header is for, or
+// "" if it can't be told
+func diffPath(header string) string {
+	rest := strings.TrimPrefix(strings.TrimRight(header, "\n"), "diff --git ")
+	if i := strings.LastIndex(rest, " b/"); i >= 0 {
+		return rest[i+len(" b/"):]
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package summary
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// maxPlanDiffLen caps how much of the diff is sent with a plan update
+const maxPlanDiffLen = 10000
+
+// UpdatePlan asks Claude to bring a plan up to date with the branch's diff
+// and, if there is one, the latest rotation summary: ticking off finished
+// tasks, adding tasks the work turned up, and recording decisions. The
+// plan's front matter is kept as is; only the markdown body is rewritten.
+func (g *Generator) UpdatePlan(plan, diff string, latest *plans.Summary) (string, error) {
+	fm, body, err := plans.ParseFrontMatter(plan)
+	if err != nil {
+		return "", err
+	}
+	diff = g.prepareDiff(diff)
+	if len(diff) > maxPlanDiffLen {
+		diff = diff[:maxPlanDiffLen] + "\n... (truncated)"
+	}
+
+	rotation := ""
+	if latest != nil {
+		var b strings.Builder
+		fmt.Fprintf(&b, "\nLatest rotation summary:\nTL;DR: %s\n", latest.TLDR)
+		for _, change := range latest.Changes {
+			fmt.Fprintf(&b, "- %s\n", change)
+		}
+		if len(latest.NextSteps) > 0 {
+			fmt.Fprintf(&b, "Next steps: %s\n", strings.Join(latest.NextSteps, "; "))
+		}
+		if latest.DriverNote != "" {
+			fmt.Fprintf(&b, "Driver note: %s\n", latest.DriverNote)
+		}
+		rotation = b.String()
+	}
+
+	prompt := fmt.Sprintf(`You maintain the markdown plan for a mob programming session. Update it to
+match the work done so far.
+
+Current plan:
+%s
+%s
+Git diff of the branch:
+%s
+
+Rules:
+- Check off ("- [x]") tasks the diff or summary shows are finished; never uncheck a task
+- Add newly discovered tasks as unchecked items in the task list
+- Append decisions the work made to the "Decisions Made" section, creating it if missing
+- Keep every other line, heading, and task number reference unchanged
+
+Respond ONLY with the full updated plan in markdown, no code fences or explanation.`, body, rotation, diff)
+
+	updated, err := g.callClaudeForPlan(prompt)
+	if err != nil {
+		return "", err
+	}
+	return plans.WithFrontMatter(fm, updated)
+}
+
+// DraftPlan asks Claude to draft a goal and task list for a new plan from
+// the branch name, its linked ticket (if any), and the recent commit log,
+// following the structure of template (an already rendered plan template)
+func (g *Generator) DraftPlan(branch, ticket, commits, template string) (string, error) {
+	if len(commits) > maxPlanDiffLen {
+		commits = commits[:maxPlanDiffLen] + "\n... (truncated)"
+	}
+	if ticket == "" {
+		ticket = "(none)"
+	}
+
+	prompt := fmt.Sprintf(`You are drafting the markdown plan for a new mob programming session.
+
+Branch: %s
+Linked ticket: %s
+
+Recent commits:
+%s
+
+Plan template:
+%s
+
+Rules:
+- Follow the template's headings and keep its lines outside the goal and task list
+- Replace the goal placeholder with one or two sentences inferred from the branch, ticket, and commits
+- Replace the placeholder tasks with 3-8 concrete unchecked tasks ("- [ ] ...") for the session
+- Don't invent details the inputs don't support; if the goal is unclear, add a task to clarify it
+
+Respond ONLY with the full plan in markdown, no code fences or explanation.`, branch, ticket, commits, template)
+
+	return g.callClaudeForPlan(prompt)
+}
+
+// callClaudeForPlan sends a plan prompt and returns the markdown Claude
+// answers with, stripped of any code fences
+func (g *Generator) callClaudeForPlan(prompt string) (string, error) {
+	response, err := g.callClaude(prompt)
+	if err != nil {
+		return "", err
+	}
+	plan := strings.TrimSpace(response)
+	plan = strings.TrimPrefix(plan, "```markdown")
+	plan = strings.TrimPrefix(plan, "```")
+	plan = strings.TrimSpace(strings.TrimSuffix(plan, "```"))
+	if plan == "" {
+		return "", fmt.Errorf("claude returned an empty plan")
+	}
+	return plan + "\n", nil
+}
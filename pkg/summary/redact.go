@@ -0,0 +1,101 @@
+package summary
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// secretPattern finds one kind of secret. When the regexp has a capture
+// group, only the first group is replaced, so "password = hunter22" keeps
+// its key and loses its value.
+type secretPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// builtinSecretPatterns are the secrets redacted before a diff is sent to
+// Claude. More specific patterns come first, so a key gets its own name
+// rather than a generic one.
+var builtinSecretPatterns = []secretPattern{
+	{"private-key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?:[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----|[\s\S]*)`)},
+	{"aws-access-key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"aws-secret-key", regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`)},
+	{"age-secret-key", regexp.MustCompile(`AGE-SECRET-KEY-1[0-9A-Z]{58}`)},
+	{"github-token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"anthropic-api-key", regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`)},
+	{"openai-api-key", regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9_-]{20,}`)},
+	{"stripe-key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{20,}`)},
+	{"google-api-key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"slack-token", regexp.MustCompile(`\bxox[aboprs]-[A-Za-z0-9-]{10,}`)},
+	{"slack-webhook", regexp.MustCompile(`https://hooks\.slack\.com/services/[A-Za-z0-9/]+`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"url-password", regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:([^/\s:@]{3,})@`)},
+	{"secret-assignment", regexp.MustCompile(`(?i)(?:api[_-]?key|secret|token|passw(?:or)?d)["']?\s*[:=]\s*["']([^"'\s]{8,})["']`)},
+}
+
+// Redaction counts the secrets of one kind replaced in a diff
+type Redaction struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Redactor replaces secrets in text with [REDACTED:<kind>] placeholders
+type Redactor struct {
+	patterns []secretPattern
+}
+
+// NewRedactor creates a redactor for the built-in secret patterns plus
+// extra regexps, whose matches are reported as "custom"
+func NewRedactor(extra []string) (*Redactor, error) {
+	r := &Redactor{patterns: append([]secretPattern(nil), builtinSecretPatterns...)}
+	for _, pattern := range extra {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, secretPattern{"custom", re})
+	}
+	return r, nil
+}
+
+// Redact returns text with every secret replaced, and what was replaced,
+// sorted by kind. The secrets themselves are never reported.
+func (r *Redactor) Redact(text string) (string, []Redaction) {
+	counts := map[string]int{}
+	for _, p := range r.patterns {
+		placeholder := "[REDACTED:" + p.name + "]"
+		text = p.re.ReplaceAllStringFunc(text, func(match string) string {
+			// Keep what's around the secret, e.g. the key it's assigned to
+			start, end := 0, len(match)
+			if p.re.NumSubexp() > 0 {
+				if loc := p.re.FindStringSubmatchIndex(match); loc != nil && loc[2] >= 0 {
+					start, end = loc[2], loc[3]
+				}
+			}
+			// An earlier, more specific pattern already got it
+			if strings.HasPrefix(match[start:end], "[REDACTED:") {
+				return match
+			}
+			counts[p.name]++
+			return match[:start] + placeholder + match[end:]
+		})
+	}
+
+	redactions := make([]Redaction, 0, len(counts))
+	for name, n := range counts {
+		redactions = append(redactions, Redaction{Name: name, Count: n})
+	}
+	sort.Slice(redactions, func(i, j int) bool { return redactions[i].Name < redactions[j].Name })
+	return text, redactions
+}
+
+// FormatRedactions lists redactions as "aws-access-key (1), custom (2)"
+func FormatRedactions(redactions []Redaction) string {
+	parts := make([]string, len(redactions))
+	for i, r := range redactions {
+		parts[i] = fmt.Sprintf("%s (%d)", r.Name, r.Count)
+	}
+	return strings.Join(parts, ", ")
+}
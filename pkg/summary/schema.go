@@ -0,0 +1,137 @@
+package summary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mob-claude/mob-claude/pkg/plans"
+)
+
+// SchemaVersion is the version of the GeneratedSummary schema. It moves
+// with the saved summary format, see plans.SummarySchemaVersion.
+const SchemaVersion = plans.SummarySchemaVersion
+
+// schemaNode is the subset of JSON Schema the summary schema is written in
+type schemaNode struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type"`
+	Properties  map[string]*schemaNode `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Items       *schemaNode            `json:"items,omitempty"`
+	MinItems    int                    `json:"minItems,omitempty"`
+	MaxItems    int                    `json:"maxItems,omitempty"`
+	MinLength   int                    `json:"minLength,omitempty"`
+	MaxLength   int                    `json:"maxLength,omitempty"`
+}
+
+// nonEmptyString is a string schema that needs at least one character
+var nonEmptyString = &schemaNode{Type: "string", MinLength: 1}
+
+// schema describes the JSON Claude returns for a summary
+var schema = &schemaNode{
+	Schema: "https://json-schema.org/draft/2020-12/schema",
+	Title:  fmt.Sprintf("mob-claude rotation summary, version %d", SchemaVersion),
+	Type:   "object",
+	Properties: map[string]*schemaNode{
+		"tldr": {
+			Type:        "string",
+			Description: "One sentence summary of what was accomplished",
+			MinLength:   1,
+			MaxLength:   100,
+		},
+		"changes": {
+			Type:        "array",
+			Description: "Specific changes made",
+			Items:       nonEmptyString,
+			MinItems:    2,
+			MaxItems:    4,
+		},
+		"nextSteps": {
+			Type:        "array",
+			Description: "Suggested next steps for the next driver",
+			Items:       nonEmptyString,
+			MinItems:    1,
+			MaxItems:    3,
+		},
+	},
+	Required: []string{"tldr", "changes", "nextSteps"},
+}
+
+// JSONSchema returns the JSON Schema of the summary Claude is asked for
+func JSONSchema() string {
+	data, _ := json.MarshalIndent(schema, "", "  ")
+	return string(data)
+}
+
+// ValidateSchema checks a JSON document against the summary schema and
+// returns the problems found, each naming the offending field
+func ValidateSchema(data []byte) []string {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []string{fmt.Sprintf("invalid JSON: %v", err)}
+	}
+	return schema.validate("", value)
+}
+
+// validate checks value against the node; path locates it for messages
+func (n *schemaNode) validate(path string, value interface{}) []string {
+	name := path
+	if name == "" {
+		name = "response"
+	}
+	var problems []string
+	switch n.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an object", name)}
+		}
+		// Every property is required, so going by Required checks them all
+		// in a fixed order
+		for _, key := range n.Required {
+			v, ok := obj[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s is missing", joinPath(path, key)))
+				continue
+			}
+			problems = append(problems, n.Properties[key].validate(joinPath(path, key), v)...)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an array", name)}
+		}
+		if len(items) < n.MinItems || (n.MaxItems > 0 && len(items) > n.MaxItems) {
+			problems = append(problems, fmt.Sprintf("%s has %d entries, expected %d-%d", name, len(items), n.MinItems, n.MaxItems))
+		}
+		if n.Items != nil {
+			for i, item := range items {
+				problems = append(problems, n.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s must be a string", name)}
+		}
+		length := utf8.RuneCountInString(strings.TrimSpace(s))
+		if length < n.MinLength {
+			problems = append(problems, fmt.Sprintf("%s is empty", name))
+		}
+		if n.MaxLength > 0 && length > n.MaxLength {
+			problems = append(problems, fmt.Sprintf("%s is longer than %d characters", name, n.MaxLength))
+		}
+	}
+	return problems
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}